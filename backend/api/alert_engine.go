@@ -0,0 +1,504 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+)
+
+const (
+	// maxAlertDeliveries - Πόσες αποστολές κρατά το /api/alerts/events
+	maxAlertDeliveries = 100
+	// alertQueueSize - Alerts σε αναμονή αποστολής· όταν γεμίσει, τα νέα σημειώνονται failed
+	alertQueueSize = 256
+	// alertWorkers - Παράλληλες αποστολές webhooks
+	alertWorkers = 4
+	// alertWebhookTimeout - Όριο ενός HTTP POST προς webhook
+	alertWebhookTimeout = 10 * time.Second
+	// alertDialTimeout - Όριο της σύνδεσης TCP προς webhook
+	alertDialTimeout = 5 * time.Second
+)
+
+// AlertRuleStore - Μόνιμη αποθήκευση των κανόνων (βλ. storage.FileAlertStore)
+type AlertRuleStore interface {
+	LoadAlertRules() ([]types.AlertRule, error)
+	SaveAlertRules(rules []types.AlertRule) error
+}
+
+// alertSample - Μια τιμή του θέματος ενός κανόνα σε έναν κύκλο συλλογής
+type alertSample struct {
+	at    time.Time
+	value float64
+}
+
+// alertRuleState - Ό,τι θυμάται ο engine για έναν κανόνα ανάμεσα στους κύκλους
+type alertRuleState struct {
+	samples []alertSample // price_change / tvl_drop: οι τιμές μέσα στο παράθυρο
+	last    *alertSample  // price_cross: η τιμή του προηγούμενου κύκλου
+	armed   bool          // price_change / tvl_drop: η συνθήκη έπαψε να ισχύει μετά το τελευταίο alert
+}
+
+// AlertEngine - Αξιολογεί τους κανόνες σε κάθε ανανέωση τιμών και στέλνει τα alerts σε
+// webhooks. Κάθε κανόνας ενεργοποιείται στη μετάβαση της συνθήκης (όχι σε κάθε κύκλο που
+// ισχύει) και όχι πιο συχνά από το cooldown του.
+type AlertEngine struct {
+	store  AlertRuleStore
+	client *http.Client
+	retry  RetryPolicy
+
+	webhookMu sync.RWMutex
+	allowlist types.WebhookAllowlist // Εσωτερικοί hosts που δέχονται webhooks
+	secret    []byte                 // Κλειδί του X-Alert-Signature (κενό = χωρίς υπογραφή)
+
+	mu         sync.Mutex
+	rules      map[string]*types.AlertRule
+	state      map[string]*alertRuleState
+	deliveries []*types.AlertDelivery // Οι πιο πρόσφατες πρώτες
+	queue      chan *types.AlertDelivery
+}
+
+// NewAlertEngine φορτώνει τους κανόνες του store. Οι αποστολές ξεκινούν με το Start.
+func NewAlertEngine(store AlertRuleStore) (*AlertEngine, error) {
+	rules, err := store.LoadAlertRules()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &AlertEngine{
+		store: store,
+		retry: RetryPolicy{
+			MaxRetries: 4,
+			BaseDelay:  time.Second,
+			MaxDelay:   30 * time.Second,
+		},
+		rules: make(map[string]*types.AlertRule, len(rules)),
+		state: make(map[string]*alertRuleState),
+		queue: make(chan *types.AlertDelivery, alertQueueSize),
+	}
+	for i := range rules {
+		rule := rules[i]
+		e.rules[rule.ID] = &rule
+	}
+
+	// Χωρίς proxy: ο έλεγχος της IP κατά τη σύνδεση πρέπει να αφορά το ίδιο το webhook
+	e.client = &http.Client{
+		Timeout:   alertWebhookTimeout,
+		Transport: &http.Transport{DialContext: e.dialWebhook},
+	}
+	return e, nil
+}
+
+// SetWebhookAllowlist - Hostnames, IPs ή CIDR εσωτερικού δικτύου που επιτρέπονται ως
+// webhooks (κενό = κανένα)
+func (e *AlertEngine) SetWebhookAllowlist(hosts []string) {
+	e.webhookMu.Lock()
+	defer e.webhookMu.Unlock()
+
+	e.allowlist = append(types.WebhookAllowlist(nil), hosts...)
+}
+
+// SetWebhookSecret - Κλειδί για την HMAC-SHA256 υπογραφή του σώματος κάθε webhook
+// (header X-Alert-Signature: sha256=<hex>)· κενό = χωρίς υπογραφή
+func (e *AlertEngine) SetWebhookSecret(secret string) {
+	e.webhookMu.Lock()
+	defer e.webhookMu.Unlock()
+
+	e.secret = []byte(secret)
+}
+
+// webhookConfig - Allowlist και κλειδί υπογραφής των webhooks
+func (e *AlertEngine) webhookConfig() (types.WebhookAllowlist, []byte) {
+	e.webhookMu.RLock()
+	defer e.webhookMu.RUnlock()
+	return e.allowlist, e.secret
+}
+
+// Start ξεκινά τους workers που στέλνουν τα webhooks, μέχρι την ακύρωση του ctx
+func (e *AlertEngine) Start(ctx context.Context) {
+	for i := 0; i < alertWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case delivery := <-e.queue:
+					e.deliver(ctx, delivery)
+				}
+			}
+		}()
+	}
+}
+
+// Rules - Όλοι οι κανόνες, με σειρά δημιουργίας
+func (e *AlertEngine) Rules() []types.AlertRule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.sortedRules()
+}
+
+// sortedRules - Αντίγραφα των κανόνων (καλείται με κλειδωμένο το mu)
+func (e *AlertEngine) sortedRules() []types.AlertRule {
+	rules := make([]types.AlertRule, 0, len(e.rules))
+	for _, rule := range e.rules {
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if !rules[i].CreatedAt.Equal(rules[j].CreatedAt) {
+			return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// Rule - Ένας κανόνας με το ID του
+func (e *AlertEngine) Rule(id string) (*types.AlertRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rule, ok := e.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: ο κανόνας %s δεν υπάρχει", ErrNotFound, id)
+	}
+	result := *rule
+	return &result, nil
+}
+
+// CreateRule - Νέος κανόνας με νέο ID· αποθηκεύεται αμέσως στο store
+func (e *AlertEngine) CreateRule(rule types.AlertRule) (*types.AlertRule, error) {
+	allowlist, _ := e.webhookConfig()
+	if err := rule.Validate(allowlist); err != nil {
+		return nil, err
+	}
+	id, err := newAlertID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rule.ID = id
+	rule.CreatedAt, rule.UpdatedAt = now, now
+	rule.LastTriggeredAt = nil
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rules[rule.ID] = &rule
+	if err := e.persist(); err != nil {
+		delete(e.rules, rule.ID)
+		return nil, err
+	}
+	result := rule
+	return &result, nil
+}
+
+// UpdateRule αντικαθιστά έναν κανόνα (κρατώντας ID και CreatedAt). Η κατάσταση του κανόνα
+// μηδενίζεται, ώστε το νέο όριο να μη συγκρίνεται με δείγματα του παλιού.
+func (e *AlertEngine) UpdateRule(id string, rule types.AlertRule) (*types.AlertRule, error) {
+	allowlist, _ := e.webhookConfig()
+	if err := rule.Validate(allowlist); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	existing, ok := e.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: ο κανόνας %s δεν υπάρχει", ErrNotFound, id)
+	}
+	previous := *existing
+
+	rule.ID = id
+	rule.CreatedAt = previous.CreatedAt
+	rule.UpdatedAt = time.Now().UTC()
+	rule.LastTriggeredAt = previous.LastTriggeredAt
+
+	e.rules[id] = &rule
+	if err := e.persist(); err != nil {
+		e.rules[id] = &previous
+		return nil, err
+	}
+	delete(e.state, id)
+
+	result := rule
+	return &result, nil
+}
+
+// DeleteRule - Διαγραφή κανόνα
+func (e *AlertEngine) DeleteRule(id string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rule, ok := e.rules[id]
+	if !ok {
+		return fmt.Errorf("%w: ο κανόνας %s δεν υπάρχει", ErrNotFound, id)
+	}
+
+	delete(e.rules, id)
+	if err := e.persist(); err != nil {
+		e.rules[id] = rule
+		return err
+	}
+	delete(e.state, id)
+	return nil
+}
+
+// Deliveries - Οι πιο πρόσφατες αποστολές (έως maxAlertDeliveries), οι νεότερες πρώτες
+func (e *AlertEngine) Deliveries() []types.AlertDelivery {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]types.AlertDelivery, len(e.deliveries))
+	for i, delivery := range e.deliveries {
+		result[i] = *delivery
+	}
+	return result
+}
+
+// persist - Αποθήκευση όλων των κανόνων (καλείται με κλειδωμένο το mu)
+func (e *AlertEngine) persist() error {
+	return e.store.SaveAlertRules(e.sortedRules())
+}
+
+// Evaluate ελέγχει τους κανόνες μιας αλυσίδας με τις τιμές ενός κύκλου συλλογής και βάζει
+// στην ουρά αποστολής όσα alerts ενεργοποιήθηκαν
+func (e *AlertEngine) Evaluate(chain string, poolPrices []types.PoolPrice, tokenPrices []types.TokenPrice) []types.AlertEvent {
+	now := time.Now().UTC()
+
+	e.mu.Lock()
+	var fired []types.AlertEvent
+	var queued []*types.AlertDelivery
+	for _, rule := range e.rules {
+		if rule.Disabled || rule.ChainName() != chain {
+			continue
+		}
+		value, subject, ok := alertSubjectValue(*rule, poolPrices, tokenPrices)
+		if !ok {
+			continue
+		}
+
+		state, exists := e.state[rule.ID]
+		if !exists {
+			state = &alertRuleState{armed: true}
+			e.state[rule.ID] = state
+		}
+
+		coolingDown := rule.LastTriggeredAt != nil && now.Sub(*rule.LastTriggeredAt) < rule.CooldownDuration()
+		event, ok := evaluateAlertRule(*rule, state, subject, value, now, coolingDown)
+		if !ok {
+			continue
+		}
+
+		id, err := newAlertID()
+		if err != nil {
+			continue
+		}
+		event.ID = id
+		event.Chain = chain
+		triggeredAt := now
+		rule.LastTriggeredAt = &triggeredAt
+
+		delivery := &types.AlertDelivery{Event: event, WebhookURL: rule.WebhookURL, Status: types.AlertDeliveryPending}
+		e.deliveries = append([]*types.AlertDelivery{delivery}, e.deliveries...)
+		if len(e.deliveries) > maxAlertDeliveries {
+			e.deliveries = e.deliveries[:maxAlertDeliveries]
+		}
+		fired = append(fired, event)
+		queued = append(queued, delivery)
+	}
+
+	if len(fired) > 0 {
+		// Το LastTriggeredAt αποθηκεύεται ώστε το cooldown να ισχύει και μετά από restart
+		if err := e.persist(); err != nil {
+			log.Printf("⚠️  Αποτυχία αποθήκευσης κανόνων alerts: %v", err)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, delivery := range queued {
+		log.Printf("🔔 Alert %s: %s", delivery.Event.RuleID, delivery.Event.Message)
+		select {
+		case e.queue <- delivery:
+		default:
+			e.mu.Lock()
+			delivery.Status = types.AlertDeliveryFailed
+			delivery.LastError = "η ουρά αποστολής είναι γεμάτη"
+			e.mu.Unlock()
+		}
+	}
+	return fired
+}
+
+// evaluateAlertRule - Ενημερώνει την κατάσταση του κανόνα με τη νέα τιμή και επιστρέφει
+// alert αν η συνθήκη μόλις ενεργοποιήθηκε. Σε cooldown το alert δεν στέλνεται αλλά ούτε
+// καταναλώνεται: η διάβαση ή ο armed κανόνας ενεργοποιούνται στον πρώτο κύκλο μετά το
+// cooldown, αν η συνθήκη ισχύει ακόμη.
+func evaluateAlertRule(rule types.AlertRule, state *alertRuleState, subject string, value float64, now time.Time, coolingDown bool) (types.AlertEvent, bool) {
+	event := types.AlertEvent{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		Kind:        rule.Kind,
+		Subject:     subject,
+		Value:       value,
+		Threshold:   rule.Threshold,
+		TriggeredAt: now,
+	}
+
+	switch rule.Kind {
+	case types.AlertKindPriceCross:
+		previous := state.last
+		current := &alertSample{at: now, value: value}
+		if previous == nil {
+			state.last = current
+			return event, false // Χρειάζεται μια προηγούμενη τιμή για να υπάρξει διάβαση
+		}
+
+		up := previous.value < rule.Threshold && value >= rule.Threshold
+		down := previous.value > rule.Threshold && value <= rule.Threshold
+		switch {
+		case up && rule.Direction != types.AlertDirectionBelow:
+			event.Message = fmt.Sprintf("%s crossed above %g (%g → %g)", subject, rule.Threshold, previous.value, value)
+		case down && rule.Direction != types.AlertDirectionAbove:
+			event.Message = fmt.Sprintf("%s crossed below %g (%g → %g)", subject, rule.Threshold, previous.value, value)
+		default:
+			state.last = current
+			return event, false
+		}
+		if coolingDown {
+			return event, false // Το state.last μένει πριν τη διάβαση
+		}
+		state.last = current
+		event.Reference = previous.value
+		event.Change = value/previous.value - 1
+		return event, true
+
+	case types.AlertKindPriceChange, types.AlertKindTVLDrop:
+		window := rule.WindowDuration()
+		samples := state.samples[:0]
+		for _, sample := range state.samples {
+			if now.Sub(sample.at) <= window {
+				samples = append(samples, sample)
+			}
+		}
+		state.samples = append(samples, alertSample{at: now, value: value})
+
+		// price_change: σε σχέση με την παλαιότερη τιμή του παραθύρου· tvl_drop: με το μέγιστο
+		reference := state.samples[0].value
+		if rule.Kind == types.AlertKindTVLDrop {
+			for _, sample := range state.samples {
+				reference = math.Max(reference, sample.value)
+			}
+		}
+		change := value/reference - 1
+
+		var triggered bool
+		switch {
+		case rule.Kind == types.AlertKindTVLDrop:
+			triggered = -change >= rule.Threshold
+		case rule.Direction == types.AlertDirectionUp:
+			triggered = change >= rule.Threshold
+		case rule.Direction == types.AlertDirectionDown:
+			triggered = -change >= rule.Threshold
+		default:
+			triggered = math.Abs(change) >= rule.Threshold
+		}
+
+		if !triggered {
+			state.armed = true
+			return event, false
+		}
+		if !state.armed || coolingDown {
+			return event, false // Η συνθήκη ισχύει ήδη από το προηγούμενο alert ή είμαστε σε cooldown
+		}
+		state.armed = false
+
+		event.Reference = reference
+		event.Change = change
+		if rule.Kind == types.AlertKindTVLDrop {
+			event.Message = fmt.Sprintf("%s dropped %.2f%% from its %s high ($%.2f → $%.2f)", subject, -change*100, window, reference, value)
+		} else {
+			event.Message = fmt.Sprintf("%s moved %+.2f%% in %s (%g → %g)", subject, change*100, window, reference, value)
+		}
+		return event, true
+	}
+
+	return event, false
+}
+
+// alertSubjectValue - Η τρέχουσα τιμή που παρακολουθεί ο κανόνας
+func alertSubjectValue(rule types.AlertRule, poolPrices []types.PoolPrice, tokenPrices []types.TokenPrice) (float64, string, bool) {
+	if rule.PoolID != "" {
+		price, ok := findAlertPool(rule.PoolID, poolPrices)
+		if !ok {
+			return 0, "", false
+		}
+		poolName := fmt.Sprintf("pool %s %s/%s", rule.PoolID, price.Token0Symbol, price.Token1Symbol)
+
+		if rule.Kind == types.AlertKindTVLDrop {
+			return price.LiquidityUSD, poolName + " TVL", validRate(price.LiquidityUSD)
+		}
+		switch {
+		case rule.Token == "" || matchesAlertToken(rule.Token, price.Token0Symbol, price.Token0Denom):
+			return price.PriceToken0ToToken1, poolName, validRate(price.PriceToken0ToToken1)
+		case matchesAlertToken(rule.Token, price.Token1Symbol, price.Token1Denom):
+			name := fmt.Sprintf("pool %s %s/%s", rule.PoolID, price.Token1Symbol, price.Token0Symbol)
+			return price.PriceToken1ToToken0, name, validRate(price.PriceToken1ToToken0)
+		default:
+			return 0, "", false
+		}
+	}
+
+	// Χωρίς pool: τιμή USD, από το token με τη μεγαλύτερη liquidity αν το symbol είναι διπλό
+	var best *types.TokenPrice
+	for i := range tokenPrices {
+		price := &tokenPrices[i]
+		if matchesAlertToken(rule.Token, price.Symbol, price.Denom) && (best == nil || price.LiquidityUSD > best.LiquidityUSD) {
+			best = price
+		}
+	}
+	if best == nil {
+		return 0, "", false
+	}
+	return best.PriceUSD, best.Symbol + "/USD", validRate(best.PriceUSD)
+}
+
+// findAlertPool - Pair id ("1", "1:0-2") ή on-chain id (το πρώτο pair του pool)
+func findAlertPool(poolID string, poolPrices []types.PoolPrice) (types.PoolPrice, bool) {
+	var fallback *types.PoolPrice
+	for i := range poolPrices {
+		if poolPrices[i].PoolID == poolID {
+			return poolPrices[i], true
+		}
+		if fallback == nil && poolPrices[i].OnChainPoolID() == poolID {
+			fallback = &poolPrices[i]
+		}
+	}
+	if fallback == nil {
+		return types.PoolPrice{}, false
+	}
+	return *fallback, true
+}
+
+func matchesAlertToken(token, symbol, denom string) bool {
+	return token == denom || strings.EqualFold(token, symbol)
+}
+
+// newAlertID - Τυχαίο ID 16 hex χαρακτήρων για κανόνες και alerts
+func newAlertID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("αποτυχία δημιουργίας ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
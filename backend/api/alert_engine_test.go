@@ -0,0 +1,102 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// memoryAlertStore - AlertRuleStore στη μνήμη
+type memoryAlertStore struct {
+	rules []types.AlertRule
+}
+
+func (s *memoryAlertStore) LoadAlertRules() ([]types.AlertRule, error) {
+	return append([]types.AlertRule(nil), s.rules...), nil
+}
+
+func (s *memoryAlertStore) SaveAlertRules(rules []types.AlertRule) error {
+	s.rules = append([]types.AlertRule(nil), rules...)
+	return nil
+}
+
+func TestEvaluateAlertRuleCooldownKeepsCrossing(t *testing.T) {
+	rule := types.AlertRule{ID: "r", Kind: types.AlertKindPriceCross, Token: "ATOM", Threshold: 10}
+	state := &alertRuleState{armed: true}
+	start := time.Now()
+
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 9, start, false); ok {
+		t.Fatal("first sample fired")
+	}
+	// Η διάβαση γίνεται μέσα στο cooldown: δεν στέλνεται, αλλά δεν χάνεται
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 11, start.Add(time.Minute), true); ok {
+		t.Fatal("crossing fired during the cooldown")
+	}
+	event, ok := evaluateAlertRule(rule, state, "ATOM/USD", 12, start.Add(2*time.Minute), false)
+	if !ok {
+		t.Fatal("crossing suppressed by the cooldown never fired")
+	}
+	if event.Reference != 9 {
+		t.Errorf("reference = %v, want the value before the crossing (9)", event.Reference)
+	}
+
+	// Μετά το alert η τιμή πρέπει να ξαναπεράσει το όριο
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 13, start.Add(3*time.Minute), false); ok {
+		t.Error("fired again without a new crossing")
+	}
+}
+
+func TestEvaluateAlertRuleCooldownKeepsArmed(t *testing.T) {
+	rule := types.AlertRule{ID: "r", Kind: types.AlertKindPriceChange, Token: "ATOM", Threshold: 0.05, Window: "10m"}
+	state := &alertRuleState{armed: true}
+	start := time.Now()
+
+	evaluateAlertRule(rule, state, "ATOM/USD", 100, start, false)
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 110, start.Add(time.Minute), true); ok {
+		t.Fatal("change fired during the cooldown")
+	}
+	if !state.armed {
+		t.Fatal("the cooldown disarmed the rule")
+	}
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 111, start.Add(2*time.Minute), false); !ok {
+		t.Fatal("change still above the threshold did not fire after the cooldown")
+	}
+	if _, ok := evaluateAlertRule(rule, state, "ATOM/USD", 112, start.Add(3*time.Minute), false); ok {
+		t.Error("fired twice for the same move")
+	}
+}
+
+func TestAlertEngineCooldown(t *testing.T) {
+	recent := time.Now().UTC().Add(-time.Minute)
+	store := &memoryAlertStore{rules: []types.AlertRule{{
+		ID: "r", Kind: types.AlertKindPriceCross, Token: "ATOM", Threshold: 10,
+		Cooldown: "15m", WebhookURL: "https://example.com/hook", LastTriggeredAt: &recent,
+	}}}
+	engine, err := NewAlertEngine(store)
+	if err != nil {
+		t.Fatalf("NewAlertEngine: %v", err)
+	}
+
+	prices := func(usd float64) []types.TokenPrice {
+		return []types.TokenPrice{{Denom: AtomDenom, Symbol: "ATOM", PriceUSD: usd}}
+	}
+	engine.Evaluate(types.DefaultChain, nil, prices(9))
+	if fired := engine.Evaluate(types.DefaultChain, nil, prices(11)); len(fired) != 0 {
+		t.Fatalf("fired %d alerts during the cooldown", len(fired))
+	}
+
+	// Το cooldown λήγει ενώ η τιμή είναι ακόμη πάνω από το όριο
+	expired := time.Now().UTC().Add(-time.Hour)
+	engine.mu.Lock()
+	engine.rules["r"].LastTriggeredAt = &expired
+	engine.mu.Unlock()
+
+	fired := engine.Evaluate(types.DefaultChain, nil, prices(11))
+	if len(fired) != 1 || fired[0].Reference != 9 {
+		t.Fatalf("fired %+v after the cooldown, want one crossing from 9", fired)
+	}
+	if store.rules[0].LastTriggeredAt == nil || !store.rules[0].LastTriggeredAt.After(expired) {
+		t.Error("LastTriggeredAt was not persisted")
+	}
+}
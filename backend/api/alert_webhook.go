@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// deliver στέλνει ένα alert στο webhook του με retries. Όλες οι προσπάθειες έχουν το ίδιο
+// X-Alert-Event-ID, ώστε ο receiver να αγνοεί διπλές παραλαβές (π.χ. μετά από timeout).
+func (e *AlertEngine) deliver(ctx context.Context, delivery *types.AlertDelivery) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		e.finishDelivery(delivery, 0, fmt.Errorf("σφάλμα κατά το encoding του alert: %w", err))
+		return
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= e.retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				e.finishDelivery(delivery, attempt, ctx.Err())
+				return
+			case <-time.After(e.retry.backoff(attempt - 1)):
+			}
+		}
+
+		retryable, err := e.postWebhook(ctx, delivery, body)
+		e.mu.Lock()
+		delivery.Attempts = attempt + 1
+		e.mu.Unlock()
+		if err == nil {
+			e.finishDelivery(delivery, attempt+1, nil)
+			return
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+
+	e.finishDelivery(delivery, 0, lastErr)
+}
+
+// postWebhook - Ένα POST· 2xx σημαίνει επιτυχία. Τα 4xx (εκτός από 408/429) δεν
+// επαναλαμβάνονται, γιατί το ίδιο request θα απορριφθεί ξανά.
+func (e *AlertEngine) postWebhook(ctx context.Context, delivery *types.AlertDelivery, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "portofoliov1-alerts")
+	req.Header.Set("X-Alert-Event-ID", delivery.Event.ID)
+	req.Header.Set("X-Alert-Rule-ID", delivery.Event.RuleID)
+	if _, secret := e.webhookConfig(); len(secret) > 0 {
+		req.Header.Set("X-Alert-Signature", signWebhookBody(secret, body))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("το webhook απάντησε %s", resp.Status)
+	default:
+		return false, fmt.Errorf("το webhook απάντησε %s", resp.Status)
+	}
+}
+
+// finishDelivery - Τελική κατάσταση μιας αποστολής (attempts 0 = αμετάβλητο)
+func (e *AlertEngine) finishDelivery(delivery *types.AlertDelivery, attempts int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if attempts > 0 {
+		delivery.Attempts = attempts
+	}
+	if err != nil {
+		delivery.Status = types.AlertDeliveryFailed
+		delivery.LastError = err.Error()
+		return
+	}
+	now := time.Now().UTC()
+	delivery.Status = types.AlertDeliveryDelivered
+	delivery.LastError = ""
+	delivery.DeliveredAt = &now
+}
+
+// signWebhookBody - "sha256=<hex HMAC-SHA256 του σώματος>", όπως το επαληθεύει ο receiver
+// με το ίδιο κλειδί
+func signWebhookBody(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// dialWebhook - Σύνδεση προς webhook που απορρίπτει εσωτερικές IPs μετά την επίλυση του
+// hostname, ώστε ένα όνομα που επιλύεται σε ιδιωτική διεύθυνση (ή ένα redirect προς αυτή)
+// να μη φτάνει σε υπηρεσίες του τοπικού δικτύου. Hosts του allowlist εξαιρούνται.
+func (e *AlertEngine) dialWebhook(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	allowlist, _ := e.webhookConfig()
+	hostAllowed := allowlist.AllowsHost(host)
+
+	dialer := &net.Dialer{
+		Timeout: alertDialTimeout,
+		Control: func(network, resolved string, _ syscall.RawConn) error {
+			ipText, _, err := net.SplitHostPort(resolved)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(ipText)
+			if ip == nil {
+				return fmt.Errorf("μη έγκυρη διεύθυνση webhook: %s", resolved)
+			}
+			if hostAllowed || allowlist.AllowsIP(ip) || !types.IsInternalIP(ip) {
+				return nil
+			}
+			return fmt.Errorf("το webhook %s επιλύεται σε εσωτερική διεύθυνση %s", host, ip)
+		},
+	}
+	return dialer.DialContext(ctx, network, address)
+}
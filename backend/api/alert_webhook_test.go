@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// webhookTestEngine - AlertEngine χωρίς κανόνες και χωρίς retries
+func webhookTestEngine(t *testing.T) *AlertEngine {
+	t.Helper()
+	engine, err := NewAlertEngine(&memoryAlertStore{})
+	if err != nil {
+		t.Fatalf("NewAlertEngine: %v", err)
+	}
+	engine.retry = RetryPolicy{}
+	return engine
+}
+
+func TestCreateRuleRejectsInternalWebhooks(t *testing.T) {
+	engine := webhookTestEngine(t)
+	rule := func(webhook string) types.AlertRule {
+		return types.AlertRule{Kind: types.AlertKindPriceCross, Token: "ATOM", Threshold: 10, WebhookURL: webhook}
+	}
+
+	tests := []struct {
+		webhook string
+		ok      bool
+	}{
+		{"https://hooks.example.com/alerts", true},
+		{"http://8.8.8.8/alerts", true},
+		{"http://127.0.0.1:9000/alerts", false},
+		{"http://localhost:9000/alerts", false},
+		{"http://api.localhost/alerts", false},
+		{"http://10.1.2.3/alerts", false},
+		{"http://192.168.0.1/alerts", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://0.0.0.0/alerts", false},
+		{"http://[::1]/alerts", false},
+		{"http://[fe80::1]/alerts", false},
+		{"http://[::ffff:127.0.0.1]/alerts", false},
+	}
+	for _, tt := range tests {
+		_, err := engine.CreateRule(rule(tt.webhook))
+		if tt.ok && err != nil {
+			t.Errorf("%s rejected: %v", tt.webhook, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("%s accepted", tt.webhook)
+		}
+	}
+
+	// Το allowlist ανοίγει μόνο ό,τι αναφέρει
+	engine.SetWebhookAllowlist([]string{"10.0.0.0/8", "alerts.localhost", "::1"})
+	for _, webhook := range []string{"http://10.1.2.3/alerts", "http://Alerts.Localhost/hook", "http://[::1]:9000/alerts"} {
+		if _, err := engine.CreateRule(rule(webhook)); err != nil {
+			t.Errorf("allowlisted %s rejected: %v", webhook, err)
+		}
+	}
+	for _, webhook := range []string{"http://127.0.0.1/alerts", "http://192.168.0.1/alerts", "http://localhost/alerts"} {
+		if _, err := engine.CreateRule(rule(webhook)); err == nil {
+			t.Errorf("%s accepted with an allowlist that does not cover it", webhook)
+		}
+	}
+}
+
+// webhookRecorder - httptest server που κρατά headers και σώμα κάθε POST
+type webhookRecorder struct {
+	mu      sync.Mutex
+	headers []http.Header
+	bodies  [][]byte
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.headers = append(r.headers, req.Header.Clone())
+	r.bodies = append(r.bodies, body)
+}
+
+func testDelivery(webhook string) *types.AlertDelivery {
+	return &types.AlertDelivery{
+		Event:      types.AlertEvent{ID: "event-1", RuleID: "rule-1", Kind: types.AlertKindPriceCross, Message: "ATOM/USD crossed above 10"},
+		WebhookURL: webhook,
+		Status:     types.AlertDeliveryPending,
+	}
+}
+
+func TestDeliverSignsWebhook(t *testing.T) {
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	engine := webhookTestEngine(t)
+	engine.SetWebhookAllowlist([]string{"127.0.0.1"})
+	engine.SetWebhookSecret("s3cret")
+
+	delivery := testDelivery(server.URL)
+	engine.deliver(context.Background(), delivery)
+	if delivery.Status != types.AlertDeliveryDelivered {
+		t.Fatalf("status = %s (%s), want delivered", delivery.Status, delivery.LastError)
+	}
+	if len(recorder.bodies) != 1 {
+		t.Fatalf("webhook received %d requests, want 1", len(recorder.bodies))
+	}
+
+	header := recorder.headers[0]
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(recorder.bodies[0])
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); header.Get("X-Alert-Signature") != want {
+		t.Errorf("X-Alert-Signature = %q, want %q", header.Get("X-Alert-Signature"), want)
+	}
+	if header.Get("X-Alert-Event-ID") != "event-1" || header.Get("X-Alert-Rule-ID") != "rule-1" {
+		t.Errorf("event/rule headers = %q/%q", header.Get("X-Alert-Event-ID"), header.Get("X-Alert-Rule-ID"))
+	}
+
+	// Χωρίς κλειδί δεν στέλνεται υπογραφή
+	engine.SetWebhookSecret("")
+	engine.deliver(context.Background(), testDelivery(server.URL))
+	if signature := recorder.headers[1].Get("X-Alert-Signature"); signature != "" {
+		t.Errorf("unsigned webhook carries X-Alert-Signature %q", signature)
+	}
+}
+
+func TestDeliverBlocksInternalAddresses(t *testing.T) {
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	// Το "localhost" επιλύεται σε 127.0.0.1 κατά τη σύνδεση, όπως ένα DNS όνομα που δείχνει
+	// σε εσωτερική διεύθυνση· ο κανόνας παρακάμπτει εδώ το Validate
+	webhook := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	engine := webhookTestEngine(t)
+
+	delivery := testDelivery(webhook)
+	engine.deliver(context.Background(), delivery)
+	if delivery.Status != types.AlertDeliveryFailed || !strings.Contains(delivery.LastError, "εσωτερική διεύθυνση") {
+		t.Errorf("status = %s (%s), want failed on the internal address", delivery.Status, delivery.LastError)
+	}
+
+	// Redirect από allowlisted hostname προς εσωτερική IP που δεν είναι στο allowlist
+	redirect := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+	engine.SetWebhookAllowlist([]string{"localhost"})
+	delivery = testDelivery(strings.Replace(redirect.URL, "127.0.0.1", "localhost", 1))
+	engine.deliver(context.Background(), delivery)
+	if delivery.Status != types.AlertDeliveryFailed {
+		t.Errorf("redirect to 127.0.0.1: status = %s, want failed", delivery.Status)
+	}
+
+	if len(recorder.bodies) != 0 {
+		t.Errorf("internal webhook received %d requests", len(recorder.bodies))
+	}
+}
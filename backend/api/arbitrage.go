@@ -0,0 +1,676 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+const (
+	// arbitrageEpsilon - Χαλαρώσεις μικρότερες από αυτό θεωρούνται θόρυβος του float
+	arbitrageEpsilon = 1e-12
+	// arbitrageSearchSteps - Επαναλήψεις του golden-section search για το μέγεθος του trade
+	arbitrageSearchSteps = 48
+	// arbitrageMaxTradeShare - Το trade δεν ξεπερνά αυτό το κλάσμα του reserve του πρώτου pool
+	arbitrageMaxTradeShare = 0.5
+)
+
+// ArbitrageConfig - Παράμετροι του ArbitrageDetector
+type ArbitrageConfig struct {
+	MinSpotReturn   float64 `json:"min_spot_return"`   // Ελάχιστη απόδοση του κύκλου μετά τα fees (0.001 = 0.1%)
+	MinLiquidityUSD float64 `json:"min_liquidity_usd"` // Pools με μικρότερη TVL δεν μπαίνουν στον γράφο
+	MaxHops         int     `json:"max_hops"`          // Μέγιστο μήκος κύκλου
+	MaxCycles       int     `json:"max_cycles"`        // Όριο κύκλων ανά αλυσίδα σε κάθε ενημέρωση
+}
+
+// DefaultArbitrageConfig - Κύκλοι έως 4 pools με απόδοση τουλάχιστον 0.1% και TVL >= $1000
+func DefaultArbitrageConfig() ArbitrageConfig {
+	return ArbitrageConfig{
+		MinSpotReturn:   0.001,
+		MinLiquidityUSD: 1000,
+		MaxHops:         4,
+		MaxCycles:       20,
+	}
+}
+
+// ArbitrageSnapshot - Τα δεδομένα ενός κύκλου συλλογής μιας αλυσίδας
+type ArbitrageSnapshot struct {
+	Chain       string
+	Pools       []types.OsmosisPool
+	PoolPrices  []types.PoolPrice // Με LiquidityUSD (μετά το PriceOracle.ApplyLiquidityUSD)
+	TokenPrices []types.TokenPrice
+	Assets      *types.AssetService
+	PriceModel  func(types.OsmosisPool) PriceModel // nil = PriceModelForPool
+}
+
+// arbitrageEdge - Μια κατεύθυνση ενός pool στον γράφο των tokens
+type arbitrageEdge struct {
+	from, to   int
+	weight     float64 // -ln(rate)
+	rate       float64 // Spot τιμή μετά το fee
+	fee        float64
+	pool       *types.OsmosisPool
+	fromDenom  string
+	toDenom    string
+	fromSymbol string
+	toSymbol   string
+}
+
+// FindArbitrage - Κύκλοι με κέρδος μετά τα fees στο snapshot μιας αλυσίδας.
+//
+// Κάθε pool γίνεται δύο ακμές με βάρος -ln(τιμή·(1-fee)), οπότε ένας κύκλος με γινόμενο
+// τιμών > 1 είναι κύκλος αρνητικού βάρους και τον βρίσκει ο Bellman-Ford. Το μέγεθος του
+// trade προκύπτει από την τοπική swap math κάθε pool (SimulateSwap): το κέρδος μειώνεται
+// με το price impact, οπότε αναζητούμε το μέγιστό του.
+func FindArbitrage(snapshot ArbitrageSnapshot, config ArbitrageConfig) []types.ArbitrageOpportunity {
+	if snapshot.PriceModel == nil {
+		snapshot.PriceModel = PriceModelForPool
+	}
+
+	edges, nodes := buildArbitrageGraph(snapshot, config)
+	if len(edges) == 0 {
+		return []types.ArbitrageOpportunity{}
+	}
+
+	tokenPrices := make(map[string]types.TokenPrice, len(snapshot.TokenPrices))
+	for _, price := range snapshot.TokenPrices {
+		tokenPrices[price.Denom] = price
+	}
+
+	opportunities := []types.ArbitrageOpportunity{}
+	for _, cycle := range findNegativeCycles(nodes, edges, config) {
+		hops := make([]arbitrageEdge, len(cycle))
+		for i, index := range cycle {
+			hops[i] = edges[index]
+		}
+		if reusesPool(hops) {
+			continue // Το δεύτερο swap στο ίδιο pool θα έβλεπε άλλα reserves
+		}
+
+		spotReturn := 1.0
+		for _, hop := range hops {
+			spotReturn *= hop.rate
+		}
+		spotReturn--
+		if spotReturn < config.MinSpotReturn {
+			continue
+		}
+
+		hops = rotateToPricedToken(hops, tokenPrices)
+		opportunity, ok := sizeArbitrageCycle(hops, snapshot, tokenPrices)
+		if !ok {
+			continue
+		}
+		opportunity.SpotReturn = spotReturn
+		opportunities = append(opportunities, opportunity)
+	}
+
+	sortArbitrageOpportunities(opportunities)
+	return opportunities
+}
+
+// buildArbitrageGraph - Ακμές token0 -> token1 και αντίστροφα για κάθε pool price με αρκετή
+// liquidity. Ανά κατεύθυνση κρατάμε το pool με την καλύτερη τιμή.
+func buildArbitrageGraph(snapshot ArbitrageSnapshot, config ArbitrageConfig) ([]arbitrageEdge, int) {
+	pools := make(map[string]*types.OsmosisPool, len(snapshot.Pools))
+	for i := range snapshot.Pools {
+		pools[snapshot.Pools[i].Id] = &snapshot.Pools[i]
+	}
+
+	nodes := make(map[string]int)
+	node := func(denom string) int {
+		if index, ok := nodes[denom]; ok {
+			return index
+		}
+		nodes[denom] = len(nodes)
+		return nodes[denom]
+	}
+
+	var edges []arbitrageEdge
+	best := make(map[[2]int]int) // (from, to) -> θέση στο edges
+
+	addEdge := func(edge arbitrageEdge) {
+		if !validRate(edge.rate) {
+			return
+		}
+		edge.weight = -math.Log(edge.rate)
+		key := [2]int{edge.from, edge.to}
+		if index, ok := best[key]; ok {
+			if edges[index].rate < edge.rate {
+				edges[index] = edge
+			}
+			return
+		}
+		best[key] = len(edges)
+		edges = append(edges, edge)
+	}
+
+	for _, price := range snapshot.PoolPrices {
+		if price.LiquidityUSD < config.MinLiquidityUSD || price.Token0Denom == price.Token1Denom {
+			continue
+		}
+		pool, ok := pools[price.OnChainPoolID()]
+		if !ok {
+			continue
+		}
+		fee := parseSwapFee(*pool).Float64()
+		from, to := node(price.Token0Denom), node(price.Token1Denom)
+
+		addEdge(arbitrageEdge{
+			from: from, to: to, rate: price.PriceToken0ToToken1 * (1 - fee), fee: fee, pool: pool,
+			fromDenom: price.Token0Denom, toDenom: price.Token1Denom,
+			fromSymbol: price.Token0Symbol, toSymbol: price.Token1Symbol,
+		})
+		addEdge(arbitrageEdge{
+			from: to, to: from, rate: price.PriceToken1ToToken0 * (1 - fee), fee: fee, pool: pool,
+			fromDenom: price.Token1Denom, toDenom: price.Token0Denom,
+			fromSymbol: price.Token1Symbol, toSymbol: price.Token0Symbol,
+		})
+	}
+
+	return edges, len(nodes)
+}
+
+// findNegativeCycles - Επαναλαμβανόμενος Bellman-Ford: μετά από κάθε εκτέλεση αφαιρείται
+// η χειρότερη ακμή κάθε κύκλου που κρατήθηκε, ώστε η επόμενη να βρει άλλους κύκλους.
+// Ένας κύκλος μακρύτερος από MaxHops δεν κρατιέται· αν κάποια ακμή του ανήκει σε αρνητικό
+// κύκλο έως MaxHops κρατιέται εκείνος, αλλιώς η ακμή του αφαιρείται χωρίς να χαθεί κάτι.
+func findNegativeCycles(nodes int, edges []arbitrageEdge, config ArbitrageConfig) [][]int {
+	removed := make([]bool, len(edges))
+	seen := make(map[string]bool)
+	var cycles [][]int
+
+	for run := 0; run < 2*config.MaxCycles && len(cycles) < config.MaxCycles; run++ {
+		found := bellmanFordCycles(nodes, edges, removed)
+		if len(found) == 0 {
+			break
+		}
+
+		for _, cycle := range found {
+			if len(cycle) > config.MaxHops {
+				short := shortCycleWithin(nodes, edges, removed, cycle, config.MaxHops)
+				if short == nil {
+					removed[worstEdge(edges, cycle)] = true
+					continue
+				}
+				cycle = short
+			}
+
+			key := cycleKey(cycle)
+			if seen[key] || len(cycles) >= config.MaxCycles {
+				continue
+			}
+			seen[key] = true
+			cycles = append(cycles, cycle)
+			removed[worstEdge(edges, cycle)] = true
+		}
+	}
+
+	return cycles
+}
+
+// worstEdge - Η ακμή του κύκλου με το μεγαλύτερο βάρος (τη χειρότερη τιμή)
+func worstEdge(edges []arbitrageEdge, cycle []int) int {
+	worst := cycle[0]
+	for _, index := range cycle {
+		if edges[index].weight > edges[worst].weight {
+			worst = index
+		}
+	}
+	return worst
+}
+
+// shortCycleWithin - Αρνητικός κύκλος έως maxHops ακμών που περνά από κάποια ακμή του
+// cycle, ή nil. Για κάθε ακμή u -> v, ένας Bellman-Ford περιορισμένος σε maxHops-1
+// περάσματα από το v βρίσκει τη φθηνότερη διαδρομή πίσω στο u.
+func shortCycleWithin(nodes int, edges []arbitrageEdge, removed []bool, cycle []int, maxHops int) []int {
+	for _, closing := range cycle {
+		if removed[closing] {
+			continue
+		}
+		if short := shortestReturn(nodes, edges, removed, closing, maxHops-1); short != nil {
+			return short
+		}
+	}
+	return nil
+}
+
+// shortestReturn - Η φθηνότερη απλή διαδρομή έως hops ακμών από το to πίσω στο from της
+// ακμής closing, αν μαζί της κάνει αρνητικό κύκλο (με τη σειρά του trade, closing πρώτη)
+func shortestReturn(nodes int, edges []arbitrageEdge, removed []bool, closing, hops int) []int {
+	if hops < 1 {
+		return nil
+	}
+	source, target := edges[closing].to, edges[closing].from
+
+	// dist[k][n]: φθηνότερη διαδρομή ακριβώς k ακμών από το source στο n
+	dist := make([][]float64, hops+1)
+	pred := make([][]int, hops+1)
+	for k := range dist {
+		dist[k] = make([]float64, nodes)
+		pred[k] = make([]int, nodes)
+		for n := range dist[k] {
+			dist[k][n] = math.Inf(1)
+			pred[k][n] = -1
+		}
+	}
+	dist[0][source] = 0
+
+	bestK := 0
+	best := -arbitrageEpsilon - edges[closing].weight
+	for k := 1; k <= hops; k++ {
+		for i, edge := range edges {
+			if removed[i] || math.IsInf(dist[k-1][edge.from], 1) {
+				continue
+			}
+			if d := dist[k-1][edge.from] + edge.weight; d < dist[k][edge.to] {
+				dist[k][edge.to] = d
+				pred[k][edge.to] = i
+			}
+		}
+		if dist[k][target] < best {
+			best, bestK = dist[k][target], k
+		}
+	}
+	if bestK == 0 {
+		return nil
+	}
+
+	path := make([]int, bestK)
+	visited := map[int]bool{source: true}
+	for k, node := bestK, target; k > 0; k-- {
+		if visited[node] {
+			return nil // Η διαδρομή ξαναπερνά από κόμβο: ο κύκλος δεν είναι απλός
+		}
+		visited[node] = true
+		path[k-1] = pred[k][node]
+		node = edges[path[k-1]].from
+	}
+	return append([]int{closing}, path...)
+}
+
+// bellmanFordCycles - Bellman-Ford από μια εικονική πηγή με απόσταση 0 προς όλους τους
+// κόμβους. Κάθε κύκλος στον γράφο των predecessors έχει αρνητικό βάρος, οπότε σταματάμε
+// μόλις εμφανιστεί ένας, χωρίς να περιμένουμε και τα n περάσματα.
+func bellmanFordCycles(nodes int, edges []arbitrageEdge, removed []bool) [][]int {
+	dist := make([]float64, nodes)
+	pred := make([]int, nodes)
+	for i := range pred {
+		pred[i] = -1
+	}
+
+	for pass := 0; pass < nodes; pass++ {
+		relaxed := false
+		for i, edge := range edges {
+			if removed[i] {
+				continue
+			}
+			if d := dist[edge.from] + edge.weight; d < dist[edge.to]-arbitrageEpsilon {
+				dist[edge.to] = d
+				pred[edge.to] = i
+				relaxed = true
+			}
+		}
+		if !relaxed {
+			return nil
+		}
+		if cycles := predecessorCycles(edges, pred); len(cycles) > 0 {
+			return cycles
+		}
+	}
+	return nil
+}
+
+// predecessorCycles - Οι κύκλοι του γράφου των predecessors, ως ακμές με τη σειρά του trade
+func predecessorCycles(edges []arbitrageEdge, pred []int) [][]int {
+	visitedBy := make([]int, len(pred)) // 0 = ανεπίσκεπτος, αλλιώς start+1 της διαδρομής
+	var cycles [][]int
+
+	for start := range pred {
+		if visitedBy[start] != 0 {
+			continue
+		}
+
+		node := start
+		for node >= 0 && visitedBy[node] == 0 {
+			visitedBy[node] = start + 1
+			if pred[node] < 0 {
+				node = -1
+				break
+			}
+			node = edges[pred[node]].from
+		}
+		if node < 0 || visitedBy[node] != start+1 {
+			continue // Η διαδρομή κατέληξε στην πηγή ή σε ήδη γνωστό κομμάτι
+		}
+
+		// Το node είναι πάνω στον κύκλο: τον διατρέχουμε προς τα πίσω και τον αντιστρέφουμε
+		var cycle []int
+		for current := node; ; {
+			index := pred[current]
+			cycle = append(cycle, index)
+			current = edges[index].from
+			if current == node {
+				break
+			}
+		}
+		for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+			cycle[i], cycle[j] = cycle[j], cycle[i]
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles
+}
+
+// cycleKey - Ίδιο κλειδί για κάθε περιστροφή του ίδιου κύκλου
+func cycleKey(cycle []int) string {
+	start := 0
+	for i, index := range cycle {
+		if index < cycle[start] {
+			start = i
+		}
+	}
+	parts := make([]string, len(cycle))
+	for i := range cycle {
+		parts[i] = strconv.Itoa(cycle[(start+i)%len(cycle)])
+	}
+	return strings.Join(parts, ">")
+}
+
+// reusesPool - Περνά ο κύκλος δύο φορές από το ίδιο on-chain pool;
+func reusesPool(hops []arbitrageEdge) bool {
+	seen := make(map[string]bool, len(hops))
+	for _, hop := range hops {
+		if seen[hop.pool.Id] {
+			return true
+		}
+		seen[hop.pool.Id] = true
+	}
+	return false
+}
+
+// rotateToPricedToken - Ο κύκλος ξεκινά από το token με τη μεγαλύτερη liquidity τιμής USD,
+// ώστε το κέρδος να μετριέται σε κάτι αποτιμήσιμο
+func rotateToPricedToken(hops []arbitrageEdge, tokenPrices map[string]types.TokenPrice) []arbitrageEdge {
+	start := 0
+	bestLiquidity := -1.0
+	for i, hop := range hops {
+		price, ok := tokenPrices[hop.fromDenom]
+		if ok && price.PriceUSD > 0 && price.LiquidityUSD > bestLiquidity {
+			start, bestLiquidity = i, price.LiquidityUSD
+		}
+	}
+
+	rotated := make([]arbitrageEdge, 0, len(hops))
+	rotated = append(rotated, hops[start:]...)
+	return append(rotated, hops[:start]...)
+}
+
+// simulateArbitrageCycle - Διαδοχικά swaps του amountIn μέσα από τα pools του κύκλου
+func simulateArbitrageCycle(hops []arbitrageEdge, amountIn *big.Int, priceModel func(types.OsmosisPool) PriceModel) ([]types.ArbitrageHop, *big.Int, bool) {
+	result := make([]types.ArbitrageHop, len(hops))
+	amount := amountIn
+
+	for i, hop := range hops {
+		if amount.Sign() <= 0 {
+			return nil, nil, false
+		}
+		swap, err := priceModel(*hop.pool).SimulateSwap(*hop.pool, types.BasicCoin{Denom: hop.fromDenom, Amount: amount.String()}, hop.toDenom)
+		if err != nil {
+			return nil, nil, false
+		}
+		out, ok := new(big.Int).SetString(swap.TokenOut.Amount, 10)
+		if !ok {
+			return nil, nil, false
+		}
+
+		result[i] = types.ArbitrageHop{
+			PoolID:     hop.pool.Id,
+			PoolType:   hop.pool.PoolType(),
+			FromSymbol: hop.fromSymbol,
+			FromDenom:  hop.fromDenom,
+			ToSymbol:   hop.toSymbol,
+			ToDenom:    hop.toDenom,
+			Rate:       hop.rate,
+			SwapFee:    hop.fee,
+			AmountIn:   amount.String(),
+			AmountOut:  out.String(),
+		}
+		amount = out
+	}
+
+	return result, amount, true
+}
+
+// sizeArbitrageCycle - Golden-section search (σε λογαριθμική κλίμακα) για το amountIn με το
+// μέγιστο κέρδος, έως arbitrageMaxTradeShare του reserve του πρώτου pool
+func sizeArbitrageCycle(hops []arbitrageEdge, snapshot ArbitrageSnapshot, tokenPrices map[string]types.TokenPrice) (types.ArbitrageOpportunity, bool) {
+	first := hops[0]
+
+	var reserve decimal.Dec
+	for _, asset := range first.pool.PoolAssets {
+		if asset.Token.Denom == first.fromDenom {
+			reserve, _ = decimal.Parse(asset.Token.Amount)
+		}
+	}
+	upper := reserve.Float64() * arbitrageMaxTradeShare
+	if upper < 1 {
+		return types.ArbitrageOpportunity{}, false
+	}
+
+	bestProfit := new(big.Int)
+	var bestIn *big.Int
+	profitAt := func(logAmount float64) float64 {
+		amountIn, _ := big.NewFloat(math.Exp(logAmount)).Int(nil)
+		_, out, ok := simulateArbitrageCycle(hops, amountIn, snapshot.PriceModel)
+		if !ok {
+			return math.Inf(-1)
+		}
+		profit := new(big.Int).Sub(out, amountIn)
+		if profit.Cmp(bestProfit) > 0 {
+			bestProfit, bestIn = profit, amountIn
+		}
+		f, _ := new(big.Float).SetInt(profit).Float64()
+		return f
+	}
+
+	lo, hi := math.Log(math.Max(1, upper*1e-12)), math.Log(upper)
+	ratio := (math.Sqrt(5) - 1) / 2
+	a, b := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+	fa, fb := profitAt(a), profitAt(b)
+	for step := 0; step < arbitrageSearchSteps; step++ {
+		if fa < fb {
+			lo, a, fa = a, b, fb
+			b = lo + ratio*(hi-lo)
+			fb = profitAt(b)
+		} else {
+			hi, b, fb = b, a, fa
+			a = hi - ratio*(hi-lo)
+			fa = profitAt(a)
+		}
+	}
+
+	if bestIn == nil {
+		return types.ArbitrageOpportunity{}, false
+	}
+	hopResults, out, ok := simulateArbitrageCycle(hops, bestIn, snapshot.PriceModel)
+	if !ok {
+		return types.ArbitrageOpportunity{}, false
+	}
+
+	path := make([]string, 0, len(hops)+1)
+	for _, hop := range hops {
+		path = append(path, hop.fromSymbol)
+	}
+	path = append(path, first.fromSymbol)
+
+	profit := new(big.Int).Sub(out, bestIn)
+	profitDec := decimal.NewFromBigInt(profit)
+	opportunity := types.ArbitrageOpportunity{
+		ID:          arbitrageID(snapshot.Chain, hops),
+		Chain:       snapshot.Chain,
+		Path:        path,
+		Hops:        hopResults,
+		TokenSymbol: first.fromSymbol,
+		TokenDenom:  first.fromDenom,
+		AmountIn:    bestIn.String(),
+		AmountOut:   out.String(),
+		Profit:      profit.String(),
+		ProfitPct:   profitDec.Quo(decimal.NewFromBigInt(bestIn)).Float64(),
+	}
+
+	if price, ok := tokenPrices[first.fromDenom]; ok && price.PriceUSD > 0 {
+		exponent := 0
+		if snapshot.Assets != nil {
+			exponent = snapshot.Assets.GetExponent(first.fromDenom)
+		}
+		opportunity.ProfitUSD = profitDec.MulPow10(-exponent).Float64() * price.PriceUSD
+	}
+
+	return opportunity, true
+}
+
+// arbitrageID - Hash των pools και των denoms του κύκλου, ανεξάρτητο από το σημείο εκκίνησης
+func arbitrageID(chain string, hops []arbitrageEdge) string {
+	start := 0
+	for i, hop := range hops {
+		if hop.fromDenom < hops[start].fromDenom {
+			start = i
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(chain)
+	for i := range hops {
+		hop := hops[(start+i)%len(hops)]
+		sb.WriteString("|" + hop.fromDenom + ">" + hop.pool.Id)
+	}
+	hash := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(hash[:8])
+}
+
+// sortArbitrageOpportunities - Μεγαλύτερο κέρδος USD πρώτα, μετά μεγαλύτερη απόδοση
+func sortArbitrageOpportunities(opportunities []types.ArbitrageOpportunity) {
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		if opportunities[i].ProfitUSD != opportunities[j].ProfitUSD {
+			return opportunities[i].ProfitUSD > opportunities[j].ProfitUSD
+		}
+		return opportunities[i].ProfitPct > opportunities[j].ProfitPct
+	})
+}
+
+// ArbitrageListener - Καλείται με τους κύκλους που δεν υπήρχαν στην προηγούμενη ενημέρωση
+type ArbitrageListener func(opportunities []types.ArbitrageOpportunity)
+
+// ArbitrageDetector - Κρατά τις τρέχουσες ευκαιρίες κάθε αλυσίδας και ειδοποιεί για τις νέες
+type ArbitrageDetector struct {
+	config ArbitrageConfig
+
+	mu            sync.RWMutex
+	opportunities map[string][]types.ArbitrageOpportunity // chain -> ευκαιρίες της τελευταίας ενημέρωσης
+	updatedAt     map[string]time.Time
+	listeners     []ArbitrageListener
+}
+
+// NewArbitrageDetector - Μηδενικά πεδία του config παίρνουν τις default τιμές
+func NewArbitrageDetector(config ArbitrageConfig) *ArbitrageDetector {
+	defaults := DefaultArbitrageConfig()
+	if config.MaxHops <= 0 {
+		config.MaxHops = defaults.MaxHops
+	}
+	if config.MaxCycles <= 0 {
+		config.MaxCycles = defaults.MaxCycles
+	}
+
+	return &ArbitrageDetector{
+		config:        config,
+		opportunities: make(map[string][]types.ArbitrageOpportunity),
+		updatedAt:     make(map[string]time.Time),
+	}
+}
+
+// Config - Οι παράμετροι του detector
+func (d *ArbitrageDetector) Config() ArbitrageConfig {
+	return d.config
+}
+
+// OnNewOpportunities - Εγγραφή listener για νέους κύκλους (π.χ. log ή /api/stream)
+func (d *ArbitrageDetector) OnNewOpportunities(listener ArbitrageListener) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.listeners = append(d.listeners, listener)
+}
+
+// Update αντικαθιστά τις ευκαιρίες της αλυσίδας του snapshot. Οι κύκλοι που υπήρχαν ήδη
+// κρατούν το DetectedAt τους· οι νέοι προωθούνται στους listeners.
+func (d *ArbitrageDetector) Update(snapshot ArbitrageSnapshot) []types.ArbitrageOpportunity {
+	found := FindArbitrage(snapshot, d.config)
+	now := time.Now()
+
+	d.mu.Lock()
+	previous := make(map[string]time.Time, len(d.opportunities[snapshot.Chain]))
+	for _, opportunity := range d.opportunities[snapshot.Chain] {
+		previous[opportunity.ID] = opportunity.DetectedAt
+	}
+
+	var fresh []types.ArbitrageOpportunity
+	for i := range found {
+		found[i].UpdatedAt = now
+		if detectedAt, ok := previous[found[i].ID]; ok {
+			found[i].DetectedAt = detectedAt
+			continue
+		}
+		found[i].DetectedAt = now
+		fresh = append(fresh, found[i])
+	}
+
+	d.opportunities[snapshot.Chain] = found
+	d.updatedAt[snapshot.Chain] = now
+	listeners := append([]ArbitrageListener(nil), d.listeners...)
+	d.mu.Unlock()
+
+	if len(fresh) > 0 {
+		for _, listener := range listeners {
+			listener(fresh)
+		}
+	}
+	return found
+}
+
+// Opportunities - Οι τρέχουσες ευκαιρίες μιας αλυσίδας ("" = όλων), το μεγαλύτερο κέρδος πρώτα
+func (d *ArbitrageDetector) Opportunities(chain string) []types.ArbitrageOpportunity {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := []types.ArbitrageOpportunity{}
+	for c, opportunities := range d.opportunities {
+		if chain == "" || c == chain {
+			result = append(result, opportunities...)
+		}
+	}
+	sortArbitrageOpportunities(result)
+	return result
+}
+
+// UpdatedAt - Πότε ενημερώθηκε τελευταία φορά κάθε αλυσίδα
+func (d *ArbitrageDetector) UpdatedAt() map[string]time.Time {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make(map[string]time.Time, len(d.updatedAt))
+	for chain, t := range d.updatedAt {
+		result[chain] = t
+	}
+	return result
+}
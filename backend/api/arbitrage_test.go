@@ -0,0 +1,182 @@
+package api
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// arbitrageTestSnapshot - Τρία ισοβαρή pools uA/uB, uB/uC και uC/uA. Τα δύο πρώτα έχουν
+// τιμή 1 και το τρίτο δίνει 1.05 uA ανά uC, οπότε ο κύκλος uA -> uB -> uC -> uA αποδίδει
+// 1.05·(1-fee)³ - 1 πριν το price impact.
+func arbitrageTestSnapshot(swapFee string) ArbitrageSnapshot {
+	const weight = "1000000"
+	pools := []types.OsmosisPool{
+		weightedTestPool("1", swapFee, [3]string{"uA", "1000000000", weight}, [3]string{"uB", "1000000000", weight}),
+		weightedTestPool("2", swapFee, [3]string{"uB", "1000000000", weight}, [3]string{"uC", "1000000000", weight}),
+		weightedTestPool("3", swapFee, [3]string{"uC", "1000000000", weight}, [3]string{"uA", "1050000000", weight}),
+	}
+
+	snapshot := ArbitrageSnapshot{
+		Chain:       "osmosis",
+		Pools:       pools,
+		TokenPrices: []types.TokenPrice{{Denom: "uA", Symbol: "A", PriceUSD: 1, LiquidityUSD: 1e9}},
+	}
+	for _, pool := range pools {
+		snapshot.PoolPrices = append(snapshot.PoolPrices, reservePoolPrice(pool))
+	}
+	return snapshot
+}
+
+// reservePoolPrice - Η τιμή ενός ισοβαρούς pool δύο tokens από τα reserves του, με
+// symbol το denom χωρίς το "u" και TVL $1B
+func reservePoolPrice(pool types.OsmosisPool) types.PoolPrice {
+	amount0, _ := new(big.Float).SetString(pool.PoolAssets[0].Token.Amount)
+	amount1, _ := new(big.Float).SetString(pool.PoolAssets[1].Token.Amount)
+	rate, _ := new(big.Float).Quo(amount1, amount0).Float64()
+
+	denom0, denom1 := pool.PoolAssets[0].Token.Denom, pool.PoolAssets[1].Token.Denom
+	return types.PoolPrice{
+		PoolID:              pool.Id,
+		Token0Denom:         denom0,
+		Token1Denom:         denom1,
+		Token0Symbol:        denom0[1:],
+		Token1Symbol:        denom1[1:],
+		PriceToken0ToToken1: rate,
+		PriceToken1ToToken0: 1 / rate,
+		LiquidityUSD:        1e9,
+	}
+}
+
+func TestFindArbitrageProfitableCycle(t *testing.T) {
+	snapshot := arbitrageTestSnapshot("0.01")
+	opportunities := FindArbitrage(snapshot, DefaultArbitrageConfig())
+	if len(opportunities) != 1 {
+		t.Fatalf("found %d opportunities, want 1: %+v", len(opportunities), opportunities)
+	}
+
+	opportunity := opportunities[0]
+	if opportunity.TokenDenom != "uA" || len(opportunity.Hops) != 3 {
+		t.Fatalf("cycle starts at %s with %d hops, want uA with 3", opportunity.TokenDenom, len(opportunity.Hops))
+	}
+	if want := 1.05*math.Pow(0.99, 3) - 1; !closeTo(opportunity.SpotReturn, want, 1e-9) {
+		t.Errorf("SpotReturn = %v, want %v", opportunity.SpotReturn, want)
+	}
+
+	// Κάθε hop ξοδεύει ό,τι έβγαλε το προηγούμενο
+	for i := 1; i < len(opportunity.Hops); i++ {
+		if opportunity.Hops[i].AmountIn != opportunity.Hops[i-1].AmountOut {
+			t.Errorf("hop %d spends %s, previous hop produced %s", i, opportunity.Hops[i].AmountIn, opportunity.Hops[i-1].AmountOut)
+		}
+	}
+
+	amountIn, _ := new(big.Int).SetString(opportunity.AmountIn, 10)
+	profit, _ := new(big.Int).SetString(opportunity.Profit, 10)
+	if profit.Sign() <= 0 {
+		t.Fatalf("profit = %s, want positive", opportunity.Profit)
+	}
+	if profitUSD, _ := new(big.Float).SetInt(profit).Float64(); !closeTo(opportunity.ProfitUSD, profitUSD, 1e-9) {
+		t.Errorf("ProfitUSD = %v, want %v", opportunity.ProfitUSD, profitUSD)
+	}
+
+	// Το μέγεθος είναι (κοντά στο) μέγιστο: ±10% δεν δίνει περισσότερο κέρδος
+	hops := arbitrageTestHops(t, snapshot, opportunity)
+	for _, scale := range []int64{9, 11} {
+		other := new(big.Int).Div(new(big.Int).Mul(amountIn, big.NewInt(scale)), big.NewInt(10))
+		_, out, ok := simulateArbitrageCycle(hops, other, PriceModelForPool)
+		if !ok {
+			t.Fatalf("simulating %s failed", other)
+		}
+		if otherProfit := new(big.Int).Sub(out, other); otherProfit.Cmp(profit) > 0 {
+			t.Errorf("amount %s yields %s, more than the chosen %s", other, otherProfit, profit)
+		}
+	}
+}
+
+// arbitrageTestHops - Οι ακμές του γράφου με τη σειρά των hops μιας ευκαιρίας
+func arbitrageTestHops(t *testing.T, snapshot ArbitrageSnapshot, opportunity types.ArbitrageOpportunity) []arbitrageEdge {
+	t.Helper()
+	edges, _ := buildArbitrageGraph(snapshot, DefaultArbitrageConfig())
+
+	hops := make([]arbitrageEdge, 0, len(opportunity.Hops))
+	for _, hop := range opportunity.Hops {
+		for _, edge := range edges {
+			if edge.pool.Id == hop.PoolID && edge.fromDenom == hop.FromDenom {
+				hops = append(hops, edge)
+			}
+		}
+	}
+	if len(hops) != len(opportunity.Hops) {
+		t.Fatalf("could not match the hops of %+v to graph edges", opportunity.Hops)
+	}
+	return hops
+}
+
+func TestFindArbitrageFeesRemoveProfit(t *testing.T) {
+	// 1.05·0.98³ ≈ 0.988: ο κύκλος υπάρχει στις τιμές αλλά όχι μετά τα fees
+	if opportunities := FindArbitrage(arbitrageTestSnapshot("0.02"), DefaultArbitrageConfig()); len(opportunities) != 0 {
+		t.Errorf("found %d opportunities with 2%% fees, want none: %+v", len(opportunities), opportunities)
+	}
+
+	// 1.05·0.99³ ≈ 1.019 περνά μόνο αν το MinSpotReturn είναι κάτω από 1.9%
+	config := DefaultArbitrageConfig()
+	config.MinSpotReturn = 0.02
+	if opportunities := FindArbitrage(arbitrageTestSnapshot("0.01"), config); len(opportunities) != 0 {
+		t.Errorf("found %d opportunities below MinSpotReturn, want none", len(opportunities))
+	}
+}
+
+func TestReusesPool(t *testing.T) {
+	poolA := &types.OsmosisPool{Id: "1"}
+	poolB := &types.OsmosisPool{Id: "2"}
+	sameIDAsA := &types.OsmosisPool{Id: "1"}
+
+	tests := []struct {
+		name string
+		hops []arbitrageEdge
+		want bool
+	}{
+		{"distinct pools", []arbitrageEdge{{pool: poolA}, {pool: poolB}}, false},
+		{"same pool twice", []arbitrageEdge{{pool: poolA}, {pool: poolB}, {pool: poolA}}, true},
+		{"same pool ID in another copy", []arbitrageEdge{{pool: poolA}, {pool: sameIDAsA}}, true},
+	}
+
+	for _, tt := range tests {
+		if got := reusesPool(tt.hops); got != tt.want {
+			t.Errorf("%s: reusesPool = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindNegativeCyclesMaxHops(t *testing.T) {
+	// Ο κύκλος 0 -> 1 -> 2 -> 3 -> 4 -> 0 είναι πολύ πιο αρνητικός και τον βρίσκει πρώτος ο
+	// Bellman-Ford. Η χειρότερη ακμή του (0 -> 1) ανήκει και στον κύκλο 0 -> 1 -> 2 -> 0,
+	// που πρέπει να βρεθεί με MaxHops = 3.
+	edges := []arbitrageEdge{
+		{from: 0, to: 1, weight: 0},
+		{from: 1, to: 2, weight: -0.01},
+		{from: 2, to: 3, weight: -1},
+		{from: 3, to: 4, weight: -1},
+		{from: 4, to: 0, weight: -1},
+		{from: 2, to: 0, weight: -0.01},
+	}
+
+	config := DefaultArbitrageConfig()
+	config.MaxHops = 3
+	cycles := findNegativeCycles(5, edges, config)
+	if len(cycles) != 1 {
+		t.Fatalf("found %d cycles, want 1: %v", len(cycles), cycles)
+	}
+	if got, want := cycleKey(cycles[0]), cycleKey([]int{0, 1, 5}); got != want {
+		t.Errorf("cycle = %s, want %s", got, want)
+	}
+
+	config.MaxHops = 5
+	for _, cycle := range findNegativeCycles(5, edges, config) {
+		if len(cycle) > config.MaxHops {
+			t.Errorf("cycle %v is longer than MaxHops", cycle)
+		}
+	}
+}
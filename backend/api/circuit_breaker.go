@@ -0,0 +1,126 @@
+package api
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Καταστάσεις του circuit breaker
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker - Μετά από threshold διαδοχικές αποτυχημένες κλήσεις σταματά τα requests
+// για cooldown. Μετά το cooldown περνά μία δοκιμαστική κλήση (half-open): επιτυχία κλείνει
+// τον breaker, αποτυχία τον ξανανοίγει.
+type CircuitBreaker struct {
+	mu                  sync.Mutex
+	name                string
+	threshold           int
+	cooldown            time.Duration
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+// CircuitStatus - Η κατάσταση του breaker για το /api/endpoints
+type CircuitStatus struct {
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Threshold           int       `json:"threshold"`
+	OpenedAt            time.Time `json:"opened_at"`
+	RetryAt             time.Time `json:"retry_at"`
+}
+
+// NewCircuitBreaker - Breaker με όριο αποτυχιών και διάρκεια ανοίγματος
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     CircuitClosed,
+	}
+}
+
+// Allow - false όσο ο breaker είναι ανοιχτός (ή περιμένει το αποτέλεσμα της δοκιμαστικής κλήσης)
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.trialInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if b.trialInFlight {
+			return false
+		}
+		b.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success - Μια κλήση πέτυχε
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitClosed {
+		log.Printf("✅ Circuit breaker %s κλειστό", b.name)
+	}
+	b.state = CircuitClosed
+	b.consecutiveFailures = 0
+	b.trialInFlight = false
+}
+
+// Failure - Μια κλήση απέτυχε (μόνο σφάλματα upstream, όχι π.χ. 404 ή 429)
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	b.trialInFlight = false
+
+	if b.state == CircuitHalfOpen || b.consecutiveFailures >= b.threshold {
+		if b.state != CircuitOpen {
+			log.Printf("🔌 Circuit breaker %s ανοιχτό για %v (%d διαδοχικές αποτυχίες)", b.name, b.cooldown, b.consecutiveFailures)
+		}
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Release - Η κλήση ακυρώθηκε πριν κριθεί: ελευθερώνει τη δοκιμαστική θέση χωρίς αποτέλεσμα
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+}
+
+// Status - Στιγμιότυπο της κατάστασης
+func (b *CircuitBreaker) Status() CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		Threshold:           b.threshold,
+	}
+	if b.state != CircuitClosed {
+		status.OpenedAt = b.openedAt
+		status.RetryAt = b.openedAt.Add(b.cooldown)
+	}
+	return status
+}
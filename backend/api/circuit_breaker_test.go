@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerCycle(t *testing.T) {
+	cooldown := 20 * time.Millisecond
+	breaker := NewCircuitBreaker("test", 2, cooldown)
+
+	expect := func(step, state string, allow bool) {
+		t.Helper()
+		if got := breaker.Status().State; got != state {
+			t.Fatalf("%s: state %s, want %s", step, got, state)
+		}
+		if got := breaker.Allow(); got != allow {
+			t.Fatalf("%s: Allow() = %v, want %v", step, got, allow)
+		}
+	}
+
+	expect("new", CircuitClosed, true)
+	breaker.Failure()
+	expect("one failure", CircuitClosed, true)
+	breaker.Success()
+	breaker.Failure()
+	expect("success resets the count", CircuitClosed, true)
+	breaker.Failure()
+	expect("threshold", CircuitOpen, false)
+
+	status := breaker.Status()
+	if status.ConsecutiveFailures != 2 || !status.RetryAt.Equal(status.OpenedAt.Add(cooldown)) {
+		t.Errorf("open status %+v", status)
+	}
+
+	// Μετά το cooldown περνά μόνο μία δοκιμαστική κλήση
+	time.Sleep(cooldown + 5*time.Millisecond)
+	expect("cooldown over", CircuitOpen, true)
+	expect("trial in flight", CircuitHalfOpen, false)
+
+	// Αποτυχία της δοκιμαστικής κλήσης: ξανά ανοιχτός
+	breaker.Failure()
+	expect("failed trial", CircuitOpen, false)
+
+	// Ακυρωμένη δοκιμαστική κλήση: η θέση ελευθερώνεται χωρίς απόφαση
+	time.Sleep(cooldown + 5*time.Millisecond)
+	expect("second cooldown", CircuitOpen, true)
+	breaker.Release()
+	expect("released trial", CircuitHalfOpen, true)
+
+	breaker.Success()
+	expect("successful trial", CircuitClosed, true)
+	if status := breaker.Status(); status.ConsecutiveFailures != 0 || !status.RetryAt.IsZero() {
+		t.Errorf("closed status %+v", status)
+	}
+}
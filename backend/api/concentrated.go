@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+const (
+	// clBalanceTTL - Μετά από τόσο ένα pool ξαναμπαίνει στη σειρά για νέα balances
+	clBalanceTTL = 5 * time.Minute
+	// clBalanceBatch - Pools ανά κύκλο: ο collector τρέχει κάθε δευτερόλεπτο, οπότε τα
+	// εκατοντάδες CL pools συμπληρώνονται σταδιακά αντί για ένα κύμα requests
+	clBalanceBatch = 25
+	// clBalanceWorkers - Παράλληλα requests για balances
+	clBalanceWorkers = 4
+)
+
+// ErrOutsideTickRange - Το swap θα περνούσε σε tick με άγνωστη liquidity
+var ErrOutsideTickRange = errors.New("το swap βγαίνει από το ενεργό εύρος ticks")
+
+// ConcentratedModel - CL pools: τιμή από το √P και swaps με τη liquidity L του ενεργού
+// εύρους (βλ. types.ConcentratedState). Swaps που θα περνούσαν το όριο του εύρους
+// απορρίπτονται με ErrOutsideTickRange, αφού πέρα από αυτό η liquidity είναι άγνωστη.
+type ConcentratedModel struct{}
+
+// clState - Τα πεδία του ConcentratedState ως αριθμοί
+type clState struct {
+	token0, token1       string
+	sqrtPrice, liquidity decimal.Dec
+	lowerSqrt, upperSqrt decimal.Dec
+}
+
+// SpotPrice - P = √P² token1 ανά token0 (base units)
+func (ConcentratedModel) SpotPrice(pool types.OsmosisPool, denomIn, denomOut string) (decimal.Dec, error) {
+	state, err := newCLState(pool)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return state.spotPrice(pool.Id, denomIn, denomOut)
+}
+
+// SimulateSwap - Swap μέσα στο ενεργό εύρος, όπως το ComputeSwapWithinBucketOutGivenIn:
+// token0 in: √P' = L·√P / (L + Δx·√P), out = L·(√P - √P')
+// token1 in: √P' = √P + Δy / L,         out = L·(√P' - √P) / (√P·√P')
+func (ConcentratedModel) SimulateSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, denomOut string) (*types.SimulateSwapResponse, error) {
+	amountIn, err := parseSwapAmount(tokenIn, denomOut)
+	if err != nil {
+		return nil, err
+	}
+	state, err := newCLState(pool)
+	if err != nil {
+		return nil, err
+	}
+	spotPrice, err := state.spotPrice(pool.Id, tokenIn.Denom, denomOut)
+	if err != nil {
+		return nil, err
+	}
+	if !state.liquidity.IsPositive() {
+		return nil, fmt.Errorf("%w: το pool %s δεν έχει liquidity στο τρέχον tick", ErrOutsideTickRange, pool.Id)
+	}
+
+	feeAmount := amountIn.Mul(parseSwapFee(pool))
+	amountInAfterFee := amountIn.Sub(feeAmount)
+	l, sqrtP := state.liquidity, state.sqrtPrice
+
+	var amountOut decimal.Dec
+	if tokenIn.Denom == state.token0 {
+		newSqrt := l.Mul(sqrtP).Quo(l.Add(amountInAfterFee.Mul(sqrtP)))
+		if newSqrt.Cmp(state.lowerSqrt) < 0 {
+			return nil, fmt.Errorf("%w: pool %s, η τιμή θα έπεφτε κάτω από το tick %d", ErrOutsideTickRange, pool.Id, pool.Concentrated.LowerTick)
+		}
+		amountOut = l.Mul(sqrtP.Sub(newSqrt))
+	} else {
+		newSqrt := sqrtP.Add(amountInAfterFee.Quo(l))
+		if newSqrt.Cmp(state.upperSqrt) > 0 {
+			return nil, fmt.Errorf("%w: pool %s, η τιμή θα ανέβαινε πάνω από το tick %d", ErrOutsideTickRange, pool.Id, pool.Concentrated.UpperTick)
+		}
+		amountOut = l.Mul(newSqrt.Sub(sqrtP)).Quo(sqrtP.Mul(newSqrt))
+	}
+
+	if pool.Concentrated.BalancesKnown {
+		if balance, err := findWeightedAsset(pool, denomOut); err != nil || amountOut.Cmp(balance.reserve) > 0 {
+			return nil, fmt.Errorf("%w: το output ξεπερνά το balance %s του pool %s", ErrOutsideTickRange, denomOut, pool.Id)
+		}
+	}
+
+	return buildSwapResponse(tokenIn, denomOut, amountIn, feeAmount, amountOut, spotPrice), nil
+}
+
+// newCLState διαβάζει την κατάσταση του ενεργού εύρους
+func newCLState(pool types.OsmosisPool) (*clState, error) {
+	cl := pool.Concentrated
+	if cl == nil {
+		return nil, fmt.Errorf("το pool %s δεν έχει κατάσταση concentrated liquidity", pool.Id)
+	}
+
+	values := make([]decimal.Dec, 4)
+	for i, field := range []string{cl.CurrentSqrtPrice, cl.CurrentTickLiquidity, cl.LowerSqrtPrice, cl.UpperSqrtPrice} {
+		value, err := decimal.Parse(field)
+		if err != nil || value.Sign() < 0 {
+			return nil, fmt.Errorf("μη έγκυρη κατάσταση CL για το pool %s", pool.Id)
+		}
+		values[i] = value
+	}
+	if !values[0].IsPositive() {
+		return nil, fmt.Errorf("μη έγκυρη √τιμή για το pool %s", pool.Id)
+	}
+
+	return &clState{
+		token0: cl.Token0, token1: cl.Token1,
+		sqrtPrice: values[0], liquidity: values[1],
+		lowerSqrt: values[2], upperSqrt: values[3],
+	}, nil
+}
+
+// spotPrice - Πόσα denomOut δίνει 1 denomIn
+func (s *clState) spotPrice(poolID, denomIn, denomOut string) (decimal.Dec, error) {
+	price := s.sqrtPrice.Mul(s.sqrtPrice)
+	switch {
+	case denomIn == s.token0 && denomOut == s.token1:
+		return price, nil
+	case denomIn == s.token1 && denomOut == s.token0:
+		return decimal.One().SafeQuo(price)
+	case denomIn != s.token0 && denomIn != s.token1:
+		return decimal.Dec{}, fmt.Errorf("το denom %s δεν υπάρχει στο pool %s", denomIn, poolID)
+	default:
+		return decimal.Dec{}, fmt.Errorf("το denom %s δεν υπάρχει στο pool %s", denomOut, poolID)
+	}
+}
+
+// ConcentratedBalanceCache - Τα πραγματικά balances των CL pools. Το LCD δεν τα δίνει στη
+// λίστα των pools, οπότε ζητούνται ανά pool και κρατούνται για clBalanceTTL.
+type ConcentratedBalanceCache struct {
+	mu      sync.RWMutex
+	entries map[string]clBalanceEntry
+}
+
+// clBalanceEntry - Τα balances ενός pool και πότε λήφθηκαν
+type clBalanceEntry struct {
+	balances  []types.BasicCoin
+	fetchedAt time.Time
+}
+
+// NewConcentratedBalanceCache - Άδειο cache
+func NewConcentratedBalanceCache() *ConcentratedBalanceCache {
+	return &ConcentratedBalanceCache{entries: make(map[string]clBalanceEntry)}
+}
+
+// Get - Τα τελευταία balances του pool (nil αν δεν έχουν ληφθεί ποτέ)
+func (c *ConcentratedBalanceCache) Get(poolID string) []types.BasicCoin {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[poolID]
+	if !ok {
+		return nil
+	}
+	return entry.balances
+}
+
+// Refresh ζητά τα balances έως clBalanceBatch pools: πρώτα όσα δεν έχουν ληφθεί ποτέ, μετά
+// όσα έχουν λήξει, τα παλαιότερα πρώτα. Pools που δεν υπάρχουν πια αφαιρούνται.
+func (c *ConcentratedBalanceCache) Refresh(ctx context.Context, client *OsmosisPoolClient, pools []types.ConcentratedPool) {
+	now := time.Now()
+	current := make(map[string]bool, len(pools))
+	var stale []string
+
+	c.mu.Lock()
+	for _, pool := range pools {
+		current[pool.Id] = true
+		if entry, ok := c.entries[pool.Id]; !ok || now.Sub(entry.fetchedAt) >= clBalanceTTL {
+			stale = append(stale, pool.Id)
+		}
+	}
+	for poolID := range c.entries {
+		if !current[poolID] {
+			delete(c.entries, poolID)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		return c.entries[stale[i]].fetchedAt.Before(c.entries[stale[j]].fetchedAt)
+	})
+	c.mu.Unlock()
+
+	if len(stale) > clBalanceBatch {
+		stale = stale[:clBalanceBatch]
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, clBalanceWorkers)
+	for _, poolID := range stale {
+		wg.Add(1)
+		go func(poolID string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if ctx.Err() != nil {
+				return
+			}
+			balances, err := client.GetPoolLiquidity(ctx, poolID)
+			if err != nil {
+				if ctx.Err() == nil && !errors.Is(err, ErrCircuitOpen) {
+					log.Printf("⚠️  Αποτυχία λήψης balances του CL pool %s: %v", poolID, err)
+				}
+				return
+			}
+
+			c.mu.Lock()
+			c.entries[poolID] = clBalanceEntry{balances: balances, fetchedAt: time.Now()}
+			c.mu.Unlock()
+		}(poolID)
+	}
+	wg.Wait()
+}
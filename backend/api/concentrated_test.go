@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// concentratedTestPool - CL pool uatom/uosmo στο tick 50 (P = 1.00005) με L = 1e9 και
+// tick spacing 100, άρα ενεργό εύρος [0, 100] και √P ∈ [1, √1.0001]
+func concentratedTestPool(t *testing.T, spreadFactor string, balances []types.BasicCoin) types.OsmosisPool {
+	t.Helper()
+	cl := types.ConcentratedPool{
+		Type:                 "/osmosis.concentratedliquidity.v1beta1.Pool",
+		Id:                   "1400",
+		CurrentTickLiquidity: "1000000000",
+		CurrentSqrtPrice:     "1.000024999687507812255867919601453017",
+		CurrentTick:          50,
+		Token0:               "uatom",
+		Token1:               "uosmo",
+		TickSpacing:          100,
+		SpreadFactor:         spreadFactor,
+	}
+	pool, err := cl.ToOsmosisPool(balances)
+	if err != nil {
+		t.Fatalf("ToOsmosisPool: %v", err)
+	}
+	return pool
+}
+
+func TestTickToPrice(t *testing.T) {
+	tests := []struct {
+		tick int64
+		want string
+	}{
+		{0, "1"},
+		{1, "1.000001"},
+		{-1, "0.9999999"},
+		{100, "1.0001"},
+		{9000000, "10"},
+		{9000001, "10.00001"},
+		{-9000000, "0.1"},
+		{-4500000, "0.55"},
+	}
+
+	for _, tt := range tests {
+		if got := types.TickToPrice(tt.tick, 0).String(); got != tt.want {
+			t.Errorf("TickToPrice(%d) = %s, want %s", tt.tick, got, tt.want)
+		}
+	}
+}
+
+func TestConcentratedActiveRange(t *testing.T) {
+	tests := []struct {
+		tick, lower, upper int64
+	}{
+		{50, 0, 100},
+		{100, 100, 200},
+		{-50, -100, 0},
+		{-100, -100, 0},
+	}
+
+	for _, tt := range tests {
+		pool := types.ConcentratedPool{CurrentTick: tt.tick, TickSpacing: 100}
+		if lower, upper := pool.ActiveRange(); lower != tt.lower || upper != tt.upper {
+			t.Errorf("tick %d: range [%d, %d], want [%d, %d]", tt.tick, lower, upper, tt.lower, tt.upper)
+		}
+	}
+}
+
+func TestConcentratedPoolBalances(t *testing.T) {
+	unknown := concentratedTestPool(t, "0", nil)
+	if unknown.Concentrated.BalancesKnown {
+		t.Error("balances known without a liquidity query")
+	}
+	for _, asset := range unknown.PoolAssets {
+		if asset.Token.Amount != "0" {
+			t.Errorf("%s: amount %s without balances, want 0", asset.Token.Denom, asset.Token.Amount)
+		}
+	}
+
+	known := concentratedTestPool(t, "0", []types.BasicCoin{
+		{Denom: "uosmo", Amount: "7000"},
+		{Denom: "uatom", Amount: "5000"},
+		{Denom: "uion", Amount: "1"},
+	})
+	if !known.Concentrated.BalancesKnown {
+		t.Error("balances unknown after a liquidity query")
+	}
+	if known.PoolAssets[0].Token.Amount != "5000" || known.PoolAssets[1].Token.Amount != "7000" {
+		t.Errorf("pool assets %+v, want the real balances", known.PoolAssets)
+	}
+	if len(known.PoolAssets) != 2 {
+		t.Errorf("got %d pool assets, want 2", len(known.PoolAssets))
+	}
+}
+
+func TestConcentratedSpotPrice(t *testing.T) {
+	pool := concentratedTestPool(t, "0", nil)
+
+	price, err := ConcentratedModel{}.SpotPrice(pool, "uatom", "uosmo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closeTo(price.Float64(), 1.00005, 1e-15) {
+		t.Errorf("spot price = %s, want 1.00005", price)
+	}
+
+	inverse, err := ConcentratedModel{}.SpotPrice(pool, "uosmo", "uatom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closeTo(inverse.Float64(), 1/1.00005, 1e-15) {
+		t.Errorf("inverse spot price = %s, want %v", inverse, 1/1.00005)
+	}
+
+	if _, err := (ConcentratedModel{}).SpotPrice(pool, "uion", "uosmo"); err == nil {
+		t.Error("expected error for a denom outside the pool")
+	}
+}
+
+func TestConcentratedSwapWithinRange(t *testing.T) {
+	// Μέσα στο εύρος το pool είναι constant-product με virtual reserves L/√P και L·√P:
+	// out = L·a / (√P·(L·√P + a)), υπολογισμένο σε 80 ψηφία
+	tests := []struct {
+		name         string
+		spreadFactor string
+		tokenIn      types.BasicCoin
+		denomOut     string
+		wantOut      string
+		wantFee      string
+	}{
+		{"token0 in", "0", types.BasicCoin{Denom: "uatom", Amount: "1000"}, "uosmo", "1000", "0"},        // 1000.049
+		{"token1 in", "0", types.BasicCoin{Denom: "uosmo", Amount: "1000"}, "uatom", "999", "0"},         // 999.949
+		{"spread factor", "0.002", types.BasicCoin{Denom: "uosmo", Amount: "1000"}, "uatom", "997", "2"}, // 997.949
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := concentratedTestPool(t, tt.spreadFactor, nil)
+			if model := PriceModelForPool(pool); model != (ConcentratedModel{}) {
+				t.Fatalf("PriceModelForPool = %T, want ConcentratedModel", model)
+			}
+
+			result, err := ConcentratedModel{}.SimulateSwap(pool, tt.tokenIn, tt.denomOut)
+			if err != nil {
+				t.Fatalf("SimulateSwap: %v", err)
+			}
+			if result.TokenOut.Amount != tt.wantOut || result.Fee.Amount != tt.wantFee {
+				t.Errorf("out %s, fee %s, want %s, %s", result.TokenOut.Amount, result.Fee.Amount, tt.wantOut, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestConcentratedSwapOutsideRange(t *testing.T) {
+	// Ως το όριο του εύρους χωρούν ~25000 από κάθε πλευρά
+	pool := concentratedTestPool(t, "0", nil)
+	for _, tokenIn := range []types.BasicCoin{
+		{Denom: "uatom", Amount: "30000"},
+		{Denom: "uosmo", Amount: "30000"},
+	} {
+		denomOut := "uosmo"
+		if tokenIn.Denom == "uosmo" {
+			denomOut = "uatom"
+		}
+		if _, err := (ConcentratedModel{}).SimulateSwap(pool, tokenIn, denomOut); !errors.Is(err, ErrOutsideTickRange) {
+			t.Errorf("%s in: error = %v, want ErrOutsideTickRange", tokenIn.Denom, err)
+		}
+	}
+
+	// Με γνωστά balances το output δεν μπορεί να τα ξεπεράσει
+	short := concentratedTestPool(t, "0", []types.BasicCoin{
+		{Denom: "uatom", Amount: "500"},
+		{Denom: "uosmo", Amount: "500"},
+	})
+	if _, err := (ConcentratedModel{}).SimulateSwap(short, types.BasicCoin{Denom: "uatom", Amount: "1000"}, "uosmo"); !errors.Is(err, ErrOutsideTickRange) {
+		t.Errorf("error = %v, want ErrOutsideTickRange for output above the pool balance", err)
+	}
+
+	empty := concentratedTestPool(t, "0", nil)
+	empty.Concentrated.CurrentTickLiquidity = "0"
+	if _, err := (ConcentratedModel{}).SimulateSwap(empty, types.BasicCoin{Denom: "uatom", Amount: "1"}, "uosmo"); !errors.Is(err, ErrOutsideTickRange) {
+		t.Errorf("error = %v, want ErrOutsideTickRange without liquidity", err)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// cosmWasmPairsLimit - Pairs ανά σελίδα του factory (το μέγιστο που δέχονται Astroport/Terraswap)
+const cosmWasmPairsLimit = 30
+
+// CosmWasmDexClient - DEX με Astroport/Terraswap-style contracts (factory + pair contracts),
+// όπως τα Astroport forks στο Archway και το Oraiswap στο Oraichain
+type CosmWasmDexClient struct {
+	*LCDClient
+	factory string
+}
+
+// NewCosmWasmDexClient - Client για το factory contract μιας αλυσίδας
+func NewCosmWasmDexClient(chain, factory string, endpoints *EndpointPool) *CosmWasmDexClient {
+	return &CosmWasmDexClient{
+		LCDClient: NewLCDClient(chain, endpoints),
+		factory:   factory,
+	}
+}
+
+// Factory - Η διεύθυνση του factory contract
+func (c *CosmWasmDexClient) Factory() string {
+	return c.factory
+}
+
+// GetAllPairs σελιδοποιεί το {"pairs":{}} query του factory μέχρι να εξαντληθεί
+func (c *CosmWasmDexClient) GetAllPairs(ctx context.Context) ([]types.CosmWasmPair, int, error) {
+	var pairs []types.CosmWasmPair
+	var startAfter []types.CosmWasmAssetInfo
+
+	pages := 0
+	for pages < maxSequentialPages {
+		query := map[string]interface{}{"limit": cosmWasmPairsLimit}
+		if startAfter != nil {
+			query["start_after"] = startAfter
+		}
+
+		var page struct {
+			Pairs []types.CosmWasmPair `json:"pairs"`
+		}
+		if err := c.SmartQuery(ctx, c.factory, map[string]interface{}{"pairs": query}, &page); err != nil {
+			return pairs, pages, fmt.Errorf("σφάλμα κατά την ανάκτηση pairs του %s: %w", c.chain, err)
+		}
+		pages++
+
+		pairs = append(pairs, page.Pairs...)
+		if len(page.Pairs) < cosmWasmPairsLimit {
+			break
+		}
+		startAfter = page.Pairs[len(page.Pairs)-1].AssetInfos
+	}
+
+	return pairs, pages, nil
+}
+
+// GetAllPools επιστρέφει κάθε pair του factory με τα reserves του ως OsmosisPool
+// (pool ID = "<chain>:<contract>"). Τα pools ζητούνται παράλληλα· pairs που αποτυγχάνουν
+// καταγράφονται στο report χωρίς να ακυρώνουν τον κύκλο.
+func (c *CosmWasmDexClient) GetAllPools(ctx context.Context) ([]types.OsmosisPool, types.PoolFetchReport, error) {
+	start := time.Now()
+	report := types.PoolFetchReport{Source: c.chain + "/cosmwasm"}
+
+	if c.factory == "" {
+		report.FailedPages = 1
+		return nil, finishFetchReport(report, start), fmt.Errorf("δεν έχει οριστεί factory contract για %s", c.chain)
+	}
+
+	pairs, pages, err := c.GetAllPairs(ctx)
+	report.Pages = pages
+	report.Total = len(pairs)
+	if err != nil {
+		report.FailedPages++
+		if len(pairs) == 0 {
+			return nil, finishFetchReport(report, start), err
+		}
+		log.Printf("⚠️  %v", err)
+	}
+
+	pools := make([]types.OsmosisPool, len(pairs))
+	fetched := make([]bool, len(pairs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentPages)
+
+	for i, pair := range pairs {
+		wg.Add(1)
+		go func(i int, pair types.CosmWasmPair) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			pool := types.CosmWasmPairPool{Pair: pair}
+			err := c.SmartQuery(ctx, pair.ContractAddr, map[string]interface{}{"pool": struct{}{}}, &pool)
+			if err == nil && types.PoolTypeFromAtType(pair.Kind()) == types.PoolTypeStableswap {
+				pool.Amplification = c.getPairAmplification(ctx, pair.ContractAddr)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Pages++
+			if err != nil {
+				report.FailedPages++
+				if ctx.Err() == nil {
+					log.Printf("⚠️  Αποτυχία pool %s (%s): %v", pair.ContractAddr, c.chain, err)
+				}
+				return
+			}
+			pools[i] = pool.ToOsmosisPool(c.chain)
+			fetched[i] = true
+		}(i, pair)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, finishFetchReport(report, start), err
+	}
+
+	result := make([]types.OsmosisPool, 0, len(pools))
+	for i, pool := range pools {
+		if fetched[i] {
+			result = append(result, pool)
+		}
+	}
+
+	report.Fetched = len(result)
+	return result, finishFetchReport(report, start), nil
+}
+
+// getPairAmplification διαβάζει το amp ενός stable pair από το config του. Σε αποτυχία
+// επιστρέφει "" και το pool μένει χωρίς τιμή αντί να τιμολογηθεί με υποθετικό amp.
+func (c *CosmWasmDexClient) getPairAmplification(ctx context.Context, contract string) string {
+	var config types.CosmWasmPairConfig
+	if err := c.SmartQuery(ctx, contract, map[string]interface{}{"config": struct{}{}}, &config); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("⚠️  Αποτυχία config του stable pair %s (%s): %v", contract, c.chain, err)
+		}
+		return ""
+	}
+	return config.Amp()
+}
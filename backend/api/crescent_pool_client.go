@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"portofoliov1/types"
+)
+
+// CrescentPoolClient - Τα pools του liquidity module του Crescent
+type CrescentPoolClient struct {
+	*LCDClient
+}
+
+// NewCrescentPoolClient - Client πάνω στους LCD nodes του Crescent
+func NewCrescentPoolClient(endpoints *EndpointPool) *CrescentPoolClient {
+	return &CrescentPoolClient{LCDClient: NewLCDClient("crescent", endpoints)}
+}
+
+// GetAllPools επιστρέφει τα basic pools ως OsmosisPool (pool IDs με prefix "crescent:").
+// Ranged και disabled pools παραλείπονται: τα reserves τους δεν αντιστοιχούν σε τιμή.
+func (c *CrescentPoolClient) GetAllPools(ctx context.Context, pageSize int) ([]types.OsmosisPool, types.PoolFetchReport, error) {
+	crescentPools, report, err := fetchAllPoolPages[types.CrescentPool](ctx, c.LCDClient, "/crescent/liquidity/v1beta1/pools", c.chain+"/liquidity", pageSize)
+	if err != nil {
+		return nil, report, fmt.Errorf("σφάλμα κατά την ανάκτηση pools του %s: %w", c.chain, err)
+	}
+
+	pools := make([]types.OsmosisPool, 0, len(crescentPools))
+	for _, pool := range crescentPools {
+		if !pool.Priceable() {
+			continue
+		}
+		pools = append(pools, pool.ToOsmosisPool(c.chain))
+	}
+
+	return pools, report, nil
+}
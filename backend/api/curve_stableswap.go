@@ -0,0 +1,184 @@
+package api
+
+import (
+	"fmt"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// CurveStableswapModel - Curve StableSwap invariant των stable pairs άλλων DEX (Astroport):
+//
+//	Ann·Σx + D = Ann·D + Dⁿ⁺¹ / (nⁿ·Πx),  Ann = amp·n
+//
+// όπως το compute_d των Astroport/Curve contracts. Το amp έρχεται από την πηγή του pool
+// (pool.Amplification)· pools χωρίς amp δεν τιμολογούνται.
+type CurveStableswapModel struct{}
+
+// curveState - Κλιμακωμένα reserves και παράμετροι ενός Curve pool
+type curveState struct {
+	denoms  []string
+	scaled  []decimal.Dec // reserve / scaling factor
+	factors []decimal.Dec
+	n       decimal.Dec
+	ann     decimal.Dec // amp·n
+}
+
+// SpotPrice - Οριακή τιμή dy/dx του invariant στο τρέχον σημείο
+func (CurveStableswapModel) SpotPrice(pool types.OsmosisPool, denomIn, denomOut string) (decimal.Dec, error) {
+	state, err := newCurveState(pool)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	i, j, err := denomIndexes(state.denoms, pool.Id, denomIn, denomOut)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return state.spotPrice(i, j), nil
+}
+
+// SimulateSwap - Λύνει το invariant για το νέο reserve του denomOut
+func (CurveStableswapModel) SimulateSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, denomOut string) (*types.SimulateSwapResponse, error) {
+	amountIn, err := parseSwapAmount(tokenIn, denomOut)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := newCurveState(pool)
+	if err != nil {
+		return nil, err
+	}
+	i, j, err := denomIndexes(state.denoms, pool.Id, tokenIn.Denom, denomOut)
+	if err != nil {
+		return nil, err
+	}
+
+	feeAmount := amountIn.Mul(parseSwapFee(pool))
+	scaledIn := amountIn.Sub(feeAmount).Quo(state.factors[i])
+
+	d := state.invariant(state.scaled)
+	newY := state.solveY(i, j, state.scaled[i].Add(scaledIn), d)
+	amountOut := state.scaled[j].Sub(newY).Mul(state.factors[j])
+
+	return buildSwapResponse(tokenIn, denomOut, amountIn, feeAmount, amountOut, state.spotPrice(i, j)), nil
+}
+
+// newCurveState διαβάζει reserves, scaling factors και amplification του pool
+func newCurveState(pool types.OsmosisPool) (*curveState, error) {
+	n := len(pool.PoolAssets)
+	if n < 2 {
+		return nil, fmt.Errorf("το stableswap pool %s έχει λιγότερα από 2 assets", pool.Id)
+	}
+
+	amp, err := decimal.Parse(pool.Amplification)
+	if err != nil || !amp.IsPositive() {
+		return nil, fmt.Errorf("το stableswap pool %s δεν έχει amplification", pool.Id)
+	}
+
+	state := &curveState{
+		denoms:  make([]string, n),
+		scaled:  make([]decimal.Dec, n),
+		factors: make([]decimal.Dec, n),
+		n:       decimal.NewFromInt(int64(n)),
+	}
+	state.ann = amp.Mul(state.n)
+
+	for k, asset := range pool.PoolAssets {
+		reserve, err := decimal.Parse(asset.Token.Amount)
+		if err != nil || !reserve.IsPositive() {
+			return nil, fmt.Errorf("μη έγκυρο reserve για %s στο pool %s", asset.Token.Denom, pool.Id)
+		}
+
+		factor := decimal.One()
+		if k < len(pool.ScalingFactors) {
+			if f, err := decimal.Parse(pool.ScalingFactors[k]); err == nil && f.IsPositive() {
+				factor = f
+			}
+		}
+
+		state.denoms[k] = asset.Token.Denom
+		state.factors[k] = factor
+		state.scaled[k] = reserve.Quo(factor)
+	}
+
+	return state, nil
+}
+
+// productTerm - D_P = Dⁿ⁺¹ / (nⁿ·Πx)
+func (s *curveState) productTerm(x []decimal.Dec, d decimal.Dec) decimal.Dec {
+	dP := d
+	for _, v := range x {
+		dP = dP.Mul(d).Quo(v.Mul(s.n))
+	}
+	return dP
+}
+
+// invariant υπολογίζει το D με Newton επαναλήψεις
+func (s *curveState) invariant(x []decimal.Dec) decimal.Dec {
+	sum := decimal.Zero()
+	for _, v := range x {
+		sum = sum.Add(v)
+	}
+	if sum.IsZero() {
+		return sum
+	}
+
+	one := decimal.One()
+	d := sum
+	for iter := 0; iter < stableswapMaxIterations; iter++ {
+		dP := s.productTerm(x, d)
+		prev := d
+		numerator := s.ann.Mul(sum).Add(dP.Mul(s.n)).Mul(d)
+		denominator := s.ann.Sub(one).Mul(d).Add(s.n.Add(one).Mul(dP))
+		d = numerator.Quo(denominator)
+		if converged(d, prev) {
+			break
+		}
+	}
+	return d
+}
+
+// solveY βρίσκει το νέο (κλιμακωμένο) reserve του j όταν το i γίνει newX, με σταθερό D
+func (s *curveState) solveY(i, j int, newX, d decimal.Dec) decimal.Dec {
+	c := d
+	sum := decimal.Zero()
+	for k, v := range s.scaled {
+		if k == j {
+			continue
+		}
+		if k == i {
+			v = newX
+		}
+		sum = sum.Add(v)
+		c = c.Mul(d).Quo(v.Mul(s.n))
+	}
+	c = c.Mul(d).Quo(s.ann.Mul(s.n))
+	b := sum.Add(d.Quo(s.ann))
+
+	y := d
+	for iter := 0; iter < stableswapMaxIterations; iter++ {
+		prev := y
+		denominator := y.Add(y).Add(b).Sub(d)
+		if !denominator.IsPositive() {
+			break
+		}
+		y = y.Mul(y).Add(c).Quo(denominator)
+		if converged(y, prev) {
+			break
+		}
+	}
+	return y
+}
+
+// spotPrice - Πόσα j (base units) δίνει 1 i (base units):
+// (x_j·(Ann·x_i + D_P)) / (x_i·(Ann·x_j + D_P)) με D_P = Dⁿ⁺¹ / (nⁿ·Πx)
+func (s *curveState) spotPrice(i, j int) decimal.Dec {
+	dP := s.productTerm(s.scaled, s.invariant(s.scaled))
+
+	xi, xj := s.scaled[i], s.scaled[j]
+	numerator := xj.Mul(s.ann.Mul(xi).Add(dP)).Mul(s.factors[j])
+	denominator := xi.Mul(s.ann.Mul(xj).Add(dP)).Mul(s.factors[i])
+
+	// Μετατροπή από κλιμακωμένες σε base μονάδες μέσω των factors
+	return numerator.Quo(denominator)
+}
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"portofoliov1/types"
+)
+
+// DenomTraceResolver - Εξηγεί από πού προέρχεται ένα denom. Τα IBC denoms αναζητούνται
+// πρώτα στο assetlist (και επαληθεύεται ότι το SHA-256 του trace δίνει το ίδιο hash)·
+// όσα δεν υπάρχουν εκεί ζητούνται από τον LCD node. Τα traces δεν αλλάζουν ποτέ για ένα
+// hash, οπότε οι απαντήσεις του node κρατιούνται για πάντα.
+type DenomTraceResolver struct {
+	mu     sync.RWMutex
+	assets *types.AssetService
+	lcd    *LCDClient
+	cache  map[string]types.DenomTrace // ibc denom -> trace από τον node
+}
+
+// NewDenomTraceResolver - assets ή lcd μπορεί να είναι nil και να οριστούν αργότερα
+func NewDenomTraceResolver(assets *types.AssetService, lcd *LCDClient) *DenomTraceResolver {
+	return &DenomTraceResolver{
+		assets: assets,
+		lcd:    lcd,
+		cache:  make(map[string]types.DenomTrace),
+	}
+}
+
+// SetAssetService - Το assetlist της αλυσίδας στην οποία ανήκουν τα denoms
+func (r *DenomTraceResolver) SetAssetService(assets *types.AssetService) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assets = assets
+}
+
+// SetLCDClient - Ο node που ρωτάμε για IBC denoms εκτός assetlist
+func (r *DenomTraceResolver) SetLCDClient(lcd *LCDClient) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lcd = lcd
+}
+
+// Resolve επιστρέφει το trace ενός denom. Δέχεται "ibc/HASH", σκέτο HASH ή native denom.
+func (r *DenomTraceResolver) Resolve(ctx context.Context, denom string) (*types.DenomTrace, error) {
+	r.mu.RLock()
+	assets, lcd := r.assets, r.lcd
+	r.mu.RUnlock()
+
+	denom = types.NormalizeIBCDenom(strings.TrimSpace(denom))
+	if denom == "" {
+		return nil, fmt.Errorf("κενό denom")
+	}
+
+	if !types.IsIBCDenom(denom) {
+		if assets != nil {
+			if asset, ok := assets.GetAsset(denom); ok && asset.Base == denom {
+				return nativeDenomTrace(asset, assets.Chain), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: το %s δεν είναι IBC denom και δεν υπάρχει στο assetlist", ErrNotFound, denom)
+	}
+
+	if assets != nil {
+		if asset, ok := assets.GetAsset(denom); ok && asset.Base == denom {
+			if trace, ok := assetDenomTrace(asset); ok {
+				return trace, nil
+			}
+		}
+	}
+
+	trace, err := r.resolveFromLCD(ctx, lcd, denom)
+	if err != nil {
+		return nil, err
+	}
+	if assets != nil {
+		annotateDenomTrace(trace, assets.GetAllTokens())
+	}
+	return trace, nil
+}
+
+// nativeDenomTrace - Denom της ίδιας της αλυσίδας (χωρίς IBC path)
+func nativeDenomTrace(asset types.Asset, chain string) *types.DenomTrace {
+	return &types.DenomTrace{
+		Denom:       asset.Base,
+		BaseDenom:   asset.Base,
+		Hops:        []types.DenomTraceHop{},
+		OriginChain: chain,
+		Symbol:      asset.Symbol,
+		Name:        asset.Name,
+		Native:      true,
+		Verified:    true,
+		Source:      "native",
+	}
+}
+
+// ibcTraces - Τα IBC βήματα ενός asset με τη σειρά του assetlist (από την πηγή προς εμάς)
+func ibcTraces(asset types.Asset) []types.Trace {
+	var traces []types.Trace
+	for _, trace := range asset.Traces {
+		if trace.Type == "ibc" || trace.Type == "ibc-cw20" {
+			traces = append(traces, trace)
+		}
+	}
+	return traces
+}
+
+// assetDenomTrace - Trace από το assetlist. Το τελευταίο IBC trace έχει το πλήρες path
+// στην αλυσίδα μας· το πρώτο δείχνει την αλυσίδα προέλευσης.
+func assetDenomTrace(asset types.Asset) (*types.DenomTrace, bool) {
+	traces := ibcTraces(asset)
+	if len(traces) == 0 || traces[len(traces)-1].Chain.Path == "" {
+		return nil, false
+	}
+
+	fullPath := traces[len(traces)-1].Chain.Path
+	path, baseDenom, hops := types.ParseDenomTracePath(fullPath)
+
+	// Το hop i (από εμάς προς την πηγή) οδηγεί στον counterparty του trace len-1-i
+	if len(hops) == len(traces) {
+		for i := range hops {
+			hops[i].CounterpartyChain = traces[len(traces)-1-i].Counterparty.ChainName
+		}
+	}
+
+	computed := types.IBCDenomFromFullPath(fullPath)
+	return &types.DenomTrace{
+		Denom:         asset.Base,
+		Path:          path,
+		BaseDenom:     baseDenom,
+		Hops:          hops,
+		OriginChain:   traces[0].Counterparty.ChainName,
+		Symbol:        asset.Symbol,
+		Name:          asset.Name,
+		ComputedDenom: computed,
+		Verified:      computed == asset.Base,
+		Source:        "assetlist",
+	}, true
+}
+
+// resolveFromLCD - Το trace από τον node: πρώτα το v1 denom_traces, μετά το v2 denoms
+// (ibc-go v8+). Κρατιέται μόνο αν το hash του επαληθεύεται.
+func (r *DenomTraceResolver) resolveFromLCD(ctx context.Context, lcd *LCDClient, denom string) (*types.DenomTrace, error) {
+	r.mu.RLock()
+	cached, ok := r.cache[denom]
+	r.mu.RUnlock()
+	if ok {
+		trace := cached
+		trace.Hops = append([]types.DenomTraceHop(nil), cached.Hops...)
+		return &trace, nil
+	}
+
+	if lcd == nil {
+		return nil, fmt.Errorf("%w: το %s δεν υπάρχει στο assetlist", ErrNotFound, denom)
+	}
+
+	hash := strings.TrimPrefix(denom, types.IBCDenomPrefix)
+	fullPath, err := queryDenomTraceV1(ctx, lcd, hash)
+	if err != nil && ctx.Err() == nil {
+		if v2Path, v2Err := queryDenomTraceV2(ctx, lcd, hash); v2Err == nil {
+			fullPath, err = v2Path, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση του trace του %s: %w", denom, err)
+	}
+
+	path, baseDenom, hops := types.ParseDenomTracePath(fullPath)
+	computed := types.IBCDenom(path, baseDenom)
+	if computed != denom {
+		return nil, fmt.Errorf("το trace %q του node δίνει %s αντί για %s", fullPath, computed, denom)
+	}
+	if hops == nil {
+		hops = []types.DenomTraceHop{}
+	}
+
+	trace := types.DenomTrace{
+		Denom:         denom,
+		Path:          path,
+		BaseDenom:     baseDenom,
+		Hops:          hops,
+		ComputedDenom: computed,
+		Verified:      true,
+		Source:        "lcd",
+	}
+
+	r.mu.Lock()
+	r.cache[denom] = trace
+	r.mu.Unlock()
+
+	trace.Hops = append([]types.DenomTraceHop(nil), hops...)
+	return &trace, nil
+}
+
+// queryDenomTraceV1 - /ibc/apps/transfer/v1/denom_traces/{hash}
+func queryDenomTraceV1(ctx context.Context, lcd *LCDClient, hash string) (string, error) {
+	resp, err := lcd.get(ctx, "/ibc/apps/transfer/v1/denom_traces/"+hash)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		DenomTrace struct {
+			Path      string `json:"path"`
+			BaseDenom string `json:"base_denom"`
+		} `json:"denom_trace"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("σφάλμα κατά το parsing του denom trace: %w", err)
+	}
+	if response.DenomTrace.BaseDenom == "" {
+		return "", errors.New("κενό denom trace")
+	}
+	if response.DenomTrace.Path == "" {
+		return response.DenomTrace.BaseDenom, nil
+	}
+	return response.DenomTrace.Path + "/" + response.DenomTrace.BaseDenom, nil
+}
+
+// queryDenomTraceV2 - /ibc/apps/transfer/v1/denoms/{hash} (ibc-go v8+)
+func queryDenomTraceV2(ctx context.Context, lcd *LCDClient, hash string) (string, error) {
+	resp, err := lcd.get(ctx, "/ibc/apps/transfer/v1/denoms/"+hash)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Denom struct {
+			Base  string `json:"base"`
+			Trace []struct {
+				PortID    string `json:"port_id"`
+				ChannelID string `json:"channel_id"`
+			} `json:"trace"`
+		} `json:"denom"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("σφάλμα κατά το parsing του denom: %w", err)
+	}
+	if response.Denom.Base == "" {
+		return "", errors.New("κενό denom trace")
+	}
+
+	segments := make([]string, 0, len(response.Denom.Trace)*2+1)
+	for _, hop := range response.Denom.Trace {
+		segments = append(segments, hop.PortID, hop.ChannelID)
+	}
+	segments = append(segments, response.Denom.Base)
+	return strings.Join(segments, "/"), nil
+}
+
+// annotateDenomTrace - Συμπληρώνει αλυσίδες και symbol σε trace από τον node, με βάση
+// assets του assetlist που έρχονται από το ίδιο κανάλι (π.χ. άλλο denom του ίδιου chain)
+func annotateDenomTrace(trace *types.DenomTrace, assets []types.Asset) {
+	if len(trace.Hops) == 0 {
+		return
+	}
+
+	// Κανάλι στην αλυσίδα μας -> αλυσίδα στην άλλη άκρη
+	channelChains := make(map[string]string)
+	for _, asset := range assets {
+		traces := ibcTraces(asset)
+		if len(traces) == 0 {
+			continue
+		}
+		last := traces[len(traces)-1]
+		if last.Chain.ChannelID != "" && last.Counterparty.ChainName != "" {
+			channelChains[last.Chain.ChannelID] = last.Counterparty.ChainName
+		}
+	}
+
+	first := &trace.Hops[0]
+	first.CounterpartyChain = channelChains[first.ChannelID]
+	if len(trace.Hops) != 1 || first.CounterpartyChain == "" {
+		return // Για multi-hop denoms η προέλευση δεν προκύπτει από το πρώτο κανάλι
+	}
+	trace.OriginChain = first.CounterpartyChain
+
+	// Το ίδιο base denom της ίδιας αλυσίδας, ίσως μέσω άλλου καναλιού
+	for _, asset := range assets {
+		traces := ibcTraces(asset)
+		if len(traces) == 0 {
+			continue
+		}
+		origin := traces[0].Counterparty
+		if origin.ChainName == trace.OriginChain && origin.BaseDenom == trace.BaseDenom {
+			trace.Symbol = asset.Symbol
+			trace.Name = asset.Name
+			return
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+const (
+	// testAtomViaJuno - ATOM μέσω Juno: transfer/channel-141/transfer/channel-0/uatom (εκτός assetlist)
+	testAtomViaJuno = "ibc/DC3546F9ADCB15B45CA5787D9755FC1BDD768173E55025420A217B90F6F6F625"
+	// testLCDOnlyDenom - transfer/channel-0/gamm/pool/1: base με "/" από το κανάλι του Cosmos Hub
+	testLCDOnlyDenom = "ibc/7F41B447119AF42B316F512BBF08CA43922D509703887AEFD9F02BBE7CC92B18"
+)
+
+// traceServer - LCD με denom traces (hash -> πλήρες trace). Με v2 απαντά μόνο στο
+// endpoint denoms του ibc-go v8+, αλλιώς μόνο στο v1 denom_traces.
+func traceServer(t *testing.T, traces map[string]string, v2 bool) (*httptest.Server, *atomic.Int64) {
+	requests := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var hash string
+		var ok bool
+		if v2 {
+			hash, ok = strings.CutPrefix(r.URL.Path, "/ibc/apps/transfer/v1/denoms/")
+		} else {
+			hash, ok = strings.CutPrefix(r.URL.Path, "/ibc/apps/transfer/v1/denom_traces/")
+		}
+		fullPath, found := traces[hash]
+		if !ok || !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		path, baseDenom, hops := types.ParseDenomTracePath(fullPath)
+		if !v2 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"denom_trace": map[string]string{"path": path, "base_denom": baseDenom},
+			})
+			return
+		}
+		trace := make([]map[string]string, 0, len(hops))
+		for _, hop := range hops {
+			trace = append(trace, map[string]string{"port_id": hop.PortID, "channel_id": hop.ChannelID})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"denom": map[string]interface{}{"base": baseDenom, "trace": trace},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestDenomTraceResolverAssetlist(t *testing.T) {
+	resolver := NewDenomTraceResolver(testAssetService(t), nil)
+
+	tests := []struct {
+		denom     string
+		path      string
+		baseDenom string
+		origin    string
+		hops      []types.DenomTraceHop
+	}{
+		{testAtomDenom, "transfer/channel-0", "uatom", "cosmoshub",
+			[]types.DenomTraceHop{{PortID: "transfer", ChannelID: "channel-0", CounterpartyChain: "cosmoshub"}}},
+		// Multi-hop: το πρώτο hop οδηγεί στην τελευταία αλυσίδα πριν από εμάς
+		{"ibc/2EB516F83C9FF44AB6826F269CA98A5622608C6C955E12112E58F23A324FEE07",
+			"transfer/channel-12618/transfer/channel-0", "eth0x0FD10b9899882a6f2fcb5c371E17e70FdEe00C38", "fxcore",
+			[]types.DenomTraceHop{
+				{PortID: "transfer", ChannelID: "channel-12618", CounterpartyChain: "pundix"},
+				{PortID: "transfer", ChannelID: "channel-0", CounterpartyChain: "fxcore"},
+			}},
+		// Base denom με "/"
+		{"ibc/1E43D59E565D41FB4E54CA639B838FFD5BCFC20003D330A56CB1396231AA1CBA",
+			"transfer/channel-2186", "factory/wormhole14ejqjyq8um4p3xfqj74yld5waqljf88fz25yxnma0cngspxe3les00fpjx/8sYgCzLRJC3J7qPn2bNbx6PiGcarhyx8rBhVaNnfvHCA", "gateway",
+			[]types.DenomTraceHop{{PortID: "transfer", ChannelID: "channel-2186", CounterpartyChain: "gateway"}}},
+	}
+	for _, tt := range tests {
+		// Και σκέτο hash με πεζά γίνεται δεκτό
+		for _, input := range []string{tt.denom, strings.ToLower(strings.TrimPrefix(tt.denom, types.IBCDenomPrefix))} {
+			trace, err := resolver.Resolve(context.Background(), input)
+			if err != nil {
+				t.Errorf("%s: %v", input, err)
+				continue
+			}
+			if trace.Source != "assetlist" || trace.Denom != tt.denom || !trace.Verified || trace.ComputedDenom != tt.denom || trace.Native {
+				t.Errorf("%s: trace %+v", input, trace)
+			}
+			if trace.Path != tt.path || trace.BaseDenom != tt.baseDenom || trace.OriginChain != tt.origin || !reflect.DeepEqual(trace.Hops, tt.hops) {
+				t.Errorf("%s: %s / %s from %s via %+v, want %s / %s from %s via %+v",
+					input, trace.Path, trace.BaseDenom, trace.OriginChain, trace.Hops, tt.path, tt.baseDenom, tt.origin, tt.hops)
+			}
+		}
+	}
+}
+
+func TestDenomTraceResolverNative(t *testing.T) {
+	resolver := NewDenomTraceResolver(testAssetService(t), nil)
+
+	trace, err := resolver.Resolve(context.Background(), " uosmo ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !trace.Native || trace.Source != "native" || trace.BaseDenom != "uosmo" || trace.Path != "" ||
+		trace.OriginChain != types.DefaultChain || trace.Symbol != "OSMO" || len(trace.Hops) != 0 {
+		t.Errorf("trace %+v", trace)
+	}
+
+	for _, denom := range []string{"", "unot-listed"} {
+		if _, err := resolver.Resolve(context.Background(), denom); err == nil {
+			t.Errorf("%q resolved", denom)
+		}
+	}
+	if _, err := resolver.Resolve(context.Background(), testAtomViaJuno); !errors.Is(err, ErrNotFound) {
+		t.Errorf("IBC denom outside the assetlist without a node: %v, want ErrNotFound", err)
+	}
+}
+
+func TestDenomTraceResolverLCD(t *testing.T) {
+	traces := map[string]string{
+		strings.TrimPrefix(testAtomViaJuno, types.IBCDenomPrefix):  "transfer/channel-141/transfer/channel-0/uatom",
+		strings.TrimPrefix(testLCDOnlyDenom, types.IBCDenomPrefix): "transfer/channel-0/gamm/pool/1",
+	}
+
+	for _, v2 := range []bool{false, true} {
+		server, requests := traceServer(t, traces, v2)
+		lcd := testLCDClient(server.URL)
+		resolver := NewDenomTraceResolver(testAssetService(t), lcd)
+
+		trace, err := resolver.Resolve(context.Background(), testAtomViaJuno)
+		if err != nil {
+			t.Fatalf("v2 %v: %v", v2, err)
+		}
+		wantHops := []types.DenomTraceHop{
+			{PortID: "transfer", ChannelID: "channel-141"},
+			{PortID: "transfer", ChannelID: "channel-0"},
+		}
+		if trace.Source != "lcd" || !trace.Verified || trace.Path != "transfer/channel-141/transfer/channel-0" || trace.BaseDenom != "uatom" {
+			t.Errorf("v2 %v: trace %+v", v2, trace)
+		}
+		// Multi-hop: η προέλευση δεν προκύπτει από το πρώτο κανάλι
+		if trace.OriginChain != "" || len(trace.Hops) != 2 || trace.Hops[1] != wantHops[1] || trace.Hops[0].ChannelID != "channel-141" {
+			t.Errorf("v2 %v: origin %q hops %+v", v2, trace.OriginChain, trace.Hops)
+		}
+
+		// Single hop από το κανάλι του Cosmos Hub: η αλυσίδα έρχεται από το assetlist
+		trace, err = resolver.Resolve(context.Background(), testLCDOnlyDenom)
+		if err != nil {
+			t.Fatalf("v2 %v: %v", v2, err)
+		}
+		if trace.BaseDenom != "gamm/pool/1" || trace.OriginChain != "cosmoshub" || trace.Hops[0].CounterpartyChain != "cosmoshub" || trace.Symbol != "" {
+			t.Errorf("v2 %v: trace %+v", v2, trace)
+		}
+
+		// Τα traces του node κρατιούνται στην cache
+		sent := requests.Load()
+		if _, err := resolver.Resolve(context.Background(), testAtomViaJuno); err != nil || requests.Load() != sent {
+			t.Errorf("v2 %v: cached trace refetched (%v)", v2, err)
+		}
+
+		// Άγνωστο hash: 404 και από τα δύο endpoints
+		unknown := "ibc/" + strings.Repeat("A", 64)
+		if _, err := resolver.Resolve(context.Background(), unknown); !errors.Is(err, ErrNotFound) {
+			t.Errorf("v2 %v: unknown denom error %v, want ErrNotFound", v2, err)
+		}
+	}
+}
+
+func TestDenomTraceResolverRejectsWrongTrace(t *testing.T) {
+	// Ο node δίνει trace που δεν αντιστοιχεί στο hash
+	server, _ := traceServer(t, map[string]string{
+		strings.TrimPrefix(testAtomViaJuno, types.IBCDenomPrefix): "transfer/channel-1/uatom",
+	}, false)
+	resolver := NewDenomTraceResolver(nil, testLCDClient(server.URL))
+
+	if trace, err := resolver.Resolve(context.Background(), testAtomViaJuno); err == nil {
+		t.Errorf("mismatched trace accepted: %+v", trace)
+	}
+	if len(resolver.cache) != 0 {
+		t.Error("mismatched trace cached")
+	}
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"portofoliov1/types"
+)
+
+// DefaultPoolPageSize - Pools ανά σελίδα του LCD pagination
+const DefaultPoolPageSize = 500
+
+// Τα είδη sources που καταχωρεί το NewDexRegistry
+const (
+	DexKindOsmosis  = "osmosis"
+	DexKindCrescent = "crescent"
+	DexKindCosmWasm = "cosmwasm"
+	DexKindFile     = "file"
+)
+
+// DexSource - Πηγή pools ενός DEX. Κάθε adapter επιστρέφει τα pools του ως OsmosisPool
+// (με Chain και pool ID με prefix αλυσίδας) ώστε ο collector να τα τιμολογεί και να τα
+// αποθηκεύει χωρίς να γνωρίζει το DEX.
+type DexSource interface {
+	// Name - Αναγνωριστικό της πηγής (logs, TokenInfo.Source)
+	Name() string
+	// Chain - Η αλυσίδα των pools: ορίζει το assetlist και το storage partition
+	Chain() string
+	// FetchPools επιστρέφει όλα τα pools και ένα report ανά endpoint που διαβάστηκε
+	FetchPools(ctx context.Context) ([]types.OsmosisPool, []types.PoolFetchReport, error)
+	// PriceModel - Το μοντέλο με το οποίο τιμολογείται ένα pool της πηγής
+	PriceModel(pool types.OsmosisPool) PriceModel
+}
+
+// LCDBacked - Sources που διαβάζουν από LCD nodes εκθέτουν τον client τους,
+// ώστε το /api/endpoints να δείχνει τους nodes και τον circuit breaker
+type LCDBacked interface {
+	LCD() *LCDClient
+}
+
+// LCD - Κάθε client που ενσωματώνει τον LCDClient ικανοποιεί το LCDBacked
+func (c *LCDClient) LCD() *LCDClient {
+	return c
+}
+
+// DexSourceConfig - Η περιγραφή μιας πηγής στο config της εφαρμογής
+type DexSourceConfig struct {
+	Kind     string // "osmosis", "crescent", "cosmwasm", "file" ή όποιο έχει καταχωρηθεί με Register
+	Chain    string // Απαραίτητο για cosmwasm· το file χρησιμοποιεί το osmosis αν λείπει
+	Contract string // Factory contract (cosmwasm)
+	Path     string // Αρχείο με pools (file)
+	PageSize int    // Pools ανά σελίδα (0 = DefaultPoolPageSize)
+}
+
+// ChainName - Η αλυσίδα της πηγής πριν χτιστεί (π.χ. για το chain registry updater)
+func (cfg DexSourceConfig) ChainName() string {
+	switch {
+	case cfg.Kind == DexKindOsmosis || cfg.Kind == DexKindCrescent:
+		return cfg.Kind
+	case cfg.Chain != "":
+		return cfg.Chain
+	case cfg.Kind == DexKindFile:
+		return types.DefaultChain
+	default:
+		return ""
+	}
+}
+
+// DexSourceFactory - Δημιουργεί μια πηγή από την περιγραφή της
+type DexSourceFactory func(cfg DexSourceConfig) (DexSource, error)
+
+// DexRegistry - Τα είδη πηγών που μπορεί να χτίσει ο collector. Τρίτοι adapters
+// καταχωρούνται με Register πριν το Build, χωρίς αλλαγές στη ροή τιμολόγησης.
+type DexRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]DexSourceFactory
+}
+
+// NewDexRegistry - Registry με τις ενσωματωμένες πηγές (Osmosis, Crescent, CosmWasm, file)
+func NewDexRegistry() *DexRegistry {
+	r := &DexRegistry{factories: make(map[string]DexSourceFactory)}
+	r.Register(DexKindOsmosis, newOsmosisDexSource)
+	r.Register(DexKindCrescent, newCrescentDexSource)
+	r.Register(DexKindCosmWasm, newCosmWasmDexSource)
+	r.Register(DexKindFile, newFileDexSource)
+	return r
+}
+
+// Register καταχωρεί (ή αντικαθιστά) το factory ενός είδους πηγής
+func (r *DexRegistry) Register(kind string, factory DexSourceFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[kind] = factory
+}
+
+// Kinds - Τα καταχωρημένα είδη, ταξινομημένα
+func (r *DexRegistry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kinds := make([]string, 0, len(r.factories))
+	for kind := range r.factories {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Build δημιουργεί μια πηγή από την περιγραφή της
+func (r *DexRegistry) Build(cfg DexSourceConfig) (DexSource, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[cfg.Kind]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("άγνωστο είδος DEX source: %q (διαθέσιμα: %v)", cfg.Kind, r.Kinds())
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPoolPageSize
+	}
+	return factory(cfg)
+}
+
+// BuildAll δημιουργεί τις πηγές με τη σειρά του config. Πηγές που αποτυγχάνουν ή που
+// επαναλαμβάνουν μια αλυσίδα παραλείπονται με log: κάθε αλυσίδα έχει μία πηγή, αφού οι
+// token prices αντικαθίστανται ανά αλυσίδα στο storage.
+func (r *DexRegistry) BuildAll(configs []DexSourceConfig) []DexSource {
+	sources := make([]DexSource, 0, len(configs))
+	chains := make(map[string]string)
+
+	for _, cfg := range configs {
+		source, err := r.Build(cfg)
+		if err != nil {
+			log.Printf("⚠️  Η πηγή %s παραλείπεται: %v", cfg.Kind, err)
+			continue
+		}
+		if existing, ok := chains[source.Chain()]; ok {
+			log.Printf("⚠️  Η πηγή %s παραλείπεται: η αλυσίδα %s έχει ήδη την πηγή %s", source.Name(), source.Chain(), existing)
+			continue
+		}
+		chains[source.Chain()] = source.Name()
+		sources = append(sources, source)
+	}
+
+	return sources
+}
+
+// loadSourceEndpoints - LCD nodes της αλυσίδας από το chain.json. Για το Osmosis, αν το
+// chain registry λείπει, αρκεί ο DefaultLCDEndpoint.
+func loadSourceEndpoints(chain string) (*EndpointPool, error) {
+	endpoints, err := LoadEndpointPool(chain)
+	if err != nil {
+		if chain != types.DefaultChain {
+			return nil, err
+		}
+		log.Printf("⚠️  Αποτυχία φόρτωσης endpoints από chain.json, χρήση %s: %v", DefaultLCDEndpoint, err)
+		endpoints = NewEndpointPool(nil)
+	}
+	return endpoints, nil
+}
+
+// OsmosisDexSource - gamm και concentrated liquidity pools του Osmosis
+type OsmosisDexSource struct {
+	*OsmosisPoolClient
+	pageSize   int
+	clBalances *ConcentratedBalanceCache // Κοινό για όλους τους κύκλους
+}
+
+func newOsmosisDexSource(cfg DexSourceConfig) (DexSource, error) {
+	endpoints, err := loadSourceEndpoints(types.DefaultChain)
+	if err != nil {
+		return nil, err
+	}
+	return &OsmosisDexSource{
+		OsmosisPoolClient: NewOsmosisPoolClient(endpoints),
+		pageSize:          cfg.PageSize,
+		clBalances:        NewConcentratedBalanceCache(),
+	}, nil
+}
+
+func (s *OsmosisDexSource) Name() string { return s.chain + "-lcd" }
+
+// FetchPools - Τα gamm pools είναι απαραίτητα· τα CL pools προστίθενται αν ο node τα εκθέτει
+func (s *OsmosisDexSource) FetchPools(ctx context.Context) ([]types.OsmosisPool, []types.PoolFetchReport, error) {
+	pools, report, err := s.GetAllPools(ctx, s.pageSize)
+	reports := []types.PoolFetchReport{report}
+	if err != nil {
+		return nil, reports, err
+	}
+
+	// Concentrated liquidity pools (δεν επιστρέφονται από το gamm endpoint)
+	clPools, clReport, err := s.GetConcentratedPoolsAsOsmosisPools(ctx, s.pageSize, s.clBalances)
+	reports = append(reports, clReport)
+	switch {
+	case ctx.Err() != nil:
+		return nil, reports, ctx.Err()
+	case errors.Is(err, ErrNotFound):
+		// Ο node δεν εκθέτει το concentrated liquidity module
+	case err != nil:
+		log.Printf("⚠️  Αποτυχία λήψης CL pools: %v", err)
+	default:
+		pools = append(pools, clPools...)
+	}
+
+	return pools, reports, nil
+}
+
+// PriceModel - Weighted, stableswap ή concentrated ανάλογα με το "@type"
+func (s *OsmosisDexSource) PriceModel(pool types.OsmosisPool) PriceModel {
+	return PriceModelForPool(pool)
+}
+
+// CrescentDexSource - Τα basic pools του liquidity module του Crescent
+type CrescentDexSource struct {
+	*CrescentPoolClient
+	pageSize int
+}
+
+func newCrescentDexSource(cfg DexSourceConfig) (DexSource, error) {
+	endpoints, err := loadSourceEndpoints("crescent")
+	if err != nil {
+		return nil, err
+	}
+	return &CrescentDexSource{CrescentPoolClient: NewCrescentPoolClient(endpoints), pageSize: cfg.PageSize}, nil
+}
+
+func (s *CrescentDexSource) Name() string { return s.chain + "-lcd" }
+
+func (s *CrescentDexSource) FetchPools(ctx context.Context) ([]types.OsmosisPool, []types.PoolFetchReport, error) {
+	pools, report, err := s.GetAllPools(ctx, s.pageSize)
+	return pools, []types.PoolFetchReport{report}, err
+}
+
+// PriceModel - Τα basic pools είναι constant-product
+func (s *CrescentDexSource) PriceModel(pool types.OsmosisPool) PriceModel {
+	return WeightedModel{}
+}
+
+// CosmWasmDexSource - Astroport/Terraswap-style factory μιας αλυσίδας
+type CosmWasmDexSource struct {
+	*CosmWasmDexClient
+}
+
+func newCosmWasmDexSource(cfg DexSourceConfig) (DexSource, error) {
+	if cfg.Chain == "" {
+		return nil, fmt.Errorf("το cosmwasm source χρειάζεται αλυσίδα")
+	}
+	if cfg.Contract == "" {
+		return nil, fmt.Errorf("δεν έχει οριστεί factory contract για %s", cfg.Chain)
+	}
+	endpoints, err := loadSourceEndpoints(cfg.Chain)
+	if err != nil {
+		return nil, err
+	}
+	return &CosmWasmDexSource{CosmWasmDexClient: NewCosmWasmDexClient(cfg.Chain, cfg.Contract, endpoints)}, nil
+}
+
+func (s *CosmWasmDexSource) Name() string { return s.chain + "-cosmwasm" }
+
+func (s *CosmWasmDexSource) FetchPools(ctx context.Context) ([]types.OsmosisPool, []types.PoolFetchReport, error) {
+	pools, report, err := s.GetAllPools(ctx)
+	return pools, []types.PoolFetchReport{report}, err
+}
+
+// PriceModel - xyk pairs ως constant-product, stable pairs με το Curve invariant και το amp του pair
+func (s *CosmWasmDexSource) PriceModel(pool types.OsmosisPool) PriceModel {
+	return PriceModelForPool(pool)
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// DefaultLCDEndpoint - Ο επίσημος LCD node, πρώτος στη σειρά όταν δεν υπάρχουν μετρήσεις
+const DefaultLCDEndpoint = "https://lcd.osmosis.zone"
+
+const (
+	// endpointPriorLatency - Υποθετικό latency για endpoints που δεν έχουν δοκιμαστεί
+	endpointPriorLatency = 500 * time.Millisecond
+	// endpointEWMAAlpha - Βάρος της νέας μέτρησης στους κινητούς μέσους
+	endpointEWMAAlpha = 0.2
+	// endpointErrorPenalty - Πόσο "ακριβαίνει" ένα endpoint ανά μονάδα error rate
+	endpointErrorPenalty = 4.0
+	// endpointRotation - Μοιράζουμε τα requests στα N καλύτερα endpoints
+	endpointRotation = 3
+	// endpointFailureThreshold - Διαδοχικές αποτυχίες πριν το cooldown
+	endpointFailureThreshold = 3
+	// endpointBaseCooldown / endpointMaxCooldown - Εκθετικό cooldown για nodes που αποτυγχάνουν
+	endpointBaseCooldown = 30 * time.Second
+	endpointMaxCooldown  = 5 * time.Minute
+)
+
+// endpointState - Μετρήσεις ενός endpoint
+type endpointState struct {
+	address             string
+	provider            string
+	latency             float64 // EWMA σε ms (0 = χωρίς μέτρηση)
+	errorRate           float64 // EWMA των αποτυχιών (0..1)
+	requests            int64
+	failures            int64
+	consecutiveFailures int
+	lastError           string
+	lastSuccess         time.Time
+	cooldownUntil       time.Time
+}
+
+// score - Latency σταθμισμένο με το error rate (μικρότερο = καλύτερο)
+func (e *endpointState) score() float64 {
+	latency := e.latency
+	if latency == 0 {
+		latency = float64(endpointPriorLatency.Milliseconds())
+	}
+	return latency * (1 + endpointErrorPenalty*e.errorRate)
+}
+
+// EndpointPool - Λίστα LCD nodes με βαθμολόγηση, εναλλαγή και failover
+type EndpointPool struct {
+	mu        sync.Mutex
+	endpoints []*endpointState
+	next      int // Δείκτης round-robin στα καλύτερα endpoints
+}
+
+// NewEndpointPool - Pool από λίστα endpoints (διπλότυπα και κενά αγνοούνται)
+func NewEndpointPool(endpoints []types.ChainEndpoint) *EndpointPool {
+	pool := &EndpointPool{}
+	seen := make(map[string]bool)
+
+	for _, endpoint := range endpoints {
+		address := strings.TrimRight(strings.TrimSpace(endpoint.Address), "/")
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		pool.endpoints = append(pool.endpoints, &endpointState{address: address, provider: endpoint.Provider})
+	}
+
+	if len(pool.endpoints) == 0 {
+		pool.endpoints = append(pool.endpoints, &endpointState{address: DefaultLCDEndpoint, provider: "Osmosis Foundation"})
+	}
+
+	return pool
+}
+
+// LoadEndpointPool - Τα REST endpoints του chain.json (για το Osmosis με τον επίσημο node πρώτο)
+func LoadEndpointPool(chain string) (*EndpointPool, error) {
+	info, err := types.LoadChainInfo(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []types.ChainEndpoint
+	if chain == types.DefaultChain {
+		endpoints = append(endpoints, types.ChainEndpoint{Address: DefaultLCDEndpoint, Provider: "Osmosis Foundation"})
+	}
+	for _, endpoint := range info.Apis.Rest {
+		// Κρατάμε μόνο HTTPS nodes
+		if strings.HasPrefix(endpoint.Address, "https://") {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("δεν βρέθηκαν HTTPS REST endpoints για %s", chain)
+	}
+
+	return NewEndpointPool(endpoints), nil
+}
+
+// Next επιλέγει endpoint: εναλλάσσει τα καλύτερα διαθέσιμα (εκτός cooldown), παραλείποντας
+// όσα έχουν ήδη δοκιμαστεί σε αυτό το request. Αν όλα είναι σε cooldown, επιστρέφει αυτό που
+// λήγει πρώτο. Κενό string σημαίνει ότι δοκιμάστηκαν όλα.
+func (p *EndpointPool) Next(tried map[string]bool) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var available, cooling []*endpointState
+	for _, endpoint := range p.endpoints {
+		if tried[endpoint.address] {
+			continue
+		}
+		if now.Before(endpoint.cooldownUntil) {
+			cooling = append(cooling, endpoint)
+		} else {
+			available = append(available, endpoint)
+		}
+	}
+
+	if len(available) == 0 {
+		if len(cooling) == 0 {
+			return ""
+		}
+		sort.SliceStable(cooling, func(i, j int) bool {
+			return cooling[i].cooldownUntil.Before(cooling[j].cooldownUntil)
+		})
+		return cooling[0].address
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].score() < available[j].score()
+	})
+
+	candidates := available
+	if len(candidates) > endpointRotation {
+		candidates = candidates[:endpointRotation]
+	}
+	address := candidates[p.next%len(candidates)].address
+	p.next++
+	return address
+}
+
+// ReportSuccess καταγράφει ένα επιτυχημένο request
+func (p *EndpointPool) ReportSuccess(address string, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint := p.find(address)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.requests++
+	endpoint.latency = ewma(endpoint.latency, float64(latency.Microseconds())/1000)
+	endpoint.errorRate = (1 - endpointEWMAAlpha) * endpoint.errorRate
+	endpoint.consecutiveFailures = 0
+	endpoint.cooldownUntil = time.Time{}
+	endpoint.lastSuccess = time.Now()
+}
+
+// ReportFailure καταγράφει αποτυχία· μετά από αρκετές διαδοχικές το endpoint μπαίνει σε cooldown
+func (p *EndpointPool) ReportFailure(address string, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint := p.find(address)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.requests++
+	endpoint.failures++
+	endpoint.consecutiveFailures++
+	endpoint.errorRate = endpointEWMAAlpha + (1-endpointEWMAAlpha)*endpoint.errorRate
+	if latency > 0 {
+		endpoint.latency = ewma(endpoint.latency, float64(latency.Microseconds())/1000)
+	}
+	if err != nil {
+		endpoint.lastError = err.Error()
+	}
+
+	if endpoint.consecutiveFailures >= endpointFailureThreshold {
+		exponent := endpoint.consecutiveFailures - endpointFailureThreshold
+		cooldown := time.Duration(float64(endpointBaseCooldown) * math.Pow(2, float64(exponent)))
+		if cooldown > endpointMaxCooldown {
+			cooldown = endpointMaxCooldown
+		}
+		endpoint.cooldownUntil = time.Now().Add(cooldown)
+	}
+}
+
+// Health - Κατάσταση όλων των endpoints, τα καλύτερα πρώτα
+func (p *EndpointPool) Health() []types.EndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	result := make([]types.EndpointHealth, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		result = append(result, types.EndpointHealth{
+			Address:             endpoint.address,
+			Provider:            endpoint.provider,
+			Healthy:             !now.Before(endpoint.cooldownUntil),
+			Score:               math.Round(endpoint.score()*100) / 100,
+			LatencyMs:           math.Round(endpoint.latency*100) / 100,
+			ErrorRate:           math.Round(endpoint.errorRate*1000) / 1000,
+			Requests:            endpoint.requests,
+			Failures:            endpoint.failures,
+			ConsecutiveFailures: endpoint.consecutiveFailures,
+			LastError:           endpoint.lastError,
+			LastSuccess:         endpoint.lastSuccess,
+			CooldownUntil:       endpoint.cooldownUntil,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Healthy != result[j].Healthy {
+			return result[i].Healthy
+		}
+		return result[i].Score < result[j].Score
+	})
+
+	return result
+}
+
+// Len - Πλήθος endpoints
+func (p *EndpointPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.endpoints)
+}
+
+func (p *EndpointPool) find(address string) *endpointState {
+	for _, endpoint := range p.endpoints {
+		if endpoint.address == address {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// ewma - Εκθετικός κινητός μέσος για το latency (η πρώτη μέτρηση γίνεται δεκτή ως έχει)
+func ewma(current, sample float64) float64 {
+	if current == 0 {
+		return sample
+	}
+	return endpointEWMAAlpha*sample + (1-endpointEWMAAlpha)*current
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+func testEndpointPool(addresses ...string) *EndpointPool {
+	endpoints := make([]types.ChainEndpoint, 0, len(addresses))
+	for _, address := range addresses {
+		endpoints = append(endpoints, types.ChainEndpoint{Address: address})
+	}
+	return NewEndpointPool(endpoints)
+}
+
+func TestEWMA(t *testing.T) {
+	tests := []struct {
+		current, sample, want float64
+	}{
+		{0, 100, 100}, // Η πρώτη μέτρηση γίνεται δεκτή ως έχει
+		{100, 200, 120},
+		{120, 120, 120},
+		{200, 100, 180},
+	}
+	for _, tt := range tests {
+		if got := ewma(tt.current, tt.sample); !closeTo(got, tt.want, 1e-9) {
+			t.Errorf("ewma(%v, %v) = %v, want %v", tt.current, tt.sample, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		latency   float64
+		errorRate float64
+		want      float64
+	}{
+		{"untested", 0, 0, 500},
+		{"fast", 100, 0, 100},
+		{"fast with errors", 100, 0.25, 200},
+		{"untested with errors", 0, 0.5, 1500},
+	}
+	for _, tt := range tests {
+		endpoint := &endpointState{latency: tt.latency, errorRate: tt.errorRate}
+		if got := endpoint.score(); !closeTo(got, tt.want, 1e-9) {
+			t.Errorf("%s: score %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointPoolReports(t *testing.T) {
+	pool := testEndpointPool("https://a")
+
+	pool.ReportSuccess("https://a", 100*time.Millisecond)
+	pool.ReportSuccess("https://a", 200*time.Millisecond)
+	pool.ReportFailure("https://a", 0, errors.New("timeout"))
+	pool.ReportSuccess("https://unknown", time.Second) // Άγνωστα endpoints αγνοούνται
+
+	health := pool.Health()[0]
+	// latency: 100 → 0.2·200 + 0.8·100 = 120 (η αποτυχία χωρίς latency δεν το αλλάζει)
+	// error rate: 0 → 0 → 0.2
+	if !closeTo(health.LatencyMs, 120, 1e-9) || !closeTo(health.ErrorRate, 0.2, 1e-9) || !closeTo(health.Score, 120*1.8, 1e-9) {
+		t.Errorf("latency %v error rate %v score %v, want 120, 0.2, 216", health.LatencyMs, health.ErrorRate, health.Score)
+	}
+	if health.Requests != 3 || health.Failures != 1 || health.ConsecutiveFailures != 1 || health.LastError != "timeout" {
+		t.Errorf("health %+v", health)
+	}
+	if !health.Healthy || health.LastSuccess.IsZero() {
+		t.Errorf("one failure benched the endpoint: %+v", health)
+	}
+
+	pool.ReportSuccess("https://a", 120*time.Millisecond)
+	health = pool.Health()[0]
+	if !closeTo(health.ErrorRate, 0.16, 1e-9) || health.ConsecutiveFailures != 0 {
+		t.Errorf("after success: error rate %v, %d consecutive failures", health.ErrorRate, health.ConsecutiveFailures)
+	}
+}
+
+func TestEndpointPoolCooldown(t *testing.T) {
+	pool := testEndpointPool("https://a", "https://b")
+
+	tests := []struct {
+		failures int
+		cooldown time.Duration
+	}{
+		{1, 0},
+		{2, 0},
+		{3, endpointBaseCooldown},
+		{4, 2 * endpointBaseCooldown},
+		{5, 4 * endpointBaseCooldown},
+		{7, endpointMaxCooldown},
+	}
+	failures := 0
+	for _, tt := range tests {
+		for failures < tt.failures {
+			pool.ReportFailure("https://a", 10*time.Millisecond, errors.New("502"))
+			failures++
+		}
+		endpoint := pool.find("https://a")
+		if tt.cooldown == 0 {
+			if !endpoint.cooldownUntil.IsZero() {
+				t.Errorf("%d failures: cooldown until %v, want none", tt.failures, endpoint.cooldownUntil)
+			}
+			continue
+		}
+		if wait := time.Until(endpoint.cooldownUntil); wait > tt.cooldown || wait < tt.cooldown-time.Second {
+			t.Errorf("%d failures: cooldown %v, want %v", tt.failures, wait, tt.cooldown)
+		}
+	}
+
+	// Το endpoint σε cooldown δεν επιλέγεται και εμφανίζεται τελευταίο στο Health
+	for i := 0; i < 5; i++ {
+		if address := pool.Next(nil); address != "https://b" {
+			t.Fatalf("Next() = %s while https://a is benched", address)
+		}
+	}
+	health := pool.Health()
+	if health[0].Address != "https://b" || health[1].Healthy {
+		t.Errorf("health order %s (healthy %v), %s (healthy %v)", health[0].Address, health[0].Healthy, health[1].Address, health[1].Healthy)
+	}
+
+	// Μία επιτυχία τερματίζει το cooldown
+	pool.ReportSuccess("https://a", 10*time.Millisecond)
+	if endpoint := pool.find("https://a"); !endpoint.cooldownUntil.IsZero() || endpoint.consecutiveFailures != 0 {
+		t.Errorf("cooldown %v after success", endpoint.cooldownUntil)
+	}
+}
+
+func TestEndpointPoolRotation(t *testing.T) {
+	pool := testEndpointPool("https://a", "https://b", "https://c", "https://d", "https://e")
+	latencies := map[string]time.Duration{
+		"https://a": 400 * time.Millisecond,
+		"https://b": 50 * time.Millisecond,
+		"https://c": 300 * time.Millisecond,
+		"https://d": 100 * time.Millisecond,
+		"https://e": 200 * time.Millisecond,
+	}
+	for address, latency := range latencies {
+		pool.ReportSuccess(address, latency)
+	}
+
+	// Εναλλαγή στα 3 καλύτερα (b, d, e) με σειρά score
+	want := []string{"https://b", "https://d", "https://e", "https://b", "https://d", "https://e"}
+	for i, expected := range want {
+		if got := pool.Next(nil); got != expected {
+			t.Errorf("Next() #%d = %s, want %s", i, got, expected)
+		}
+	}
+
+	// Ένα endpoint με timeouts χάνει τη θέση του στα καλύτερα, πριν μπει σε cooldown
+	pool.ReportFailure("https://b", time.Second, errors.New("timeout"))
+	pool.ReportFailure("https://b", time.Second, errors.New("timeout"))
+	seen := make(map[string]bool)
+	for i := 0; i < endpointRotation; i++ {
+		seen[pool.Next(nil)] = true
+	}
+	if seen["https://b"] || !seen["https://c"] {
+		t.Errorf("rotation %v, want d, e, c after b's failures", seen)
+	}
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	pool := testEndpointPool("https://a", "https://b", "https://c")
+
+	// Κάθε request δοκιμάζει διαφορετικό endpoint μέχρι να εξαντληθούν
+	tried := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		address := pool.Next(tried)
+		if address == "" || tried[address] {
+			t.Fatalf("attempt %d: Next() = %q after %v", i, address, tried)
+		}
+		tried[address] = true
+	}
+	if address := pool.Next(tried); address != "" {
+		t.Errorf("Next() = %s after all endpoints were tried", address)
+	}
+
+	// Όταν όλα είναι σε cooldown, επιστρέφεται αυτό που λήγει πρώτο:
+	// a και b 30s (το b μπαίνει αργότερα), c 60s
+	failures := map[string]int{"https://a": 3, "https://b": 3, "https://c": 4}
+	for _, address := range []string{"https://a", "https://b", "https://c"} {
+		for i := 0; i < failures[address]; i++ {
+			pool.ReportFailure(address, 0, errors.New("down"))
+		}
+	}
+	if address := pool.Next(nil); address != "https://a" {
+		t.Errorf("Next() = %s with every endpoint benched, want the earliest cooldown https://a", address)
+	}
+}
+
+func TestNewEndpointPool(t *testing.T) {
+	pool := NewEndpointPool([]types.ChainEndpoint{
+		{Address: " https://a/ ", Provider: "A"},
+		{Address: "https://a"},
+		{Address: ""},
+		{Address: "https://b", Provider: "B"},
+	})
+	if pool.Len() != 2 || pool.endpoints[0].address != "https://a" || pool.endpoints[0].provider != "A" {
+		t.Errorf("endpoints %+v", pool.endpoints)
+	}
+
+	if pool := NewEndpointPool(nil); pool.Len() != 1 || pool.Next(nil) != DefaultLCDEndpoint {
+		t.Errorf("empty pool without the default LCD")
+	}
+}
+
+func TestLoadEndpointPool(t *testing.T) {
+	pool, err := LoadEndpointPool(types.DefaultChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Len() < 2 {
+		t.Fatalf("%d endpoints, want the chain.json nodes", pool.Len())
+	}
+	if pool.endpoints[0].address != DefaultLCDEndpoint {
+		t.Errorf("first endpoint %s, want %s", pool.endpoints[0].address, DefaultLCDEndpoint)
+	}
+	if address := pool.Next(nil); address != DefaultLCDEndpoint {
+		t.Errorf("Next() = %s on a fresh pool, want %s", address, DefaultLCDEndpoint)
+	}
+	for i, endpoint := range pool.endpoints {
+		if !strings.HasPrefix(endpoint.address, "https://") {
+			t.Errorf("non-HTTPS endpoint %s", endpoint.address)
+		}
+		if i > 0 && endpoint.address == DefaultLCDEndpoint {
+			t.Errorf("default LCD listed twice")
+		}
+	}
+
+	if _, err := LoadEndpointPool("no-such-chain"); err == nil {
+		t.Error("unknown chain loaded")
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Τυποποιημένα σφάλματα του OsmosisPoolClient, για έλεγχο με errors.Is
+var (
+	// ErrRateLimited - Ο node απάντησε 429 (βλ. UpstreamError.RetryAfter)
+	ErrRateLimited = errors.New("υπέρβαση ορίου requests")
+	// ErrNotFound - Ο πόρος δεν υπάρχει (404), δεν έχει νόημα retry
+	ErrNotFound = errors.New("δεν βρέθηκε")
+	// ErrUpstream - Network error, 5xx ή άλλη μη αναμενόμενη απάντηση του node
+	ErrUpstream = errors.New("σφάλμα upstream")
+	// ErrCircuitOpen - Ο circuit breaker είναι ανοιχτός, το request δεν στάλθηκε
+	ErrCircuitOpen = errors.New("circuit breaker ανοιχτός")
+)
+
+// UpstreamError - Λεπτομέρειες μιας αποτυχημένης κλήσης στο LCD. Το Unwrap επιστρέφει
+// ένα από τα ErrRateLimited, ErrNotFound, ErrUpstream.
+type UpstreamError struct {
+	Kind       error
+	Endpoint   string
+	StatusCode int           // 0 για network errors
+	RetryAfter time.Duration // Από το header Retry-After (μόνο για 429)
+	Err        error         // Το αρχικό σφάλμα (network, κ.λπ.)
+}
+
+func (e *UpstreamError) Error() string {
+	switch {
+	case e.Endpoint == "":
+		return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+	case e.StatusCode != 0:
+		return fmt.Sprintf("%v: status code %d από %s", e.Kind, e.StatusCode, e.Endpoint)
+	case e.Err != nil:
+		return fmt.Sprintf("%v: %s: %v", e.Kind, e.Endpoint, e.Err)
+	default:
+		return fmt.Sprintf("%v: %s", e.Kind, e.Endpoint)
+	}
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Kind
+}
+
+// retryable - Network errors, 5xx και 429 αξίζουν νέα προσπάθεια
+func (e *UpstreamError) retryable() bool {
+	return e.Kind == ErrRateLimited || (e.Kind == ErrUpstream && (e.StatusCode == 0 || e.StatusCode >= http.StatusInternalServerError))
+}
+
+// newStatusError χαρτογραφεί ένα non-200 status στο αντίστοιχο τυποποιημένο σφάλμα
+func newStatusError(endpoint string, resp *http.Response) *UpstreamError {
+	err := &UpstreamError{Kind: ErrUpstream, Endpoint: endpoint, StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		err.Kind = ErrRateLimited
+		err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	case http.StatusNotFound:
+		err.Kind = ErrNotFound
+	}
+	return err
+}
+
+// parseRetryAfter - Δευτερόλεπτα ή HTTP-date (0 αν λείπει ή είναι άκυρο)
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// FileDexSource - Πηγή pools από αρχείο JSON, χωρίς δίκτυο. Χρησιμεύει για tests και
+// αναπαραγωγή snapshots: το αρχείο έχει τη μορφή μιας σελίδας του gamm endpoint
+// ({"pools": [...]}), οπότε ένα `curl .../osmosis/gamm/v1beta1/pools` αρκεί.
+// Διαβάζεται σε κάθε FetchPools, ώστε αλλαγές στο αρχείο να φαίνονται στον επόμενο κύκλο.
+type FileDexSource struct {
+	chain string
+	path  string
+}
+
+// NewFileDexSource - Πηγή για τα pools του αρχείου path (chain "" = osmosis)
+func NewFileDexSource(chain, path string) *FileDexSource {
+	if chain == "" {
+		chain = types.DefaultChain
+	}
+	return &FileDexSource{chain: chain, path: path}
+}
+
+func newFileDexSource(cfg DexSourceConfig) (DexSource, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("το file source χρειάζεται αρχείο pools")
+	}
+	if _, err := os.Stat(cfg.Path); err != nil {
+		return nil, fmt.Errorf("σφάλμα πρόσβασης στο %s: %w", cfg.Path, err)
+	}
+	return NewFileDexSource(cfg.Chain, cfg.Path), nil
+}
+
+func (s *FileDexSource) Name() string  { return "file:" + s.path }
+func (s *FileDexSource) Chain() string { return s.chain }
+func (s *FileDexSource) Path() string  { return s.path }
+
+// FetchPools διαβάζει το αρχείο και δίνει σε κάθε pool την αλυσίδα της πηγής
+func (s *FileDexSource) FetchPools(ctx context.Context) ([]types.OsmosisPool, []types.PoolFetchReport, error) {
+	start := time.Now()
+	report := types.PoolFetchReport{Source: s.Name(), Pages: 1}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		report.FailedPages = 1
+		return nil, []types.PoolFetchReport{finishFetchReport(report, start)}, fmt.Errorf("σφάλμα ανάγνωσης του %s: %w", s.path, err)
+	}
+
+	var page struct {
+		Pools []types.OsmosisPool `json:"pools"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		report.FailedPages = 1
+		return nil, []types.PoolFetchReport{finishFetchReport(report, start)}, fmt.Errorf("σφάλμα κατά το parsing του %s: %w", s.path, err)
+	}
+
+	for i := range page.Pools {
+		pool := &page.Pools[i]
+		pool.NormalizeAssets()
+		if pool.Chain == "" {
+			pool.Chain = s.chain
+		}
+		if !strings.HasPrefix(pool.Id, pool.Chain+":") {
+			pool.Id = types.ChainPoolID(pool.Chain, pool.Id)
+		}
+	}
+
+	report.Total = len(page.Pools)
+	report.Fetched = len(page.Pools)
+	return page.Pools, []types.PoolFetchReport{finishFetchReport(report, start)}, nil
+}
+
+// PriceModel - Όπως στο Osmosis: το "@type" κάθε pool επιλέγει το μοντέλο
+func (s *FileDexSource) PriceModel(pool types.OsmosisPool) PriceModel {
+	return PriceModelForPool(pool)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+const testFixturePath = "../data/fixtures/osmosis_pools.json"
+
+func TestFileDexSourceRegistry(t *testing.T) {
+	sources := NewDexRegistry().BuildAll([]DexSourceConfig{
+		{Kind: DexKindFile, Path: testFixturePath},
+		{Kind: DexKindFile, Chain: "crescent", Path: testFixturePath},
+		{Kind: DexKindFile, Chain: "crescent", Path: testFixturePath}, // Ίδια αλυσίδα: παραλείπεται
+		{Kind: DexKindFile, Chain: "archway", Path: "missing.json"},   // Δεν υπάρχει: παραλείπεται
+	})
+	if len(sources) != 2 {
+		t.Fatalf("got %d sources, want 2", len(sources))
+	}
+
+	tests := []struct {
+		chain   string
+		wantIDs []string
+	}{
+		{types.DefaultChain, []string{"1", "678", "1212"}},
+		{"crescent", []string{"crescent:1", "crescent:678", "crescent:1212"}},
+	}
+	for i, tt := range tests {
+		source := sources[i]
+		if source.Chain() != tt.chain {
+			t.Errorf("source %d: chain %s, want %s", i, source.Chain(), tt.chain)
+		}
+
+		pools, reports, err := source.FetchPools(context.Background())
+		if err != nil {
+			t.Fatalf("%s: FetchPools: %v", source.Name(), err)
+		}
+		if len(reports) != 1 || reports[0].Total != 3 || reports[0].Fetched != 3 || reports[0].FailedPages != 0 {
+			t.Errorf("%s: report %+v, want 3 of 3 pools", source.Name(), reports)
+		}
+		if len(pools) != len(tt.wantIDs) {
+			t.Fatalf("%s: got %d pools, want %d", source.Name(), len(pools), len(tt.wantIDs))
+		}
+		for j, pool := range pools {
+			if pool.Id != tt.wantIDs[j] || pool.Chain != tt.chain {
+				t.Errorf("%s: pool %s on %s, want %s on %s", source.Name(), pool.Id, pool.Chain, tt.wantIDs[j], tt.chain)
+			}
+		}
+	}
+}
+
+func TestFileDexSourcePriceModels(t *testing.T) {
+	source := NewFileDexSource("", testFixturePath)
+	pools, _, err := source.FetchPools(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantModels := map[string]PriceModel{
+		"1":    WeightedModel{},
+		"678":  WeightedModel{},
+		"1212": StableswapModel{},
+	}
+	for _, pool := range pools {
+		if model := source.PriceModel(pool); model != wantModels[pool.Id] {
+			t.Errorf("pool %s (%s): model %T, want %T", pool.Id, pool.PoolType(), model, wantModels[pool.Id])
+		}
+	}
+
+	// Το stableswap pool έχει μόνο pool_liquidity: τα PoolAssets γεμίζουν από αυτό
+	prices, err := ComputePoolPricesWithModel(pools, testAssetService(t), source.PriceModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[string]types.PoolPrice, len(prices))
+	for _, price := range prices {
+		byID[price.PoolID] = price
+	}
+
+	tests := []struct {
+		poolID, poolType string
+		price            float64
+	}{
+		{"1", types.PoolTypeBalancer, 20}, // 100000 ATOM / 2000000 OSMO
+		{"678", types.PoolTypeBalancer, 2},
+		{"1212", types.PoolTypeStableswap, 1},
+	}
+	for _, tt := range tests {
+		price, ok := byID[tt.poolID]
+		if !ok {
+			t.Errorf("pool %s: no price", tt.poolID)
+			continue
+		}
+		if price.PoolType != tt.poolType || !closeTo(price.PriceToken0ToToken1, tt.price, 1e-12) {
+			t.Errorf("pool %s: %s at %v, want %s at %v", tt.poolID, price.PoolType, price.PriceToken0ToToken1, tt.poolType, tt.price)
+		}
+	}
+}
+
+func TestFileDexSourceErrors(t *testing.T) {
+	if _, err := NewDexRegistry().Build(DexSourceConfig{Kind: DexKindFile}); err == nil {
+		t.Error("file source without a path built")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := NewFileDexSource("", testFixturePath).FetchPools(ctx); err == nil {
+		t.Error("FetchPools ignored a cancelled context")
+	}
+
+	_, reports, err := NewFileDexSource("", "missing.json").FetchPools(context.Background())
+	if err == nil || len(reports) != 1 || reports[0].FailedPages != 1 {
+		t.Errorf("missing file: error %v, reports %+v", err, reports)
+	}
+}
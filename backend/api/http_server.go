@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,11 +24,34 @@ type PriceData struct {
 type HTTPServer struct {
 	port                 int
 	priceData            *PriceData
+	serverMu             sync.Mutex
 	server               *http.Server
+	shuttingDown         bool
 	chainRegistryUpdater ChainRegistryUpdater
 	sqliteStorage        SQLiteStorageReader
+	streamHub            *PriceStreamHub
+	streamMu             sync.RWMutex
+	streamOrigins        map[string]bool // Επιπλέον origins του WebSocket /api/stream (βλ. SetStreamAllowedOrigins)
+
+	lcdMu        sync.RWMutex
+	lcdClients   map[string]*LCDClient // chain -> LCD client
+	chainMu      sync.RWMutex
+	chainTokens  map[string][]types.TokenInfo // chain -> tokens του τελευταίου κύκλου
+	fetchMu      sync.RWMutex
+	fetchReports map[string]types.PoolFetchReport // source -> τελευταίο report
+	assetMu      sync.RWMutex
+	assets       *types.AssetService      // Το κοινό AssetService του Osmosis (βλ. SetAssetService)
+	assetEvents  []types.AssetChangeEvent // Τα τελευταία reloads των assetlists
+	denomTraces  *DenomTraceResolver      // /api/denoms/{denom}/trace (denoms του Osmosis)
+	arbitrageMu  sync.RWMutex
+	arbitrage    *ArbitrageDetector // /api/arbitrage (nil αν είναι απενεργοποιημένο)
+	alertMu      sync.RWMutex
+	alerts       *AlertEngine // /api/alerts (nil αν είναι απενεργοποιημένο)
 }
 
+// maxAssetEvents - Πόσα asset change events κρατά το /api/chain-registry/status
+const maxAssetEvents = 20
+
 type SQLiteStorageReader interface {
 	GetLatestTokenPrices() ([]types.TokenPrice, error)
 	GetAllUniqueTokens() ([]types.TokenPrice, error)
@@ -32,7 +59,16 @@ type SQLiteStorageReader interface {
 	GetTokenPriceFromPools(symbol string) (*types.TokenPrice, error)
 	GetAllPoolsForToken(symbol string) ([]types.PoolPrice, error)
 	GetLatestPoolPrices() ([]types.PoolPrice, error)
+	GetPool(poolID string) (*types.OsmosisPool, error)
 	GetDatabaseStats() (map[string]interface{}, error)
+	GetPoolCandles(poolID, interval string, from, to time.Time) ([]types.Candle, error)
+	GetTokenCandles(symbol, interval string, from, to time.Time) ([]types.Candle, error)
+}
+
+// HistoryReader - Προαιρετικό: storage που κρατά ιστορικό τιμών (π.χ. SQLite)
+type HistoryReader interface {
+	GetTokenPriceHistory(symbol string, from, to time.Time) ([]types.TokenPrice, error)
+	GetPoolPriceHistory(poolID string, from, to time.Time) ([]types.PoolPrice, error)
 }
 
 type ChainRegistryUpdater interface {
@@ -48,6 +84,11 @@ func NewHTTPServer(port int, updater ChainRegistryUpdater, storage SQLiteStorage
 		},
 		chainRegistryUpdater: updater,
 		sqliteStorage:        storage,
+		streamHub:            NewPriceStreamHub(),
+		fetchReports:         make(map[string]types.PoolFetchReport),
+		lcdClients:           make(map[string]*LCDClient),
+		chainTokens:          make(map[string][]types.TokenInfo),
+		denomTraces:          NewDenomTraceResolver(nil, nil),
 	}
 }
 
@@ -61,40 +102,85 @@ func (s *HTTPServer) Start() error {
 	mux.HandleFunc("/api/tokens", s.handleGetAllTokens)
 	mux.HandleFunc("/api/tokens/", s.handleGetToken)
 	mux.HandleFunc("/api/pools", s.handleGetPools)
+	mux.HandleFunc("/api/pools/", s.handleGetPool)
 	mux.HandleFunc("/api/convert", s.handleConvert)
+	mux.HandleFunc("/api/swap/simulate", s.handleSimulateSwap)
+	mux.HandleFunc("/api/route", s.handleGetRoute)
+	mux.HandleFunc("/api/stream", s.handleStream)
+	mux.HandleFunc("/api/endpoints", s.handleGetEndpoints)
+	mux.HandleFunc("/api/chains", s.handleGetChains)
+	mux.HandleFunc("/api/chains/", s.handleGetChainTokens)
+	mux.HandleFunc("/api/denoms/", s.handleGetDenomTrace)
+	mux.HandleFunc("/api/arbitrage", s.handleGetArbitrage)
+	mux.HandleFunc("/api/alerts", s.handleAlerts)
+	mux.HandleFunc("/api/alerts/", s.handleAlert)
+	mux.HandleFunc("/api/portfolio/", s.handleGetPortfolio)
 	mux.HandleFunc("/api/chain-registry/update", s.handleForceUpdateChainRegistry)
 	mux.HandleFunc("/api/chain-registry/status", s.handleChainRegistryStatus)
 	mux.Handle("/", http.FileServer(http.Dir("static")))
 
-	s.server = &http.Server{
+	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", s.port),
 		Handler: mux,
 	}
+	// Τα streams είναι hijacked/long-lived: το Shutdown δεν τα περιμένει, τα κλείνουμε εμείς
+	server.RegisterOnShutdown(s.streamHub.Close)
+
+	s.serverMu.Lock()
+	if s.shuttingDown {
+		s.serverMu.Unlock()
+		return http.ErrServerClosed
+	}
+	s.server = server
+	s.serverMu.Unlock()
 
 	log.Println("🌐 HTTP Server started on port", s.port)
 	log.Println("📍 Endpoints:")
 	log.Println("   GET  /api/health")
-	log.Println("   GET  /api/tokens")
+	log.Println("   GET  /api/tokens?chain={chain}")
+	log.Println("   GET  /api/tokens/{symbol}/price")
 	log.Println("   GET  /api/tokens/{symbol}/pools")
-	log.Println("   GET  /api/pools")
+	log.Println("   GET  /api/tokens/{symbol}/history?from={t}&to={t}")
+	log.Println("   GET  /api/tokens/{symbol}/candles?interval=1m|5m|1h|1d")
+	log.Println("   GET  /api/pools?chain={chain}&min_tvl={usd}&sort=tvl")
+	log.Println("   GET  /api/pools/{id}/history?from={t}&to={t}")
+	log.Println("   GET  /api/pools/{id}/candles?interval=1m|5m|1h|1d")
+	log.Println("   GET  /api/convert?from={symbol}&to={symbol}&amount={n}")
+	log.Println("   POST /api/swap/simulate")
+	log.Println("   GET  /api/route?token_in={denom|symbol}&amount={base units}&token_out={denom|symbol}&slippage={0.005}")
+	log.Println("   GET  /api/stream?tokens={symbols}&pools={ids}&events=pool_prices,arbitrage (WebSocket / SSE)")
+	log.Println("   GET  /api/endpoints?chain={chain}")
+	log.Println("   GET  /api/chains")
+	log.Println("   GET  /api/chains/{chain}/tokens")
+	log.Println("   GET  /api/denoms/{denom}/trace")
+	log.Println("   GET  /api/arbitrage?chain={chain}&min_profit_usd={usd}&min_profit_pct={pct}")
+	log.Println("   GET|POST /api/alerts")
+	log.Println("   GET|PUT|DELETE /api/alerts/{id}")
+	log.Println("   GET  /api/alerts/events")
+	log.Println("   GET  /api/portfolio/{address}")
 	log.Println()
 
-	return s.server.ListenAndServe()
+	return server.ListenAndServe()
 }
 
+// loadChainRegistryTokens - Τα tokens του assetlist από το κοινό AssetService
+// (ή από το αρχείο, αν δεν έχει οριστεί)
 func (s *HTTPServer) loadChainRegistryTokens() error {
-	assetService, err := types.NewAssetService()
-	if err != nil {
-		return err
+	s.assetMu.RLock()
+	assetService := s.assets
+	s.assetMu.RUnlock()
+
+	if assetService == nil {
+		var err error
+		if assetService, err = types.NewAssetService(); err != nil {
+			return err
+		}
 	}
 
 	s.priceData.mu.Lock()
 	defer s.priceData.mu.Unlock()
 
-	s.priceData.AllTokens = make([]types.Asset, 0, len(assetService.TokenMetadata))
-	for _, asset := range assetService.TokenMetadata {
-		s.priceData.AllTokens = append(s.priceData.AllTokens, asset)
-	}
+	s.priceData.AllTokens = assetService.GetAllTokens()
 
 	log.Printf("✅ Loaded %d tokens from chain-registry", len(s.priceData.AllTokens))
 	return nil
@@ -109,9 +195,22 @@ func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	status := "healthy"
+	s.fetchMu.RLock()
+	poolFetch := make(map[string]types.PoolFetchReport, len(s.fetchReports))
+	for source, report := range s.fetchReports {
+		poolFetch[source] = report
+		if !report.Complete {
+			status = "degraded"
+		}
+	}
+	s.fetchMu.RUnlock()
+
 	response := map[string]interface{}{
-		"status":   "healthy",
-		"database": stats,
+		"status":         status,
+		"database":       stats,
+		"pool_fetch":     poolFetch,
+		"stream_clients": s.streamHub.ClientCount(),
 	}
 	json.NewEncoder(w).Encode(response)
 }
@@ -132,7 +231,32 @@ func (s *HTTPServer) handleGetToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Use /api/tokens/{symbol}/pools", http.StatusBadRequest)
+	if len(pathParts) > 1 && pathParts[1] == "history" {
+		s.handleGetTokenHistory(w, r, symbol)
+		return
+	}
+
+	if len(pathParts) > 1 && pathParts[1] == "candles" {
+		s.handleGetTokenCandles(w, r, symbol)
+		return
+	}
+
+	if len(pathParts) == 1 || pathParts[1] == "" || pathParts[1] == "price" {
+		s.handleGetTokenPrice(w, r, symbol)
+		return
+	}
+
+	http.Error(w, "Use /api/tokens/{symbol}/pools, /api/tokens/{symbol}/price, /api/tokens/{symbol}/history or /api/tokens/{symbol}/candles", http.StatusBadRequest)
+}
+
+func (s *HTTPServer) handleGetTokenPrice(w http.ResponseWriter, r *http.Request, symbol string) {
+	price, err := s.sqliteStorage.GetTokenPrice(symbol)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(price)
 }
 
 func (s *HTTPServer) handleGetTokenPools(w http.ResponseWriter, r *http.Request, symbol string) {
@@ -142,12 +266,21 @@ func (s *HTTPServer) handleGetTokenPools(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	pools, err = filterAndSortPools(pools, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	type PoolWithPrice struct {
 		PoolID       string    `json:"pool_id"`
+		ParentPoolID string    `json:"parent_pool_id,omitempty"`
 		PairedWith   string    `json:"paired_with"`
 		PairedDenom  string    `json:"paired_denom"`
 		TokenPrice   float64   `json:"token_price"`
 		InversePrice float64   `json:"inverse_price"`
+		TokenWeight  float64   `json:"token_weight"`
+		PairedWeight float64   `json:"paired_weight"`
 		LiquidityUSD float64   `json:"liquidity_usd"`
 		Timestamp    time.Time `json:"timestamp"`
 	}
@@ -155,26 +288,33 @@ func (s *HTTPServer) handleGetTokenPools(w http.ResponseWriter, r *http.Request,
 	result := make([]PoolWithPrice, 0, len(pools))
 	for _, pool := range pools {
 		var pairedSymbol, pairedDenom string
-		var tokenPrice, inversePrice float64
+		var tokenPrice, inversePrice, tokenWeight, pairedWeight float64
 
 		if pool.Token0Symbol == symbol {
 			pairedSymbol = pool.Token1Symbol
 			pairedDenom = pool.Token1Denom
 			tokenPrice = pool.PriceToken0ToToken1
 			inversePrice = pool.PriceToken1ToToken0
+			tokenWeight = pool.Token0Weight
+			pairedWeight = pool.Token1Weight
 		} else {
 			pairedSymbol = pool.Token0Symbol
 			pairedDenom = pool.Token0Denom
 			tokenPrice = pool.PriceToken1ToToken0
 			inversePrice = pool.PriceToken0ToToken1
+			tokenWeight = pool.Token1Weight
+			pairedWeight = pool.Token0Weight
 		}
 
 		result = append(result, PoolWithPrice{
 			PoolID:       pool.PoolID,
+			ParentPoolID: pool.ParentPoolID,
 			PairedWith:   pairedSymbol,
 			PairedDenom:  pairedDenom,
 			TokenPrice:   tokenPrice,
 			InversePrice: inversePrice,
+			TokenWeight:  tokenWeight,
+			PairedWeight: pairedWeight,
 			LiquidityUSD: pool.LiquidityUSD,
 			Timestamp:    pool.Timestamp,
 		})
@@ -204,9 +344,31 @@ func (s *HTTPServer) handleGetAllTokens(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	tokens, err := s.sqliteStorage.GetLatestTokenPrices()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if chain := r.URL.Query().Get("chain"); chain != "" {
+		filtered := tokens[:0]
+		for _, token := range tokens {
+			if token.Chain == chain {
+				filtered = append(filtered, token)
+			}
+		}
+		tokens = filtered
+	}
+
+	// Τα πιο liquid tokens πρώτα
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].LiquidityUSD > tokens[j].LiquidityUSD
+	})
+
 	response := map[string]interface{}{
-		"message":       "Token list",
-		"latest_update": stats["latest_record"],
+		"tokens":        tokens,
+		"count":         len(tokens),
+		"latest_update": stats["last_update"],
 	}
 
 	json.NewEncoder(w).Encode(response)
@@ -221,6 +383,12 @@ func (s *HTTPServer) handleGetPools(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pools, err = filterAndSortPools(pools, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var latestUpdate time.Time
 	if len(pools) > 0 {
 		latestUpdate = pools[0].Timestamp
@@ -235,9 +403,699 @@ func (s *HTTPServer) handleGetPools(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func (s *HTTPServer) handleGetPool(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/pools/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		http.Error(w, "Use /api/pools/{id}/history or /api/pools/{id}/candles", http.StatusBadRequest)
+		return
+	}
+
+	poolID := pathParts[0]
+
+	switch pathParts[1] {
+	case "history":
+		s.handleGetPoolHistory(w, r, poolID)
+	case "candles":
+		s.handleGetPoolCandles(w, r, poolID)
+	default:
+		http.Error(w, "Use /api/pools/{id}/history or /api/pools/{id}/candles", http.StatusBadRequest)
+	}
+}
+
+func (s *HTTPServer) handleGetTokenCandles(w http.ResponseWriter, r *http.Request, symbol string) {
+	interval, from, to, err := parseCandleQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	candles, err := s.sqliteStorage.GetTokenCandles(symbol, interval, from, to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"symbol":   symbol,
+		"interval": interval,
+		"quote":    "USD",
+		"candles":  candles,
+		"count":    len(candles),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *HTTPServer) handleGetPoolCandles(w http.ResponseWriter, r *http.Request, poolID string) {
+	interval, from, to, err := parseCandleQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	candles, err := s.sqliteStorage.GetPoolCandles(poolID, interval, from, to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pool_id":  poolID,
+		"interval": interval,
+		"quote":    "token1 per token0",
+		"candles":  candles,
+		"count":    len(candles),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseCandleQuery - interval (default 1m) και from/to όπως στο parseTimeRange
+func parseCandleQuery(r *http.Request) (string, time.Time, time.Time, error) {
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "1m"
+	}
+	switch interval {
+	case "1m", "5m", "1h", "1d":
+	default:
+		return "", time.Time{}, time.Time{}, fmt.Errorf("unsupported interval: %s", interval)
+	}
+
+	from, to, err := parseTimeRange(r)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	return interval, from, to, nil
+}
+
+func (s *HTTPServer) handleGetTokenHistory(w http.ResponseWriter, r *http.Request, symbol string) {
+	history, ok := s.sqliteStorage.(HistoryReader)
+	if !ok {
+		http.Error(w, "Price history requires sqlite storage", http.StatusNotImplemented)
+		return
+	}
+
+	from, to, err := parseTimeRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	prices, err := history.GetTokenPriceHistory(symbol, from, to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"symbol": symbol,
+		"from":   from,
+		"to":     to,
+		"prices": prices,
+		"count":  len(prices),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *HTTPServer) handleGetPoolHistory(w http.ResponseWriter, r *http.Request, poolID string) {
+	history, ok := s.sqliteStorage.(HistoryReader)
+	if !ok {
+		http.Error(w, "Price history requires sqlite storage", http.StatusNotImplemented)
+		return
+	}
+
+	from, to, err := parseTimeRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	prices, err := history.GetPoolPriceHistory(poolID, from, to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pool_id": poolID,
+		"from":    from,
+		"to":      to,
+		"prices":  prices,
+		"count":   len(prices),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseTimeRange διαβάζει τα from/to (RFC3339 ή unix seconds), default οι τελευταίες 24 ώρες
+func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	to := time.Now()
+	if value := query.Get("to"); value != "" {
+		parsed, err := parseTimeParam(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to")
+		}
+		to = parsed
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if value := query.Get("from"); value != "" {
+		parsed, err := parseTimeParam(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from")
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+	return from, to, nil
+}
+
+// parseTimeParam - RFC3339 ή unix timestamp σε δευτερόλεπτα
+func parseTimeParam(value string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// filterAndSortPools εφαρμόζει τα query params min_tvl, max_tvl, sort=tvl και order=asc|desc.
+// Όταν χρησιμοποιείται το TVL, pools με LiquidityUnknown παραλείπονται.
+func filterAndSortPools(pools []types.PoolPrice, r *http.Request) ([]types.PoolPrice, error) {
+	query := r.URL.Query()
+
+	minTVL, err := parseOptionalFloat(query.Get("min_tvl"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid min_tvl")
+	}
+	maxTVL, err := parseOptionalFloat(query.Get("max_tvl"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid max_tvl")
+	}
+
+	chain := query.Get("chain")
+	// Pools με άγνωστο TVL (CL χωρίς balances) δεν συγκρίνονται με το 0 που φέρουν
+	byTVL := minTVL != nil || maxTVL != nil || query.Get("sort") == "tvl"
+
+	filtered := make([]types.PoolPrice, 0, len(pools))
+	for _, pool := range pools {
+		if chain != "" && pool.Chain != chain {
+			continue
+		}
+		if byTVL && pool.LiquidityUnknown {
+			continue
+		}
+		if minTVL != nil && pool.LiquidityUSD < *minTVL {
+			continue
+		}
+		if maxTVL != nil && pool.LiquidityUSD > *maxTVL {
+			continue
+		}
+		filtered = append(filtered, pool)
+	}
+
+	switch query.Get("sort") {
+	case "":
+	case "tvl":
+		ascending := query.Get("order") == "asc"
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if ascending {
+				return filtered[i].LiquidityUSD < filtered[j].LiquidityUSD
+			}
+			return filtered[i].LiquidityUSD > filtered[j].LiquidityUSD
+		})
+	default:
+		return nil, fmt.Errorf("unsupported sort: %s", query.Get("sort"))
+	}
+
+	return filtered, nil
+}
+
+// poolPricesForChain - Οι pool prices μιας αλυσίδας (κενό = osmosis· εγγραφές χωρίς
+// chain θεωρούνται osmosis)
+func poolPricesForChain(pools []types.PoolPrice, chain string) []types.PoolPrice {
+	if chain == "" {
+		chain = types.DefaultChain
+	}
+	result := make([]types.PoolPrice, 0, len(pools))
+	for _, pool := range pools {
+		if pool.Chain == chain || (pool.Chain == "" && chain == types.DefaultChain) {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
+
+// parseOptionalFloat επιστρέφει nil για κενό query param
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
 func (s *HTTPServer) handleConvert(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Coming soon"})
+
+	query := r.URL.Query()
+	from := strings.TrimSpace(query.Get("from"))
+	to := strings.TrimSpace(query.Get("to"))
+	if from == "" || to == "" {
+		http.Error(w, "Parameters 'from' and 'to' required", http.StatusBadRequest)
+		return
+	}
+
+	amount := 1.0
+	if amountStr := query.Get("amount"); amountStr != "" {
+		parsed, err := strconv.ParseFloat(amountStr, 64)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+		amount = parsed
+	}
+
+	pools, err := s.sqliteStorage.GetLatestPoolPrices()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	// Μόνο pools μίας αλυσίδας: μια διαδρομή δεν μπορεί να περάσει από pools δύο αλυσίδων
+	result, err := NewTokenConverter(poolPricesForChain(pools, query.Get("chain"))).Convert(from, to, amount)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *HTTPServer) handleSimulateSwap(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request struct {
+		PoolID        string          `json:"pool_id"`
+		TokenIn       types.BasicCoin `json:"token_in"`
+		TokenOutDenom string          `json:"token_out_denom"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if request.PoolID == "" || request.TokenIn.Denom == "" || request.TokenOutDenom == "" {
+		http.Error(w, "Fields 'pool_id', 'token_in' and 'token_out_denom' required", http.StatusBadRequest)
+		return
+	}
+
+	pool, err := s.sqliteStorage.GetPool(request.PoolID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	result, err := SimulateSwapLocal(*pool, request.TokenIn, request.TokenOutDenom)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleGetRoute - Smart order routing με split σε παράλληλες διαδρομές πάνω στα pools της cache
+func (s *HTTPServer) handleGetRoute(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	tokenIn := strings.TrimSpace(query.Get("token_in"))
+	tokenOut := strings.TrimSpace(query.Get("token_out"))
+	amount := strings.TrimSpace(query.Get("amount"))
+	if tokenIn == "" || tokenOut == "" || amount == "" {
+		http.Error(w, "Parameters 'token_in', 'token_out' and 'amount' required", http.StatusBadRequest)
+		return
+	}
+
+	slippage := DefaultSlippageTolerance
+	if parsed, err := parseOptionalFloat(query.Get("slippage")); err != nil {
+		http.Error(w, "Invalid slippage", http.StatusBadRequest)
+		return
+	} else if parsed != nil {
+		slippage = *parsed
+	}
+
+	pools, err := s.sqliteStorage.GetLatestPoolPrices()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	chainPools := poolPricesForChain(pools, query.Get("chain"))
+
+	router := NewSwapRouter(DefaultRouterConfig(), chainPools, s.sqliteStorage.GetPool)
+	result, err := router.Route(types.BasicCoin{Denom: tokenIn, Amount: amount}, tokenOut, slippage)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed: %v", err), status)
+		return
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *HTTPServer) handleGetEndpoints(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	chain := r.URL.Query().Get("chain")
+	if chain == "" {
+		chain = types.DefaultChain
+	}
+
+	s.lcdMu.RLock()
+	client := s.lcdClients[chain]
+	chains := make([]string, 0, len(s.lcdClients))
+	for name := range s.lcdClients {
+		chains = append(chains, name)
+	}
+	s.lcdMu.RUnlock()
+	sort.Strings(chains)
+
+	if client == nil {
+		http.Error(w, fmt.Sprintf("LCD client not configured for chain %s", chain), http.StatusServiceUnavailable)
+		return
+	}
+
+	endpoints := client.Endpoints().Health()
+	healthy := 0
+	for _, endpoint := range endpoints {
+		if endpoint.Healthy {
+			healthy++
+		}
+	}
+
+	response := map[string]interface{}{
+		"chain":           chain,
+		"chains":          chains,
+		"endpoints":       endpoints,
+		"count":           len(endpoints),
+		"healthy":         healthy,
+		"circuit_breaker": client.CircuitBreaker().Status(),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleGetChains - Οι αλυσίδες του collector με το πλήθος των tokens που τιμολογήθηκαν
+func (s *HTTPServer) handleGetChains(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	s.chainMu.RLock()
+	chains := make([]map[string]interface{}, 0, len(s.chainTokens))
+	for chain, tokens := range s.chainTokens {
+		chains = append(chains, map[string]interface{}{
+			"chain":       chain,
+			"token_count": len(tokens),
+		})
+	}
+	s.chainMu.RUnlock()
+
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i]["chain"].(string) < chains[j]["chain"].(string)
+	})
+
+	response := map[string]interface{}{
+		"chains": chains,
+		"count":  len(chains),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleGetChainTokens - /api/chains/{chain}/tokens
+func (s *HTTPServer) handleGetChainTokens(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/chains/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "tokens" {
+		http.Error(w, "Use /api/chains/{chain}/tokens", http.StatusBadRequest)
+		return
+	}
+	chain := pathParts[0]
+
+	s.chainMu.RLock()
+	tokens, ok := s.chainTokens[chain]
+	s.chainMu.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("Chain %s not collected", chain), http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"chain":  chain,
+		"tokens": tokens,
+		"count":  len(tokens),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleGetDenomTrace - /api/denoms/{denom}/trace. Το denom μπορεί να περιέχει "/"
+// (ibc/HASH, factory/...), οπότε είναι ό,τι βρίσκεται ανάμεσα στο prefix και το /trace.
+func (s *HTTPServer) handleGetDenomTrace(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	denom, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/denoms/"), "/trace")
+	if !ok || denom == "" {
+		http.Error(w, "Use /api/denoms/{denom}/trace", http.StatusBadRequest)
+		return
+	}
+
+	trace, err := s.denomTraces.Resolve(r.Context(), denom)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed: %v", err), status)
+		return
+	}
+
+	json.NewEncoder(w).Encode(trace)
+}
+
+// handleGetPortfolio - /api/portfolio/{address}: τα bank balances ενός Osmosis wallet,
+// αποτιμημένα με τις τελευταίες τιμές της cache
+func (s *HTTPServer) handleGetPortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	address := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/portfolio/"), "/")
+	if err := ValidateOsmosisAddress(address); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid address: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	s.lcdMu.RLock()
+	client := s.lcdClients[types.DefaultChain]
+	s.lcdMu.RUnlock()
+	if client == nil {
+		http.Error(w, fmt.Sprintf("LCD client not configured for chain %s", types.DefaultChain), http.StatusServiceUnavailable)
+		return
+	}
+
+	balances, err := (&OsmosisPoolClient{LCDClient: client}).GetBalances(r.Context(), address)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	prices, err := s.sqliteStorage.GetLatestTokenPrices()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	s.assetMu.RLock()
+	assetService := s.assets
+	s.assetMu.RUnlock()
+
+	json.NewEncoder(w).Encode(BuildPortfolio(address, balances, assetService, prices))
+}
+
+// handleGetArbitrage - Οι κύκλοι arbitrage της τελευταίας ενημέρωσης, το μεγαλύτερο κέρδος πρώτα
+func (s *HTTPServer) handleGetArbitrage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	detector := s.ArbitrageDetector()
+	if detector == nil {
+		http.Error(w, "Arbitrage detection disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	minProfitUSD, err := parseOptionalFloat(query.Get("min_profit_usd"))
+	if err != nil {
+		http.Error(w, "Invalid min_profit_usd", http.StatusBadRequest)
+		return
+	}
+	minProfitPct, err := parseOptionalFloat(query.Get("min_profit_pct"))
+	if err != nil {
+		http.Error(w, "Invalid min_profit_pct", http.StatusBadRequest)
+		return
+	}
+
+	chain := query.Get("chain")
+	opportunities := make([]types.ArbitrageOpportunity, 0)
+	for _, opportunity := range detector.Opportunities(chain) {
+		if minProfitUSD != nil && opportunity.ProfitUSD < *minProfitUSD {
+			continue
+		}
+		if minProfitPct != nil && opportunity.ProfitPct < *minProfitPct {
+			continue
+		}
+		opportunities = append(opportunities, opportunity)
+	}
+
+	updatedAt := detector.UpdatedAt()
+	if chain != "" {
+		updatedAt = map[string]time.Time{chain: updatedAt[chain]}
+	}
+
+	response := map[string]interface{}{
+		"opportunities": opportunities,
+		"count":         len(opportunities),
+		"updated_at":    updatedAt,
+		"config":        detector.Config(),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleAlerts - GET: όλοι οι κανόνες, POST: νέος κανόνας
+func (s *HTTPServer) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	engine := s.AlertEngine()
+	if engine == nil {
+		http.Error(w, "Alerts disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		rules := engine.Rules()
+		response := map[string]interface{}{
+			"rules": rules,
+			"count": len(rules),
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case http.MethodPost:
+		var rule types.AlertRule
+		if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		created, err := engine.CreateRule(rule)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed: %v", err), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAlert - /api/alerts/{id} (GET, PUT, DELETE) και /api/alerts/events
+func (s *HTTPServer) handleAlert(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	engine := s.AlertEngine()
+	if engine == nil {
+		http.Error(w, "Alerts disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/alerts/")
+	if id == "" || strings.Contains(id, "/") {
+		http.Error(w, "Use /api/alerts/{id} or /api/alerts/events", http.StatusBadRequest)
+		return
+	}
+
+	if id == "events" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		deliveries := engine.Deliveries()
+		response := map[string]interface{}{
+			"events": deliveries,
+			"count":  len(deliveries),
+		}
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var (
+		rule *types.AlertRule
+		err  error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		rule, err = engine.Rule(id)
+	case http.MethodPut:
+		var update types.AlertRule
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		rule, err = engine.UpdateRule(id, update)
+	case http.MethodDelete:
+		if err = engine.DeleteRule(id); err == nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{"deleted": id})
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed: %v", err), status)
+		return
+	}
+
+	json.NewEncoder(w).Encode(rule)
 }
 
 func (s *HTTPServer) handleForceUpdateChainRegistry(w http.ResponseWriter, r *http.Request) {
@@ -277,17 +1135,133 @@ func (s *HTTPServer) handleChainRegistryStatus(w http.ResponseWriter, r *http.Re
 	tokenCount := len(s.priceData.AllTokens)
 	s.priceData.mu.RUnlock()
 
+	s.assetMu.RLock()
+	assetEvents := make([]types.AssetChangeEvent, len(s.assetEvents))
+	copy(assetEvents, s.assetEvents)
+	s.assetMu.RUnlock()
+
 	response := map[string]interface{}{
-		"last_update": lastUpdate,
-		"token_count": tokenCount,
+		"last_update":   lastUpdate,
+		"token_count":   tokenCount,
+		"asset_changes": assetEvents,
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
+// SetAssetService - Το AssetService που μοιράζεται με τον collector: κάθε reload του
+// ανανεώνει τα tokens του chain registry χωρίς restart
+func (s *HTTPServer) SetAssetService(assetService *types.AssetService) {
+	s.assetMu.Lock()
+	s.assets = assetService
+	s.assetMu.Unlock()
+	s.denomTraces.SetAssetService(assetService)
+
+	assetService.OnChange(func(event types.AssetChangeEvent) {
+		s.RecordAssetChange(event)
+		if err := s.loadChainRegistryTokens(); err != nil {
+			log.Printf("⚠️  Warning: %v", err)
+		}
+	})
+}
+
+// RecordAssetChange - Καταγραφή ενός reload assetlist (οποιασδήποτε αλυσίδας) για το status
+func (s *HTTPServer) RecordAssetChange(event types.AssetChangeEvent) {
+	s.assetMu.Lock()
+	defer s.assetMu.Unlock()
+
+	s.assetEvents = append(s.assetEvents, event)
+	if len(s.assetEvents) > maxAssetEvents {
+		s.assetEvents = s.assetEvents[len(s.assetEvents)-maxAssetEvents:]
+	}
+}
+
+// SetLCDClient - Ο LCD client μιας αλυσίδας, για την κατάσταση των endpoints στο /api/endpoints
+func (s *HTTPServer) SetLCDClient(client *LCDClient) {
+	s.lcdMu.Lock()
+	defer s.lcdMu.Unlock()
+
+	s.lcdClients[client.Chain()] = client
+	if client.Chain() == types.DefaultChain {
+		s.denomTraces.SetLCDClient(client)
+	}
+}
+
+// SetChainTokens - Τα tokens που τιμολογήθηκαν σε μια αλυσίδα στον τελευταίο κύκλο (/api/chains)
+func (s *HTTPServer) SetChainTokens(chain string, tokens []types.TokenInfo) {
+	s.chainMu.Lock()
+	defer s.chainMu.Unlock()
+
+	s.chainTokens[chain] = tokens
+}
+
+// SetPoolFetchReport - Καταγραφή της πληρότητας της τελευταίας λήψης pools για το /api/health
+func (s *HTTPServer) SetPoolFetchReport(report types.PoolFetchReport) {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	s.fetchReports[report.Source] = report
+}
+
+// SetArbitrageDetector - Ενεργοποιεί το /api/arbitrage και το event "arbitrage" του /api/stream
+func (s *HTTPServer) SetArbitrageDetector(detector *ArbitrageDetector) {
+	s.arbitrageMu.Lock()
+	s.arbitrage = detector
+	s.arbitrageMu.Unlock()
+
+	detector.OnNewOpportunities(s.streamHub.PublishArbitrage)
+}
+
+// ArbitrageDetector - nil αν δεν έχει οριστεί detector
+func (s *HTTPServer) ArbitrageDetector() *ArbitrageDetector {
+	s.arbitrageMu.RLock()
+	defer s.arbitrageMu.RUnlock()
+	return s.arbitrage
+}
+
+// SetAlertEngine - Ενεργοποιεί τα /api/alerts
+func (s *HTTPServer) SetAlertEngine(engine *AlertEngine) {
+	s.alertMu.Lock()
+	defer s.alertMu.Unlock()
+	s.alerts = engine
+}
+
+// AlertEngine - nil αν δεν έχει οριστεί engine
+func (s *HTTPServer) AlertEngine() *AlertEngine {
+	s.alertMu.RLock()
+	defer s.alertMu.RUnlock()
+	return s.alerts
+}
+
+// PublishPoolPrices - Προώθηση των αλλαγών τιμών στους clients του /api/stream
+func (s *HTTPServer) PublishPoolPrices(changed []types.PoolPrice) {
+	s.streamHub.Publish(changed)
+}
+
+// Shutdown - Graceful τερματισμός: σταματά να δέχεται συνδέσεις, κλείνει τα streams και
+// περιμένει τα ενεργά requests μέχρι τη λήξη του ctx
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	s.serverMu.Lock()
+	s.shuttingDown = true
+	server := s.server
+	s.serverMu.Unlock()
+
+	if server == nil {
+		s.streamHub.Close()
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
 func (s *HTTPServer) Stop() error {
-	if s.server != nil {
-		return s.server.Close()
+	s.serverMu.Lock()
+	s.shuttingDown = true
+	server := s.server
+	s.serverMu.Unlock()
+
+	s.streamHub.Close()
+	if server != nil {
+		return server.Close()
 	}
 	return nil
 }
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+func TestFilterAndSortPoolsUnknownTVL(t *testing.T) {
+	pools := []types.PoolPrice{
+		{PoolID: "1", LiquidityUSD: 500},
+		{PoolID: "2", LiquidityUSD: 0, LiquidityUnknown: true, PoolType: types.PoolTypeConcentrated},
+		{PoolID: "3", LiquidityUSD: 2000},
+		{PoolID: "4", LiquidityUSD: 50},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"1", "2", "3", "4"}},
+		{"sort=tvl", []string{"3", "1", "4"}},
+		{"sort=tvl&order=asc", []string{"4", "1", "3"}},
+		{"max_tvl=600", []string{"1", "4"}},
+		{"min_tvl=100&sort=tvl", []string{"3", "1"}},
+	}
+
+	for _, tt := range tests {
+		request := httptest.NewRequest("GET", "/api/pools?"+tt.query, nil)
+		filtered, err := filterAndSortPools(pools, request)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		got := make([]string, len(filtered))
+		for i, pool := range filtered {
+			got[i] = pool.PoolID
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: pools %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: pools %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPoolPricesForChain(t *testing.T) {
+	pools := []types.PoolPrice{
+		{PoolID: "1", Chain: "osmosis"},
+		{PoolID: "2"}, // Εγγραφές χωρίς chain είναι του Osmosis
+		{PoolID: "crescent:3", Chain: "crescent"},
+	}
+
+	tests := []struct {
+		chain string
+		want  []string
+	}{
+		{"", []string{"1", "2"}},
+		{"osmosis", []string{"1", "2"}},
+		{"crescent", []string{"crescent:3"}},
+		{"juno", nil},
+	}
+	for _, tt := range tests {
+		got := poolPricesForChain(pools, tt.chain)
+		if len(got) != len(tt.want) {
+			t.Errorf("chain %q: %d pools, want %v", tt.chain, len(got), tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].PoolID != tt.want[i] {
+				t.Errorf("chain %q: pool %s, want %s", tt.chain, got[i].PoolID, tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// LCDClient - Ο κοινός REST client μιας Cosmos αλυσίδας: endpoint pool με failover,
+// retries και circuit breaker. Οι DEX-specific clients (Osmosis, Crescent, CosmWasm)
+// χτίζονται πάνω του.
+type LCDClient struct {
+	chain      string
+	httpClient *http.Client
+	endpoints  *EndpointPool
+	retry      RetryPolicy
+	breaker    *CircuitBreaker
+}
+
+// NewLCDClient - Client για την αλυσίδα chain πάνω σε ένα endpoint pool
+func NewLCDClient(chain string, endpoints *EndpointPool) *LCDClient {
+	return &LCDClient{
+		chain: chain,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second, // Ανά endpoint: ένας αργός node δεν μπλοκάρει το refresh
+		},
+		endpoints: endpoints,
+		retry:     DefaultRetryPolicy(),
+		breaker:   NewCircuitBreaker(chain+"-lcd", 5, 30*time.Second),
+	}
+}
+
+// Chain - Η αλυσίδα του client
+func (c *LCDClient) Chain() string {
+	return c.chain
+}
+
+// Endpoints - Το pool των LCD nodes του client
+func (c *LCDClient) Endpoints() *EndpointPool {
+	return c.endpoints
+}
+
+// CircuitBreaker - Ο breaker που προστατεύει τις κλήσεις του client
+func (c *LCDClient) CircuitBreaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// SmartQuery εκτελεί ένα CosmWasm smart query και αποκωδικοποιεί το "data" στο result
+func (c *LCDClient) SmartQuery(ctx context.Context, contract string, query interface{}, result interface{}) error {
+	payload, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("σφάλμα κατά τη σειριοποίηση του query: %w", err)
+	}
+
+	path := fmt.Sprintf("/cosmwasm/wasm/v1/contract/%s/smart/%s", contract, url.PathEscape(base64.StdEncoding.EncodeToString(payload)))
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("σφάλμα κατά το parsing του smart query: %w", err)
+	}
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return fmt.Errorf("σφάλμα κατά το parsing του smart query: %w", err)
+	}
+	return nil
+}
@@ -1,91 +1,136 @@
 package api
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"math"
-	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
+	"portofoliov1/decimal"
 	"portofoliov1/types"
 )
 
+// OsmosisPoolClient - Τα gamm/CL endpoints του Osmosis πάνω στον κοινό LCD client
 type OsmosisPoolClient struct {
-	httpClient *http.Client
-	baseURL    string
+	*LCDClient
 }
 
-func NewOsmosisPoolClient() *OsmosisPoolClient {
-	return &OsmosisPoolClient{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: "https://lcd.osmosis.zone", // Χρησιμοποιούμε το επίσημο LCD API
+// NewOsmosisPoolClient - Client πάνω σε ένα endpoint pool (nil = μόνο το επίσημο LCD API)
+func NewOsmosisPoolClient(endpoints *EndpointPool) *OsmosisPoolClient {
+	if endpoints == nil {
+		endpoints = NewEndpointPool(nil)
 	}
+	return &OsmosisPoolClient{LCDClient: NewLCDClient(types.DefaultChain, endpoints)}
 }
 
 // GetPoolById επιστρέφει λεπτομέρειες για ένα συγκεκριμένο pool
-func (c *OsmosisPoolClient) GetPoolById(poolId string) (*types.OsmosisPool, error) {
-	url := fmt.Sprintf("%s/pools/%s", c.baseURL, poolId)
+func (c *OsmosisPoolClient) GetPoolById(ctx context.Context, poolId string) (*types.OsmosisPool, error) {
+	path := fmt.Sprintf("/pools/%s", poolId)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση pool: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
-	}
-
 	var pool types.OsmosisPool
 	if err := json.NewDecoder(resp.Body).Decode(&pool); err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά το parsing του pool: %w", err)
 	}
+	pool.NormalizeAssets()
 
 	return &pool, nil
 }
 
-// GetAllPools επιστρέφει όλα τα διαθέσιμα pools
-func (c *OsmosisPoolClient) GetAllPools(limit int, offset int) ([]types.OsmosisPool, error) {
-	url := fmt.Sprintf("%s/osmosis/gamm/v1beta1/pools?pagination.limit=%d&pagination.offset=%d", c.baseURL, limit, offset)
+// GetAllPools επιστρέφει όλα τα gamm pools, ακολουθώντας το pagination μέχρι το τέλος
+func (c *OsmosisPoolClient) GetAllPools(ctx context.Context, pageSize int) ([]types.OsmosisPool, types.PoolFetchReport, error) {
+	pools, report, err := fetchAllPoolPages[types.OsmosisPool](ctx, c.LCDClient, "/osmosis/gamm/v1beta1/pools", "gamm", pageSize)
+	if err != nil {
+		return nil, report, fmt.Errorf("σφάλμα κατά την ανάκτηση pools: %w", err)
+	}
 
-	resp, err := c.httpClient.Get(url)
+	for i := range pools {
+		pools[i].NormalizeAssets()
+		pools[i].Chain = c.chain
+	}
+
+	return pools, report, nil
+}
+
+// GetConcentratedPools επιστρέφει όλα τα concentrated liquidity pools
+func (c *OsmosisPoolClient) GetConcentratedPools(ctx context.Context, pageSize int) ([]types.ConcentratedPool, types.PoolFetchReport, error) {
+	pools, report, err := fetchAllPoolPages[types.ConcentratedPool](ctx, c.LCDClient, "/osmosis/concentratedliquidity/v1beta1/pools", "concentrated", pageSize)
 	if err != nil {
-		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση pools: %w", err)
+		return nil, report, fmt.Errorf("σφάλμα κατά την ανάκτηση CL pools: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
+	return pools, report, nil
+}
+
+// GetConcentratedPoolsAsOsmosisPools επιστρέφει τα CL pools μετατρεμμένα σε OsmosisPool,
+// έτοιμα για αποθήκευση μαζί με τα gamm pools. Τα balances έρχονται από το cache (nil =
+// άγνωστα), που ανανεώνει πρώτα όσα pools δεν έχουν ληφθεί ή έχουν λήξει.
+func (c *OsmosisPoolClient) GetConcentratedPoolsAsOsmosisPools(ctx context.Context, pageSize int, balances *ConcentratedBalanceCache) ([]types.OsmosisPool, types.PoolFetchReport, error) {
+	clPools, report, err := c.GetConcentratedPools(ctx, pageSize)
+	if err != nil {
+		return nil, report, err
+	}
+	if balances != nil {
+		balances.Refresh(ctx, c, clPools)
 	}
 
-	var poolsResponse struct {
-		Pools []types.OsmosisPool `json:"pools"`
+	pools := make([]types.OsmosisPool, 0, len(clPools))
+	for _, clPool := range clPools {
+		var coins []types.BasicCoin
+		if balances != nil {
+			coins = balances.Get(clPool.Id)
+		}
+		pool, err := clPool.ToOsmosisPool(coins)
+		if err != nil {
+			continue
+		}
+		pool.Chain = c.chain
+		pools = append(pools, pool)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&poolsResponse); err != nil {
-		return nil, fmt.Errorf("σφάλμα κατά το parsing των pools: %w", err)
+
+	return pools, report, nil
+}
+
+// GetPoolLiquidity επιστρέφει τα πραγματικά balances ενός pool (poolmanager total_pool_liquidity)
+func (c *OsmosisPoolClient) GetPoolLiquidity(ctx context.Context, poolId string) ([]types.BasicCoin, error) {
+	path := fmt.Sprintf("/osmosis/poolmanager/v1beta1/pools/%s/total_pool_liquidity", url.PathEscape(poolId))
+
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση liquidity του pool %s: %w", poolId, err)
 	}
+	defer resp.Body.Close()
 
-	return poolsResponse.Pools, nil
+	var response struct {
+		Liquidity []types.BasicCoin `json:"liquidity"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("σφάλμα κατά το parsing της liquidity: %w", err)
+	}
+	if response.Liquidity == nil {
+		response.Liquidity = []types.BasicCoin{}
+	}
+
+	return response.Liquidity, nil
 }
 
 // GetSpotPrice επιστρέφει την τρέχουσα τιμή μεταξύ δύο tokens σε ένα pool
-func (c *OsmosisPoolClient) GetSpotPrice(poolId string, tokenIn string, tokenOut string) (float64, error) {
-	url := fmt.Sprintf("%s/osmosis/gamm/v1beta1/pools/%s/prices?base_asset_denom=%s&quote_asset_denom=%s", c.baseURL, poolId, tokenIn, tokenOut)
+func (c *OsmosisPoolClient) GetSpotPrice(ctx context.Context, poolId string, tokenIn string, tokenOut string) (float64, error) {
+	path := fmt.Sprintf("/osmosis/gamm/v1beta1/pools/%s/prices?base_asset_denom=%s&quote_asset_denom=%s", poolId, tokenIn, tokenOut)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		return 0, fmt.Errorf("σφάλμα κατά την ανάκτηση spot price: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
-	}
-
 	var response struct {
 		SpotPrice string `json:"spot_price"`
 	}
@@ -102,8 +147,8 @@ func (c *OsmosisPoolClient) GetSpotPrice(poolId string, tokenIn string, tokenOut
 }
 
 // SimulateSwap προσομοιώνει ένα swap και επιστρέφει το αναμενόμενο αποτέλεσμα
-func (c *OsmosisPoolClient) SimulateSwap(poolId string, tokenIn types.BasicCoin) (*types.SimulateSwapResponse, error) {
-	url := fmt.Sprintf("%s/osmosis/gamm/v1beta1/pools/%s/estimate/swap", c.baseURL, poolId)
+func (c *OsmosisPoolClient) SimulateSwap(ctx context.Context, poolId string, tokenIn types.BasicCoin) (*types.SimulateSwapResponse, error) {
+	path := fmt.Sprintf("/osmosis/gamm/v1beta1/pools/%s/estimate/swap", poolId)
 
 	body, err := json.Marshal(map[string]interface{}{
 		"token_in": tokenIn,
@@ -112,16 +157,12 @@ func (c *OsmosisPoolClient) SimulateSwap(poolId string, tokenIn types.BasicCoin)
 		return nil, fmt.Errorf("σφάλμα κατά τη σειριοποίηση του request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := c.post(ctx, path, body)
 	if err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά την προσομοίωση του swap: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
-	}
-
 	var result types.SimulateSwapResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά το parsing του simulation result: %w", err)
@@ -131,19 +172,15 @@ func (c *OsmosisPoolClient) SimulateSwap(poolId string, tokenIn types.BasicCoin)
 }
 
 // GetPoolStats επιστρέφει στατιστικά για ένα pool (volume, TVL, APR κλπ)
-func (c *OsmosisPoolClient) GetPoolStats(poolId string) (*types.PoolStats, error) {
-	url := fmt.Sprintf("%s/osmosis/gamm/v1beta1/pools/%s/stats", c.baseURL, poolId)
+func (c *OsmosisPoolClient) GetPoolStats(ctx context.Context, poolId string) (*types.PoolStats, error) {
+	path := fmt.Sprintf("/osmosis/gamm/v1beta1/pools/%s/stats", poolId)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση pool stats: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
-	}
-
 	var stats types.PoolStats
 	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά το parsing των stats: %w", err)
@@ -153,19 +190,15 @@ func (c *OsmosisPoolClient) GetPoolStats(poolId string) (*types.PoolStats, error
 }
 
 // GetBlockHeight επιστρέφει το τρέχον block height του Osmosis chain
-func (c *OsmosisPoolClient) GetBlockHeight() (*types.BlockHeightResponse, error) {
-	url := fmt.Sprintf("%s/cosmos/base/tendermint/v1beta1/blocks/latest", c.baseURL)
+func (c *OsmosisPoolClient) GetBlockHeight(ctx context.Context) (*types.BlockHeightResponse, error) {
+	path := "/cosmos/base/tendermint/v1beta1/blocks/latest"
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση block height: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("μη αναμενόμενο status code: %d", resp.StatusCode)
-	}
-
 	var response struct {
 		Block struct {
 			Header struct {
@@ -185,90 +218,62 @@ func (c *OsmosisPoolClient) GetBlockHeight() (*types.BlockHeightResponse, error)
 	return &types.BlockHeightResponse{Height: height}, nil
 }
 
-// CalculateSpotPrices υπολογίζει τις τιμές όλων των tokens σε USD
-func (c *OsmosisPoolClient) CalculateSpotPrices(pools []types.OsmosisPool, assetService *types.AssetService) (map[string]float64, error) {
-	// Βοηθητικοί χάρτες
-	prices := make(map[string]float64)       // Τελικές τιμές σε USD
-	poolPrices := make(map[string][]float64) // Τιμές από διάφορα pools
-
-	// Γνωστά stablecoins και η τιμή τους σε USD
-	stableCoins := map[string]float64{
-		"ibc/D189335C6E4A68B513C10AB227BF1C1D38C746766278BA3EEB4FB14124F1D858": 1.0, // USDC
-		"ibc/8242AD24008032E457D2E12D46588FD39FB54FB29680C6C7663D296B383C37C4": 1.0, // USDT
-		"ibc/6329DD8CF31A334DD5BE3F68C846C9FE313281362B37686A62343BAC1EB1546D": 1.0, // BUSD
-		"ibc/0CD3A0285E1341859B5E86B6AB7682F023D03E97607CCC1DC95706411D866DF7": 1.0, // DAI
-	}
-
-	// Σιωπηλός υπολογισμός - no logs
-	var stablePools int
-	for _, pool := range pools {
+// GetBalances επιστρέφει όλα τα bank balances μιας διεύθυνσης (base units), ακολουθώντας
+// το next_key μέχρι το τέλος. Αν οι σελίδες ξεπεράσουν το maxSequentialPages επιστρέφει σφάλμα.
+func (c *OsmosisPoolClient) GetBalances(ctx context.Context, address string) ([]types.BasicCoin, error) {
+	path := "/cosmos/bank/v1beta1/balances/" + url.PathEscape(address)
+
+	balances := []types.BasicCoin{}
+	key := ""
+	for page := 0; page < maxSequentialPages; page++ {
+		query := url.Values{}
+		query.Set("pagination.limit", "1000")
+		if key != "" {
+			query.Set("pagination.key", key)
+		}
 
-		if len(pool.PoolAssets) != 2 {
-			continue
+		resp, err := c.get(ctx, path+"?"+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("σφάλμα κατά την ανάκτηση balances: %w", err)
 		}
 
-		asset0 := pool.PoolAssets[0]
-		asset1 := pool.PoolAssets[1]
-
-		// Έλεγξε αν το pool περιέχει stablecoin
-		hasStable := false
-		var stableDenom, otherDenom string
-		var stableAmount, otherAmount float64
-
-		for denom := range stableCoins {
-			if asset0.Token.Denom == denom {
-				hasStable = true
-				stableDenom = asset0.Token.Denom
-				otherDenom = asset1.Token.Denom
-				stableAmount, _ = strconv.ParseFloat(asset0.Token.Amount, 64)
-				otherAmount, _ = strconv.ParseFloat(asset1.Token.Amount, 64)
-				break
-			} else if asset1.Token.Denom == denom {
-				hasStable = true
-				stableDenom = asset1.Token.Denom
-				otherDenom = asset0.Token.Denom
-				stableAmount, _ = strconv.ParseFloat(asset1.Token.Amount, 64)
-				otherAmount, _ = strconv.ParseFloat(asset0.Token.Amount, 64)
-				break
-			}
+		var response struct {
+			Balances   []types.BasicCoin  `json:"balances"`
+			Pagination types.PageResponse `json:"pagination"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("σφάλμα κατά το parsing των balances: %w", err)
 		}
 
-		if hasStable && stableAmount > 0 && otherAmount > 0 {
-			stablePools++
-			// Υπολόγισε τιμή σε USD
-			stableExp := assetService.GetExponent(stableDenom)
-			otherExp := assetService.GetExponent(otherDenom)
-
-			stableValue := stableAmount / math.Pow10(stableExp) * stableCoins[stableDenom]
-			otherValue := otherAmount / math.Pow10(otherExp)
-
-			price := stableValue / otherValue
-			if price > 0 && price < 1e12 { // Φιλτράρισμα εξωφρενικών τιμών
-				symbol := assetService.GetSymbol(otherDenom)
-				if symbol != "" {
-					poolPrices[symbol] = append(poolPrices[symbol], price)
-				}
-			}
+		balances = append(balances, response.Balances...)
+		if response.Pagination.NextKey == "" {
+			return balances, nil
 		}
+		key = response.Pagination.NextKey
 	}
 
-	// Σιωπηλή ολοκλήρωση - no logs
+	// Δεν επιστρέφουμε μέρος των balances: ένα κομμένο portfolio θα έδειχνε λάθος σύνολα
+	return nil, fmt.Errorf("τα balances της %s ξεπερνούν τις %d σελίδες", address, maxSequentialPages)
+}
 
-	// Υπολόγισε μέσες τιμές
-	for symbol, priceList := range poolPrices {
-		if len(priceList) > 0 {
-			var sum float64
-			for _, p := range priceList {
-				sum += p
-			}
-			avgPrice := sum / float64(len(priceList))
-			prices[symbol] = avgPrice
+// CalculateSpotPrices υπολογίζει τις τιμές όλων των tokens σε USD μέσω του PriceOracle.
+// Για symbols που αντιστοιχούν σε πολλά denoms κρατάμε το πιο liquid.
+func (c *OsmosisPoolClient) CalculateSpotPrices(pools []types.OsmosisPool, assetService *types.AssetService) (map[string]float64, error) {
+	poolPrices, err := c.GetAllPoolPrices(pools, assetService)
+	if err != nil {
+		return nil, err
+	}
 
-			// Αποθήκευση OSMO price χωρίς log
-			if symbol == "OSMO" {
-				assetService.SetOsmoUsdPrice(avgPrice)
-			}
+	prices := make(map[string]float64)
+	liquidity := make(map[string]float64)
+	for _, tokenPrice := range NewPriceOracle(assetService).ComputeTokenPrices(poolPrices) {
+		if existing, ok := liquidity[tokenPrice.Symbol]; ok && existing >= tokenPrice.LiquidityUSD {
+			continue
 		}
+		prices[tokenPrice.Symbol] = tokenPrice.PriceUSD
+		liquidity[tokenPrice.Symbol] = tokenPrice.LiquidityUSD
 	}
 
 	if len(prices) == 0 {
@@ -280,86 +285,174 @@ func (c *OsmosisPoolClient) CalculateSpotPrices(pools []types.OsmosisPool, asset
 
 // GetAllPoolPrices επιστρέφει τις τιμές για όλα τα pools
 func (c *OsmosisPoolClient) GetAllPoolPrices(pools []types.OsmosisPool, assetService *types.AssetService) ([]types.PoolPrice, error) {
+	return ComputePoolPrices(pools, assetService)
+}
+
+// ComputePoolPrices υπολογίζει τις τιμές για όλα τα pools, ανεξαρτήτως αλυσίδας·
+// τα symbols και οι exponents έρχονται από το AssetService της αλυσίδας των pools
+func ComputePoolPrices(pools []types.OsmosisPool, assetService *types.AssetService) ([]types.PoolPrice, error) {
+	return ComputePoolPricesWithModel(pools, assetService, PriceModelForPool)
+}
+
+// ComputePoolPricesWithModel - Όπως το ComputePoolPrices, με το μοντέλο τιμολόγησης
+// κάθε pool από τον caller (π.χ. DexSource.PriceModel)
+func ComputePoolPricesWithModel(pools []types.OsmosisPool, assetService *types.AssetService, priceModel func(types.OsmosisPool) PriceModel) ([]types.PoolPrice, error) {
 	poolPrices := make([]types.PoolPrice, 0, len(pools))
 	timestamp := time.Now()
 
 	var skippedPools, processedPools int
 
 	for _, pool := range pools {
-		// Δουλεύουμε μόνο με pools 2 assets
-		if len(pool.PoolAssets) != 2 {
+		if len(pool.PoolAssets) < 2 {
 			skippedPools++
 			continue
 		}
 
-		asset0 := pool.PoolAssets[0]
-		asset1 := pool.PoolAssets[1]
+		// Για pools με N assets δημιουργούμε μία εγγραφή για κάθε ζεύγος
+		for _, pair := range poolAssetPairs(pool) {
+			poolPrice, ok := buildPoolPrice(pool, pair[0], pair[1], priceModel(pool), assetService, timestamp)
+			if !ok {
+				skippedPools++
+				continue
+			}
 
-		// Parse amounts με error handling
-		amount0, err := strconv.ParseFloat(asset0.Token.Amount, 64)
-		if err != nil {
-			skippedPools++
-			continue
-		}
-		amount1, err := strconv.ParseFloat(asset1.Token.Amount, 64)
-		if err != nil {
-			skippedPools++
-			continue
+			poolPrices = append(poolPrices, poolPrice)
+			processedPools++
 		}
+	}
 
-		// Υπολόγισε την τιμή: πόσο token1 χρειάζεσαι για 1 token0
-		var price float64
-		if amount0 > 0 && amount1 > 0 {
-			// Προσαρμογή με exponents
-			exp0 := assetService.GetExponent(asset0.Token.Denom)
-			exp1 := assetService.GetExponent(asset1.Token.Denom)
+	return poolPrices, nil
+}
 
-			adjustedAmount0 := amount0 / math.Pow10(exp0)
-			adjustedAmount1 := amount1 / math.Pow10(exp1)
+// normalizedWeight επιστρέφει το weight ενός denom ως κλάσμα του TotalWeight του pool.
+// Pools χωρίς weights (π.χ. stableswap) θεωρούνται ισοβαρή.
+func normalizedWeight(pool types.OsmosisPool, denom string) float64 {
+	equalWeight := 1.0 / float64(len(pool.PoolAssets))
 
-			if adjustedAmount0 > 0 {
-				price = adjustedAmount1 / adjustedAmount0
-			}
+	weight, sum := decimal.Zero(), decimal.Zero()
+	for _, asset := range pool.PoolAssets {
+		w, err := decimal.Parse(asset.Weight)
+		if err != nil || !w.IsPositive() {
+			return equalWeight
+		}
+		if asset.Token.Denom == denom {
+			weight = w
 		}
+		sum = sum.Add(w)
+	}
 
-		// Λήψη symbols
-		symbol0 := assetService.GetSymbol(asset0.Token.Denom)
-		symbol1 := assetService.GetSymbol(asset1.Token.Denom)
+	totalWeight, err := decimal.Parse(pool.TotalWeight)
+	if err != nil || !totalWeight.IsPositive() {
+		totalWeight = sum
+	}
 
-		// Αν δεν βρέθηκαν symbols, χρησιμοποίησε truncated denoms
-		if symbol0 == "" {
-			if len(asset0.Token.Denom) > 12 {
-				symbol0 = asset0.Token.Denom[:12] + "..."
-			} else {
-				symbol0 = asset0.Token.Denom
-			}
-		}
-		if symbol1 == "" {
-			if len(asset1.Token.Denom) > 12 {
-				symbol1 = asset1.Token.Denom[:12] + "..."
-			} else {
-				symbol1 = asset1.Token.Denom
-			}
-		}
+	if !weight.IsPositive() || !totalWeight.IsPositive() {
+		return equalWeight
+	}
+	return weight.Quo(totalWeight).Float64()
+}
 
-		poolPrice := types.PoolPrice{
-			PoolID:              pool.Id,
-			Token0Symbol:        symbol0,
-			Token0Denom:         asset0.Token.Denom,
-			Token0Amount:        asset0.Token.Amount,
-			Token1Symbol:        symbol1,
-			Token1Denom:         asset1.Token.Denom,
-			Token1Amount:        asset1.Token.Amount,
-			PriceOSMO:           price,
-			PriceToken0ToToken1: price,       // Ίδιο με PriceOSMO για συμβατότητα
-			PriceToken1ToToken0: 1.0 / price, // Αντίστροφη τιμή
-			LiquidityUSD:        0.0,         // Θα προστεθεί αργότερα
-			Timestamp:           timestamp,
+// poolAssetPairs επιστρέφει όλα τα ζεύγη δεικτών (i < j) των assets ενός pool
+func poolAssetPairs(pool types.OsmosisPool) [][2]int {
+	n := len(pool.PoolAssets)
+	pairs := make([][2]int, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			pairs = append(pairs, [2]int{i, j})
 		}
+	}
+	return pairs
+}
 
-		poolPrices = append(poolPrices, poolPrice)
-		processedPools++
+// buildPoolPrice υπολογίζει την εγγραφή τιμής για το ζεύγος assets (i, j) ενός pool.
+// Για pools με περισσότερα από 2 assets το PoolID γίνεται "<pool_id>:<i>-<j>"
+// και το ParentPoolID δείχνει στο πραγματικό pool.
+func buildPoolPrice(pool types.OsmosisPool, i, j int, model PriceModel, assetService *types.AssetService, timestamp time.Time) (types.PoolPrice, bool) {
+	asset0 := pool.PoolAssets[i]
+	asset1 := pool.PoolAssets[j]
+
+	// Parse amounts με error handling (ακέραια strings, συχνά πέρα από την ακρίβεια του float64)
+	if _, err := decimal.Parse(asset0.Token.Amount); err != nil {
+		return types.PoolPrice{}, false
+	}
+	if _, err := decimal.Parse(asset1.Token.Amount); err != nil {
+		return types.PoolPrice{}, false
 	}
 
-	return poolPrices, nil
+	weight0 := normalizedWeight(pool, asset0.Token.Denom)
+	weight1 := normalizedWeight(pool, asset1.Token.Denom)
+
+	// Υπολόγισε την τιμή: πόσο token1 χρειάζεσαι για 1 token0
+	// Το μοντέλο έρχεται από την πηγή του pool: weighted (B1 / W1) / (B0 / W0) ή stableswap curve
+	// Τα μοντέλα απορρίπτουν μηδενικά reserves· το CL τιμολογείται από το √P ακόμα κι αν
+	// τα balances του δεν έχουν ληφθεί
+	price := decimal.Zero()
+	if rawPrice, err := model.SpotPrice(pool, asset0.Token.Denom, asset1.Token.Denom); err == nil {
+		// Προσαρμογή με exponents
+		exp0 := assetService.GetExponent(asset0.Token.Denom)
+		exp1 := assetService.GetExponent(asset1.Token.Denom)
+
+		price = rawPrice.MulPow10(exp0 - exp1)
+	}
+
+	// Η αντίστροφη τιμή: άπειρη (float) και κενή (string) όταν η τιμή είναι 0
+	priceFloat := price.Float64()
+	inverseFloat := 1.0 / priceFloat
+	var priceExact, inverseExact string
+	if price.IsPositive() {
+		priceExact = price.String()
+		inverseExact = decimal.One().Quo(price).String()
+	}
+
+	// Λήψη symbols
+	symbol0 := displaySymbol(asset0.Token.Denom, assetService)
+	symbol1 := displaySymbol(asset1.Token.Denom, assetService)
+
+	chain := pool.Chain
+	if chain == "" {
+		chain = assetService.Chain
+	}
+
+	poolID := pool.Id
+	var parentPoolID string
+	if len(pool.PoolAssets) > 2 {
+		poolID = fmt.Sprintf("%s:%d-%d", pool.Id, i, j)
+		parentPoolID = pool.Id
+	}
+
+	return types.PoolPrice{
+		PoolID:              poolID,
+		ParentPoolID:        parentPoolID,
+		PoolAssetCount:      len(pool.PoolAssets),
+		PoolType:            pool.PoolType(),
+		Token0Symbol:        symbol0,
+		Token0Denom:         asset0.Token.Denom,
+		Token0Amount:        asset0.Token.Amount,
+		Token1Symbol:        symbol1,
+		Token1Denom:         asset1.Token.Denom,
+		Token1Amount:        asset1.Token.Amount,
+		PriceOSMO:           priceFloat,
+		PriceToken0ToToken1: priceFloat,   // Ίδιο με PriceOSMO για συμβατότητα
+		PriceToken1ToToken0: inverseFloat, // Αντίστροφη τιμή
+		Price0To1Exact:      priceExact,
+		Price1To0Exact:      inverseExact,
+		Token0Weight:        weight0,
+		Token1Weight:        weight1,
+		LiquidityUSD:        0.0, // Συμπληρώνεται από το PriceOracle.ApplyLiquidityUSD
+		LiquidityUnknown:    pool.Concentrated != nil && !pool.Concentrated.BalancesKnown,
+		Chain:               chain,
+		Timestamp:           timestamp,
+	}, true
+}
+
+// displaySymbol επιστρέφει το symbol ενός denom ή, αν δεν βρεθεί, truncated denom
+func displaySymbol(denom string, assetService *types.AssetService) string {
+	symbol := assetService.GetSymbol(denom)
+	if symbol == "" {
+		if len(denom) > 12 {
+			return denom[:12] + "..."
+		}
+		return denom
+	}
+	return symbol
 }
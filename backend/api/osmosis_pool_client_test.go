@@ -0,0 +1,183 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+const (
+	testAtomDenom  = AtomDenom
+	testEvmosDenom = "ibc/6AE98883D4D5D5FF9E50D7130F1305DA2FFA0C652D1DD9C123657C6B4EB2DF8A" // 18 decimals
+)
+
+func testAssetService(t *testing.T) *types.AssetService {
+	t.Helper()
+	assets, err := types.NewAssetService()
+	if err != nil {
+		t.Fatalf("NewAssetService: %v", err)
+	}
+	return assets
+}
+
+// testLCDClient - LCDClient πάνω σε httptest servers, με σύντομο backoff για τα tests
+func testLCDClient(urls ...string) *LCDClient {
+	endpoints := make([]types.ChainEndpoint, 0, len(urls))
+	for _, address := range urls {
+		endpoints = append(endpoints, types.ChainEndpoint{Address: address})
+	}
+	client := NewLCDClient(types.DefaultChain, NewEndpointPool(endpoints))
+	client.retry = RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxRetryAfter: 2 * time.Second}
+	return client
+}
+
+func TestWeightedSpotPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		pool              types.OsmosisPool
+		denomIn, denomOut string
+		want              string
+	}{
+		{
+			name: "equal weights",
+			pool: weightedTestPool("1", "0.002",
+				[3]string{"uatom", "1000000", "536870912000000"},
+				[3]string{"uosmo", "2000000", "536870912000000"}),
+			denomIn: "uatom", denomOut: "uosmo",
+			want: "2",
+		},
+		{
+			// (Bo/Wo)/(Bi/Wi) = (2e6/20)/(1e6/80) = 8
+			name: "80/20",
+			pool: weightedTestPool("2", "0.002",
+				[3]string{"uatom", "1000000", "80"},
+				[3]string{"uosmo", "2000000", "20"}),
+			denomIn: "uatom", denomOut: "uosmo",
+			want: "8",
+		},
+		{
+			name: "80/20 inverse",
+			pool: weightedTestPool("2", "0.002",
+				[3]string{"uatom", "1000000", "80"},
+				[3]string{"uosmo", "2000000", "20"}),
+			denomIn: "uosmo", denomOut: "uatom",
+			want: "0.125",
+		},
+		{
+			// Το fee δεν επηρεάζει τη spot τιμή
+			name: "fee ignored",
+			pool: weightedTestPool("3", "0.3",
+				[3]string{"uatom", "3", "1"},
+				[3]string{"uosmo", "1", "1"}),
+			denomIn: "uatom", denomOut: "uosmo",
+			want: "0.333333333333333333333333333333333333",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := WeightedModel{}.SpotPrice(tt.pool, tt.denomIn, tt.denomOut)
+			if err != nil {
+				t.Fatalf("SpotPrice: %v", err)
+			}
+			if price.String() != tt.want {
+				t.Errorf("spot price = %s, want %s", price, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputePoolPricesWeighted(t *testing.T) {
+	assets := testAssetService(t)
+
+	// 1000 ATOM (80%) και 2000 OSMO (20%): 1 ATOM = (2000/0.2)/(1000/0.8) = 8 OSMO
+	weighted := weightedTestPool("1", "0.002",
+		[3]string{testAtomDenom, "1000000000", "858993459200000"},
+		[3]string{OsmoDenom, "2000000000", "214748364800000"})
+	weighted.TotalWeight = "1073741824000000"
+
+	// 1 ATOM (6 decimals) και 10 EVMOS (18 decimals): 1 ATOM = 10 EVMOS σε display units
+	decimals := weightedTestPool("2", "0.002",
+		[3]string{testAtomDenom, "1000000", "1"},
+		[3]string{testEvmosDenom, "10000000000000000000", "1"})
+
+	prices, err := ComputePoolPrices([]types.OsmosisPool{weighted, decimals}, assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("got %d pool prices, want 2", len(prices))
+	}
+
+	tests := []struct {
+		price                types.PoolPrice
+		exact, inverseExact  string
+		weight0, weight1     float64
+		symbol0, symbol1     string
+		wantPoolType, wantID string
+	}{
+		{prices[0], "8", "0.125", 0.8, 0.2, "ATOM", "OSMO", types.PoolTypeBalancer, "1"},
+		{prices[1], "10", "0.1", 0.5, 0.5, "ATOM", "EVMOS", types.PoolTypeBalancer, "2"},
+	}
+	for _, tt := range tests {
+		p := tt.price
+		if p.PoolID != tt.wantID || p.PoolType != tt.wantPoolType {
+			t.Errorf("pool %s (%s), want %s (%s)", p.PoolID, p.PoolType, tt.wantID, tt.wantPoolType)
+		}
+		if p.Price0To1Exact != tt.exact || p.Price1To0Exact != tt.inverseExact {
+			t.Errorf("pool %s: exact prices %s / %s, want %s / %s", p.PoolID, p.Price0To1Exact, p.Price1To0Exact, tt.exact, tt.inverseExact)
+		}
+		if !closeTo(p.PriceToken0ToToken1*p.PriceToken1ToToken0, 1, 1e-12) {
+			t.Errorf("pool %s: float prices %v and %v are not inverse", p.PoolID, p.PriceToken0ToToken1, p.PriceToken1ToToken0)
+		}
+		if !closeTo(p.Token0Weight, tt.weight0, 1e-12) || !closeTo(p.Token1Weight, tt.weight1, 1e-12) {
+			t.Errorf("pool %s: weights %v/%v, want %v/%v", p.PoolID, p.Token0Weight, p.Token1Weight, tt.weight0, tt.weight1)
+		}
+		if p.Token0Symbol != tt.symbol0 || p.Token1Symbol != tt.symbol1 {
+			t.Errorf("pool %s: symbols %s/%s, want %s/%s", p.PoolID, p.Token0Symbol, p.Token1Symbol, tt.symbol0, tt.symbol1)
+		}
+	}
+}
+
+func TestComputePoolPricesMultiAsset(t *testing.T) {
+	assets := testAssetService(t)
+
+	pool := weightedTestPool("5", "0.002",
+		[3]string{testAtomDenom, "1000000", "1"},
+		[3]string{OsmoDenom, "2000000", "1"},
+		[3]string{testEvmosDenom, "4000000000000000000", "2"})
+
+	prices, err := ComputePoolPrices([]types.OsmosisPool{pool}, assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct {
+		price            string
+		weight0, weight1 float64
+	}{
+		"5:0-1": {"2", 0.25, 0.25},
+		"5:0-2": {"2", 0.25, 0.5}, // (4e18/2)/(1e6/1), 10^(6-18)
+		"5:1-2": {"1", 0.25, 0.5},
+	}
+	if len(prices) != len(want) {
+		t.Fatalf("got %d pool prices, want %d", len(prices), len(want))
+	}
+	for _, p := range prices {
+		expected, ok := want[p.PoolID]
+		if !ok {
+			t.Errorf("unexpected pool id %s", p.PoolID)
+			continue
+		}
+		if p.ParentPoolID != "5" || p.OnChainPoolID() != "5" || p.PoolAssetCount != 3 {
+			t.Errorf("%s: parent %q, asset count %d", p.PoolID, p.ParentPoolID, p.PoolAssetCount)
+		}
+		if p.Price0To1Exact != expected.price {
+			t.Errorf("%s: price %s, want %s", p.PoolID, p.Price0To1Exact, expected.price)
+		}
+		if !closeTo(p.Token0Weight, expected.weight0, 1e-12) || !closeTo(p.Token1Weight, expected.weight1, 1e-12) {
+			t.Errorf("%s: weights %v/%v, want %v/%v", p.PoolID, p.Token0Weight, p.Token1Weight, expected.weight0, expected.weight1)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+)
+
+const (
+	// maxConcurrentPages - Παράλληλα requests όταν οι σελίδες ζητούνται με offset
+	maxConcurrentPages = 4
+	// maxSequentialPages - Όριο ασφαλείας για το next_key loop
+	maxSequentialPages = 1000
+)
+
+// poolPage - Μια σελίδα από ένα LCD pools endpoint
+type poolPage[T any] struct {
+	Pools      []T                `json:"pools"`
+	Pagination types.PageResponse `json:"pagination"`
+}
+
+// fetchAllPoolPages φέρνει όλα τα pools ενός endpoint. Η πρώτη σελίδα ζητά και το
+// pagination.total· αν ο node το δηλώνει, οι υπόλοιπες σελίδες ζητούνται παράλληλα
+// με offset, αλλιώς ακολουθείται σειριακά το next_key μέχρι να εξαντληθεί.
+// Σελίδες που αποτυγχάνουν μετά την πρώτη δεν ακυρώνουν το αποτέλεσμα, καταγράφονται
+// όμως στο report.
+func fetchAllPoolPages[T any](ctx context.Context, c *LCDClient, path, source string, pageSize int) ([]T, types.PoolFetchReport, error) {
+	start := time.Now()
+	report := types.PoolFetchReport{Source: source}
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+
+	first, err := fetchPoolPage[T](ctx, c, path, pageSize, 0, "", true)
+	report.Pages = 1
+	if err != nil {
+		report.FailedPages = 1
+		return nil, finishFetchReport(report, start), err
+	}
+
+	pools := first.Pools
+	report.Total, _ = strconv.Atoi(first.Pagination.Total)
+
+	switch {
+	case first.Pagination.NextKey == "":
+		// Μία σελίδα αρκεί
+
+	case report.Total > len(first.Pools):
+		offsets := make([]int, 0, report.Total/pageSize)
+		for offset := len(first.Pools); offset < report.Total; offset += pageSize {
+			offsets = append(offsets, offset)
+		}
+
+		pages := make([][]T, len(offsets))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		semaphore := make(chan struct{}, maxConcurrentPages)
+
+		for i, offset := range offsets {
+			wg.Add(1)
+			go func(i, offset int) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				page, err := fetchPoolPage[T](ctx, c, path, pageSize, offset, "", false)
+
+				mu.Lock()
+				defer mu.Unlock()
+				report.Pages++
+				if err != nil {
+					report.FailedPages++
+					if ctx.Err() == nil {
+						log.Printf("⚠️  Αποτυχία σελίδας %s (offset %d): %v", source, offset, err)
+					}
+					return
+				}
+				pages[i] = page.Pools
+			}(i, offset)
+		}
+		wg.Wait()
+
+		for _, page := range pages {
+			pools = append(pools, page...)
+		}
+
+	default:
+		nextKey := first.Pagination.NextKey
+		for nextKey != "" && report.Pages < maxSequentialPages && ctx.Err() == nil {
+			page, err := fetchPoolPage[T](ctx, c, path, pageSize, 0, nextKey, false)
+			report.Pages++
+			if err != nil {
+				report.FailedPages++
+				log.Printf("⚠️  Αποτυχία σελίδας %s (next_key %s): %v", source, nextKey, err)
+				break
+			}
+			pools = append(pools, page.Pools...)
+			nextKey = page.Pagination.NextKey
+		}
+		if nextKey != "" && report.FailedPages == 0 {
+			// Το όριο σελίδων εξαντλήθηκε πριν το next_key
+			report.FailedPages++
+		}
+	}
+
+	report.Fetched = len(pools)
+	if err := ctx.Err(); err != nil {
+		return nil, finishFetchReport(report, start), err
+	}
+	return pools, finishFetchReport(report, start), nil
+}
+
+// fetchPoolPage ζητά μία σελίδα, είτε με offset είτε με το next_key της προηγούμενης
+func fetchPoolPage[T any](ctx context.Context, c *LCDClient, path string, limit, offset int, key string, countTotal bool) (*poolPage[T], error) {
+	query := url.Values{}
+	query.Set("pagination.limit", strconv.Itoa(limit))
+	if key != "" {
+		query.Set("pagination.key", key)
+	} else if offset > 0 {
+		query.Set("pagination.offset", strconv.Itoa(offset))
+	}
+	if countTotal {
+		query.Set("pagination.count_total", "true")
+	}
+
+	resp, err := c.get(ctx, path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var page poolPage[T]
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("σφάλμα κατά το parsing της σελίδας: %w", err)
+	}
+
+	return &page, nil
+}
+
+// finishFetchReport συμπληρώνει completeness, duration και timestamp
+func finishFetchReport(report types.PoolFetchReport, start time.Time) types.PoolFetchReport {
+	switch {
+	case report.Total > 0:
+		report.Completeness = float64(report.Fetched) / float64(report.Total)
+		if report.Completeness > 1 {
+			report.Completeness = 1
+		}
+	case report.FailedPages == 0:
+		report.Completeness = 1
+	}
+
+	report.Complete = report.FailedPages == 0 && report.Completeness >= 1
+	report.Duration = time.Since(start).Round(time.Millisecond).String()
+	report.Timestamp = time.Now()
+	return report
+}
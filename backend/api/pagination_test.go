@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// testPagePool - Ελάχιστο pool για τα tests σελιδοποίησης
+type testPagePool struct {
+	ID string `json:"id"`
+}
+
+// pagesServer - LCD με count pools. Με withTotal δηλώνει το pagination.total (offset path),
+// αλλιώς μόνο next_key. Η σελίδα που ξεκινά στο failAt (αν > 0) απαντά πάντα 500.
+func pagesServer(t *testing.T, count int, withTotal bool, failAt int) (*httptest.Server, *atomic.Int64) {
+	requests := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("pagination.limit"))
+		offset, _ := strconv.Atoi(query.Get("pagination.offset"))
+		if key := query.Get("pagination.key"); key != "" {
+			if query.Has("pagination.offset") {
+				t.Errorf("request with both key and offset: %s", r.URL.RawQuery)
+			}
+			offset, _ = strconv.Atoi(key)
+		}
+		if offset > 0 && query.Has("pagination.count_total") {
+			t.Errorf("count_total requested after the first page: %s", r.URL.RawQuery)
+		}
+		if failAt > 0 && offset == failAt {
+			http.Error(w, "node error", http.StatusInternalServerError)
+			return
+		}
+
+		page := poolPage[testPagePool]{Pools: []testPagePool{}}
+		for i := offset; i < min(offset+limit, count); i++ {
+			page.Pools = append(page.Pools, testPagePool{ID: strconv.Itoa(i)})
+		}
+		if offset+limit < count {
+			page.Pagination.NextKey = strconv.Itoa(offset + limit)
+		}
+		if withTotal && query.Get("pagination.count_total") == "true" {
+			page.Pagination.Total = strconv.Itoa(count)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func poolIDs(pools []testPagePool) []string {
+	ids := make([]string, len(pools))
+	for i, pool := range pools {
+		ids[i] = pool.ID
+	}
+	return ids
+}
+
+func TestFetchAllPoolPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int
+		withTotal    bool
+		failAt       int
+		ids          []string
+		total        int
+		pages        int
+		failedPages  int
+		completeness float64
+	}{
+		{"single page", 2, true, 0, []string{"0", "1"}, 2, 1, 0, 1},
+		{"next_key", 5, false, 0, []string{"0", "1", "2", "3", "4"}, 0, 3, 0, 1},
+		// Μετά από αποτυχημένη σελίδα το next_key χάνεται: κρατάμε ό,τι λήφθηκε
+		{"next_key failed page", 7, false, 4, []string{"0", "1", "2", "3"}, 0, 3, 1, 0},
+		{"offset", 7, true, 0, []string{"0", "1", "2", "3", "4", "5", "6"}, 7, 4, 0, 1},
+		// Οι υπόλοιπες σελίδες συνεχίζουν και η σειρά διατηρείται
+		{"offset failed page", 7, true, 4, []string{"0", "1", "2", "3", "6"}, 7, 4, 1, 5.0 / 7},
+	}
+	for _, tt := range tests {
+		server, _ := pagesServer(t, tt.count, tt.withTotal, tt.failAt)
+		client := testLCDClient(server.URL)
+
+		pools, report, err := fetchAllPoolPages[testPagePool](context.Background(), client, "/pools", "test", 2)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := poolIDs(pools); !slices.Equal(got, tt.ids) {
+			t.Errorf("%s: pools %v, want %v", tt.name, got, tt.ids)
+		}
+
+		complete := tt.failedPages == 0
+		if report.Source != "test" || report.Total != tt.total || report.Fetched != len(tt.ids) ||
+			report.Pages != tt.pages || report.FailedPages != tt.failedPages || report.Complete != complete ||
+			!closeTo(report.Completeness, tt.completeness, 1e-12) {
+			t.Errorf("%s: report %+v, want total %d fetched %d pages %d failed %d completeness %v",
+				tt.name, report, tt.total, len(tt.ids), tt.pages, tt.failedPages, tt.completeness)
+		}
+		if report.Duration == "" || report.Timestamp.IsZero() {
+			t.Errorf("%s: report without duration or timestamp", tt.name)
+		}
+	}
+}
+
+func TestFetchAllPoolPagesFirstPageFails(t *testing.T) {
+	server, _ := pagesServer(t, 0, true, 0)
+	server.Close()
+	client := testLCDClient(server.URL)
+
+	pools, report, err := fetchAllPoolPages[testPagePool](context.Background(), client, "/pools", "test", 2)
+	if err == nil || pools != nil {
+		t.Fatalf("%d pools, error %v; want an error", len(pools), err)
+	}
+	if report.Pages != 1 || report.FailedPages != 1 || report.Complete || report.Completeness != 0 {
+		t.Errorf("report %+v", report)
+	}
+}
+
+func TestFetchAllPoolPagesConcurrentRequests(t *testing.T) {
+	server, requests := pagesServer(t, 50, true, 0)
+	client := testLCDClient(server.URL)
+
+	pools, report, err := fetchAllPoolPages[testPagePool](context.Background(), client, "/pools", "test", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 50 || requests.Load() != 10 || report.Pages != 10 || !report.Complete {
+		t.Fatalf("%d pools in %d requests, report %+v", len(pools), requests.Load(), report)
+	}
+	for i, pool := range pools {
+		if pool.ID != strconv.Itoa(i) {
+			t.Fatalf("pool %d has id %s: pages out of order", i, pool.ID)
+		}
+	}
+}
+
+func TestFinishFetchReport(t *testing.T) {
+	tests := []struct {
+		name         string
+		report       types.PoolFetchReport
+		complete     bool
+		completeness float64
+	}{
+		{"unknown total", types.PoolFetchReport{Fetched: 10}, true, 1},
+		{"unknown total, failed page", types.PoolFetchReport{Fetched: 10, FailedPages: 1}, false, 0},
+		{"partial", types.PoolFetchReport{Total: 10, Fetched: 8}, false, 0.8},
+		{"more than total", types.PoolFetchReport{Total: 10, Fetched: 12}, true, 1},
+		{"all pools, failed page", types.PoolFetchReport{Total: 10, Fetched: 10, FailedPages: 1}, false, 1},
+	}
+	for _, tt := range tests {
+		report := finishFetchReport(tt.report, time.Now())
+		if report.Complete != tt.complete || !closeTo(report.Completeness, tt.completeness, 1e-12) {
+			t.Errorf("%s: complete %v at %v, want %v at %v", tt.name, report.Complete, report.Completeness, tt.complete, tt.completeness)
+		}
+	}
+}
+
+// healthStorage - Storage για το /api/health· οι υπόλοιπες μέθοδοι δεν καλούνται
+type healthStorage struct {
+	SQLiteStorageReader
+}
+
+func (healthStorage) GetDatabaseStats() (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+func TestHealthReportsPoolFetch(t *testing.T) {
+	server := NewHTTPServer(0, nil, healthStorage{})
+
+	health := func() (string, map[string]types.PoolFetchReport) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		server.handleHealth(recorder, httptest.NewRequest("GET", "/api/health", nil))
+		var response struct {
+			Status    string                           `json:"status"`
+			PoolFetch map[string]types.PoolFetchReport `json:"pool_fetch"`
+		}
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+		return response.Status, response.PoolFetch
+	}
+
+	server.SetPoolFetchReport(types.PoolFetchReport{Source: "gamm", Total: 4, Fetched: 4, Pages: 2, Complete: true, Completeness: 1})
+	if status, _ := health(); status != "healthy" {
+		t.Errorf("status %s with complete fetches, want healthy", status)
+	}
+
+	server.SetPoolFetchReport(types.PoolFetchReport{Source: "concentrated", Total: 7, Fetched: 5, Pages: 4, FailedPages: 1, Completeness: 5.0 / 7})
+	status, reports := health()
+	if status != "degraded" {
+		t.Errorf("status %s with a failed page, want degraded", status)
+	}
+	report := reports["concentrated"]
+	if len(reports) != 2 || report.FailedPages != 1 || report.Fetched != 5 || report.Complete || !closeTo(report.Completeness, 5.0/7, 1e-12) {
+		t.Errorf("pool_fetch %+v", reports)
+	}
+}
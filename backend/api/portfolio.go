@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// bech32Charset - Οι χαρακτήρες του data part μιας bech32 διεύθυνσης
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// ValidateOsmosisAddress - Έλεγχος μορφής (prefix osmo1, bech32 χαρακτήρες, μήκος) πριν
+// ρωτήσουμε τον node. Το checksum το ελέγχει ο node.
+func ValidateOsmosisAddress(address string) error {
+	data, ok := strings.CutPrefix(address, "osmo1")
+	if !ok {
+		return fmt.Errorf("η διεύθυνση %q δεν ξεκινά με osmo1", address)
+	}
+	// 38 χαρακτήρες για λογαριασμούς (20 bytes), 58 για contracts (32 bytes)
+	if len(data) < 38 || len(data) > 85 {
+		return fmt.Errorf("μη έγκυρο μήκος διεύθυνσης: %q", address)
+	}
+	for _, c := range data {
+		if !strings.ContainsRune(bech32Charset, c) {
+			return fmt.Errorf("μη έγκυρος χαρακτήρας %q στη διεύθυνση", c)
+		}
+	}
+	return nil
+}
+
+// BuildPortfolio - Αποτίμηση των balances με τα metadata του AssetService και τις τιμές
+// της cache. Tokens χωρίς τιμή εμφανίζονται με μηδενική αξία και Priced = false.
+func BuildPortfolio(address string, balances []types.BasicCoin, assets *types.AssetService, prices []types.TokenPrice) types.Portfolio {
+	priceByDenom := make(map[string]types.TokenPrice, len(prices))
+	for _, price := range prices {
+		if price.Chain != "" && price.Chain != types.DefaultChain {
+			continue
+		}
+		if existing, ok := priceByDenom[price.Denom]; !ok || price.LiquidityUSD > existing.LiquidityUSD {
+			priceByDenom[price.Denom] = price
+		}
+	}
+
+	portfolio := types.Portfolio{
+		Address:   address,
+		Chain:     types.DefaultChain,
+		Holdings:  make([]types.PortfolioHolding, 0, len(balances)),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, balance := range balances {
+		amount, err := decimal.Parse(balance.Amount)
+		if err != nil || !amount.IsPositive() {
+			continue
+		}
+
+		holding := types.PortfolioHolding{
+			Denom:  balance.Denom,
+			Symbol: balance.Denom,
+			Amount: balance.Amount,
+		}
+		if assets != nil {
+			holding.Symbol = assets.GetSymbol(balance.Denom)
+			holding.Exponent = assets.GetExponent(balance.Denom)
+			holding.LogoURL = assets.GetLogoURL(balance.Denom)
+			if asset, ok := assets.GetAsset(balance.Denom); ok {
+				holding.Name = asset.Name
+			}
+		}
+
+		display := amount.MulPow10(-holding.Exponent)
+		holding.DisplayAmount = display.String()
+
+		if price, ok := priceByDenom[balance.Denom]; ok && price.PriceUSD > 0 {
+			holding.Priced = true
+			holding.PriceUSD = price.PriceUSD
+			holding.PriceOSMO = price.PriceOSMO
+			holding.ValueUSD = display.Float64() * price.PriceUSD
+			holding.ValueOSMO = display.Float64() * price.PriceOSMO
+			portfolio.TotalUSD += holding.ValueUSD
+			portfolio.TotalOSMO += holding.ValueOSMO
+		} else {
+			portfolio.UnpricedCount++
+		}
+
+		portfolio.Holdings = append(portfolio.Holdings, holding)
+	}
+
+	for i := range portfolio.Holdings {
+		if portfolio.TotalUSD > 0 {
+			portfolio.Holdings[i].Share = portfolio.Holdings[i].ValueUSD / portfolio.TotalUSD
+		}
+	}
+	sort.SliceStable(portfolio.Holdings, func(i, j int) bool {
+		a, b := portfolio.Holdings[i], portfolio.Holdings[j]
+		if a.ValueUSD != b.ValueUSD {
+			return a.ValueUSD > b.ValueUSD
+		}
+		return a.Symbol < b.Symbol
+	})
+
+	return portfolio
+}
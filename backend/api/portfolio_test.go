@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// testAddress - Έγκυρη (ως μορφή) διεύθυνση λογαριασμού: osmo1 + 38 χαρακτήρες bech32
+const testAddress = "osmo1qpzry9x8gf2tvdw0s3jn54khce6mua7lqpzry9"
+
+func TestValidateOsmosisAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		valid   bool
+	}{
+		{"account", testAddress, true},
+		{"contract", "osmo1" + strings.Repeat("q", 58), true},
+		{"empty", "", false},
+		{"wrong prefix", "cosmos1qpzry9x8gf2tvdw0s3jn54khce6mua7lqpzry9", false},
+		{"prefix only", "osmo1", false},
+		{"too short", "osmo1" + strings.Repeat("q", 37), false},
+		{"too long", "osmo1" + strings.Repeat("q", 86), false},
+		{"uppercase", strings.ToUpper(testAddress), false},
+		{"character b", "osmo1" + strings.Repeat("q", 37) + "b", false},
+		{"character 1", "osmo1" + strings.Repeat("q", 37) + "1", false},
+	}
+	for _, tt := range tests {
+		err := ValidateOsmosisAddress(tt.address)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: ValidateOsmosisAddress(%q) = %v, want valid %v", tt.name, tt.address, err, tt.valid)
+		}
+	}
+}
+
+func TestBuildPortfolio(t *testing.T) {
+	assets := testAssetService(t)
+	prices := []types.TokenPrice{
+		{Symbol: "OSMO", Denom: "uosmo", PriceUSD: 0.5, PriceOSMO: 1, LiquidityUSD: 1000, Chain: types.DefaultChain},
+		// Λιγότερο liquid τιμή του ίδιου denom: αγνοείται
+		{Symbol: "OSMO", Denom: "uosmo", PriceUSD: 0.9, PriceOSMO: 1, LiquidityUSD: 10, Chain: types.DefaultChain},
+		{Symbol: "ATOM", Denom: testAtomDenom, PriceUSD: 10, PriceOSMO: 20, LiquidityUSD: 500},
+		// Τιμή άλλης αλυσίδας για το ίδιο denom: αγνοείται
+		{Symbol: "EVMOS", Denom: testEvmosDenom, PriceUSD: 3, PriceOSMO: 6, LiquidityUSD: 5000, Chain: "crescent"},
+	}
+
+	tests := []struct {
+		denom, amount string
+		symbol        string
+		exponent      int
+		display       string
+		priced        bool
+		valueUSD      float64
+		valueOSMO     float64
+	}{
+		{testAtomDenom, "2500000", "ATOM", 6, "2.5", true, 25, 50},
+		{"uosmo", "12000000", "OSMO", 6, "12", true, 6, 12},
+		{testEvmosDenom, "1500000000000000000", "EVMOS", 18, "1.5", false, 0, 0},
+		{"factory/osmo1unknown/token", "42", "factory/osmo1unknown/token", 0, "42", false, 0, 0},
+	}
+
+	balances := []types.BasicCoin{{Denom: "uion", Amount: "0"}} // Μηδενικά balances παραλείπονται
+	for _, tt := range tests {
+		balances = append(balances, types.BasicCoin{Denom: tt.denom, Amount: tt.amount})
+	}
+	portfolio := BuildPortfolio(testAddress, balances, assets, prices)
+
+	if len(portfolio.Holdings) != len(tests) {
+		t.Fatalf("%d holdings, want %d: %+v", len(portfolio.Holdings), len(tests), portfolio.Holdings)
+	}
+	for i, tt := range tests {
+		holding := portfolio.Holdings[i]
+		if holding.Denom != tt.denom {
+			t.Errorf("holding %d: %s, want %s (sorted by value)", i, holding.Denom, tt.denom)
+			continue
+		}
+		if holding.Symbol != tt.symbol || holding.Exponent != tt.exponent || holding.DisplayAmount != tt.display {
+			t.Errorf("%s: %s exponent %d display %s, want %s exponent %d display %s",
+				tt.denom, holding.Symbol, holding.Exponent, holding.DisplayAmount, tt.symbol, tt.exponent, tt.display)
+		}
+		if holding.Amount != tt.amount {
+			t.Errorf("%s: amount %s, want %s", tt.denom, holding.Amount, tt.amount)
+		}
+		if holding.Priced != tt.priced || !closeTo(holding.ValueUSD, tt.valueUSD, 1e-9) || !closeTo(holding.ValueOSMO, tt.valueOSMO, 1e-9) {
+			t.Errorf("%s: priced %v value %v USD / %v OSMO, want %v %v / %v",
+				tt.denom, holding.Priced, holding.ValueUSD, holding.ValueOSMO, tt.priced, tt.valueUSD, tt.valueOSMO)
+		}
+	}
+
+	if portfolio.Address != testAddress || portfolio.Chain != types.DefaultChain {
+		t.Errorf("portfolio %s on %s", portfolio.Address, portfolio.Chain)
+	}
+	if !closeTo(portfolio.TotalUSD, 31, 1e-9) || !closeTo(portfolio.TotalOSMO, 62, 1e-9) {
+		t.Errorf("totals %v USD / %v OSMO, want 31 / 62", portfolio.TotalUSD, portfolio.TotalOSMO)
+	}
+	if portfolio.UnpricedCount != 2 {
+		t.Errorf("unpriced count %d, want 2", portfolio.UnpricedCount)
+	}
+	if !closeTo(portfolio.Holdings[0].Share, 25.0/31, 1e-9) || portfolio.Holdings[2].Share != 0 {
+		t.Errorf("shares %v, %v", portfolio.Holdings[0].Share, portfolio.Holdings[2].Share)
+	}
+}
+
+func TestBuildPortfolioWithoutAssets(t *testing.T) {
+	prices := []types.TokenPrice{{Symbol: "OSMO", Denom: "uosmo", PriceUSD: 0.5, Chain: types.DefaultChain}}
+	portfolio := BuildPortfolio(testAddress, []types.BasicCoin{{Denom: "uosmo", Amount: "3"}}, nil, prices)
+
+	if len(portfolio.Holdings) != 1 {
+		t.Fatalf("%d holdings, want 1", len(portfolio.Holdings))
+	}
+	// Χωρίς assetlist ο exponent είναι 0 και το ποσό μένει σε base units
+	holding := portfolio.Holdings[0]
+	if holding.Symbol != "uosmo" || holding.DisplayAmount != "3" || !closeTo(holding.ValueUSD, 1.5, 1e-12) {
+		t.Errorf("holding %+v", holding)
+	}
+}
+
+// balancesServer - LCD με balances σε σελίδες των δύο, που ακολουθούνται μέσω next_key
+func balancesServer(t *testing.T, coins []types.BasicCoin, endless bool) (*httptest.Server, *atomic.Int64) {
+	requests := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/cosmos/bank/v1beta1/balances/"+testAddress {
+			http.NotFound(w, r)
+			return
+		}
+		page := 0
+		if key := r.URL.Query().Get("pagination.key"); key != "" {
+			page, _ = strconv.Atoi(key)
+		}
+
+		response := struct {
+			Balances   []types.BasicCoin  `json:"balances"`
+			Pagination types.PageResponse `json:"pagination"`
+		}{Balances: []types.BasicCoin{}}
+		if start := page * 2; start < len(coins) {
+			response.Balances = coins[start:min(start+2, len(coins))]
+		}
+		if endless || (page+1)*2 < len(coins) {
+			response.Pagination.NextKey = strconv.Itoa(page + 1)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestGetBalancesFollowsNextKey(t *testing.T) {
+	coins := []types.BasicCoin{
+		{Denom: "uosmo", Amount: "1"},
+		{Denom: "uion", Amount: "2"},
+		{Denom: testAtomDenom, Amount: "3"},
+		{Denom: testEvmosDenom, Amount: "4"},
+		{Denom: "factory/osmo1x/token", Amount: "5"},
+	}
+	server, requests := balancesServer(t, coins, false)
+	client := &OsmosisPoolClient{LCDClient: testLCDClient(server.URL)}
+
+	balances, err := client.GetBalances(context.Background(), testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != len(coins) || requests.Load() != 3 {
+		t.Fatalf("%d balances in %d requests, want %d in 3", len(balances), requests.Load(), len(coins))
+	}
+	for i, coin := range coins {
+		if balances[i] != coin {
+			t.Errorf("balance %d: %+v, want %+v", i, balances[i], coin)
+		}
+	}
+}
+
+func TestGetBalancesRejectsTruncatedList(t *testing.T) {
+	server, requests := balancesServer(t, []types.BasicCoin{{Denom: "uosmo", Amount: "1"}}, true)
+	client := &OsmosisPoolClient{LCDClient: testLCDClient(server.URL)}
+
+	balances, err := client.GetBalances(context.Background(), testAddress)
+	if err == nil {
+		t.Fatalf("%d balances without an error, want an error after %d pages", len(balances), maxSequentialPages)
+	}
+	if balances != nil || requests.Load() != maxSequentialPages {
+		t.Errorf("%d balances after %d requests, want none after %d", len(balances), requests.Load(), maxSequentialPages)
+	}
+}
@@ -0,0 +1,378 @@
+package api
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// USDAnchors - Stablecoins που θεωρούνται ίσα με 1 USD (αφετηρία του price graph)
+var USDAnchors = map[string]float64{
+	"ibc/498A0751C798A0D9A389AA3691123DADA57DAA4FE165D5C75894505B876BA6E4":                    1.0, // USDC (Noble)
+	"ibc/D189335C6E4A68B513C10AB227BF1C1D38C746766278BA3EEB4FB14124F1D858":                    1.0, // USDC (Axelar)
+	"ibc/4ABBEF4C8926DDDB320AE5188CFD63267ABBCEFC0583E4AE05D6E5AA2401DDAB":                    1.0, // USDT (Kava)
+	"ibc/8242AD24008032E457D2E12D46588FD39FB54FB29680C6C7663D296B383C37C4":                    1.0, // USDT (Axelar)
+	"factory/osmo1em6xs47hd82806f5cxgyufguxrrc7l0aqx7nzzptjuqgswczk8csavdxek/alloyed/allUSDT": 1.0, // Alloyed USDT
+	"ibc/6329DD8CF31A334DD5BE3F68C846C9FE313281362B37686A62343BAC1EB1546D":                    1.0, // BUSD
+	"ibc/0CD3A0285E1341859B5E86B6AB7682F023D03E97607CCC1DC95706411D866DF7":                    1.0, // DAI
+}
+
+// usdStableSymbols - Symbols του chain registry που θεωρούνται 1 USD σε αλυσίδες εκτός Osmosis
+var usdStableSymbols = map[string]bool{
+	"USDC": true, "USDT": true, "AXLUSDC": true, "AXLUSDT": true, "WUSDC": true, "DAI": true, "NOBLEUSDC": true,
+}
+
+const (
+	// OsmoDenom - Το native token του Osmosis
+	OsmoDenom = "uosmo"
+	// AtomDenom - ATOM μέσω IBC (channel-0)
+	AtomDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+	// minOracleLiquidityUSD - Pools με μικρότερη liquidity αγνοούνται από το oracle
+	minOracleLiquidityUSD = 100.0
+)
+
+// oracleBridgeDenoms - Η σειρά με την οποία τιμολογούνται τα "γεφυρωτικά" tokens
+// πριν από τα υπόλοιπα: stable → OSMO → ATOM → long-tail tokens
+var oracleBridgeDenoms = []string{OsmoDenom, AtomDenom}
+
+// oracleQuote - Μια τιμή για ένα token από ένα pool, με βάρος τη liquidity του pool
+type oracleQuote struct {
+	priceUSD     float64
+	liquidityUSD float64
+}
+
+// oracleEdge - Ένα pool ιδωμένο από την πλευρά του token που τιμολογείται
+type oracleEdge struct {
+	neighbor        string  // denom του άλλου token
+	rate            float64 // πόσα neighbor (display units) δίνει 1 token
+	neighborReserve float64 // reserve του neighbor σε display units
+	neighborWeight  float64 // κανονικοποιημένο weight του neighbor στο pool
+	ownReserve      float64 // reserve του token σε display units
+	concentrated    bool    // CL pool: χωρίς weights, η liquidity μετριέται και από τις δύο πλευρές
+}
+
+// PriceOracle υπολογίζει USD/OSMO τιμές για κάθε token που είναι προσβάσιμο
+// από τα USD anchors μέσω του γράφου των pools. Κάθε pool συνεισφέρει στην τιμή
+// ανάλογα με τη liquidity του (σε USD).
+type PriceOracle struct {
+	assetService *types.AssetService
+	anchors      map[string]float64
+	osmoUSD      float64 // Τιμή OSMO για το PriceOSMO όταν το uosmo δεν υπάρχει στα pools
+}
+
+// NewPriceOracle - Δημιουργία oracle με τα default USD anchors
+func NewPriceOracle(assetService *types.AssetService) *PriceOracle {
+	return &PriceOracle{
+		assetService: assetService,
+		anchors:      USDAnchors,
+	}
+}
+
+// NewChainPriceOracle - Oracle για αλυσίδα εκτός Osmosis με δικά της anchors (βλ. ChainUSDAnchors)
+// και την τιμή του OSMO από το Osmosis oracle
+func NewChainPriceOracle(assetService *types.AssetService, anchors map[string]float64, osmoUSD float64) *PriceOracle {
+	return &PriceOracle{
+		assetService: assetService,
+		anchors:      anchors,
+		osmoUSD:      osmoUSD,
+	}
+}
+
+// ChainUSDAnchors - Τα anchors μιας αλυσίδας από το assetlist της: τα stablecoins στο 1 USD
+// και όσα tokens έχουν ήδη τιμή στο Osmosis με το ίδιο symbol (π.χ. ARCH, ORAI)
+func ChainUSDAnchors(assetService *types.AssetService, knownUSD func(symbol string) (float64, bool)) map[string]float64 {
+	anchors := make(map[string]float64)
+	for _, asset := range assetService.GetAllTokens() {
+		symbol := strings.ToUpper(asset.Symbol)
+		switch {
+		case usdStableSymbols[symbol]:
+			anchors[asset.Base] = 1.0
+		case knownUSD != nil:
+			if price, ok := knownUSD(asset.Symbol); ok && validRate(price) {
+				anchors[asset.Base] = price
+			}
+		}
+	}
+	return anchors
+}
+
+// ComputeTokenPrices επιστρέφει ένα TokenPrice για κάθε token που τιμολογήθηκε
+func (o *PriceOracle) ComputeTokenPrices(poolPrices []types.PoolPrice) []types.TokenPrice {
+	graph := o.buildGraph(poolPrices)
+
+	pricesUSD := make(map[string]float64)
+	liquidity := make(map[string]float64)
+	symbols := make(map[string]string)
+
+	for _, price := range poolPrices {
+		symbols[price.Token0Denom] = price.Token0Symbol
+		symbols[price.Token1Denom] = price.Token1Symbol
+	}
+
+	for denom, usd := range o.anchors {
+		if _, ok := graph[denom]; ok {
+			pricesUSD[denom] = usd
+		}
+	}
+
+	// 1. Bridge tokens με σειρά: κάθε ένα τιμολογείται από όσα έχουν ήδη τιμή
+	for _, denom := range oracleBridgeDenoms {
+		if _, priced := pricesUSD[denom]; priced {
+			continue
+		}
+		if quote, ok := o.quote(graph[denom], pricesUSD); ok {
+			pricesUSD[denom] = quote.priceUSD
+			liquidity[denom] = quote.liquidityUSD
+		}
+	}
+
+	// 2. Long-tail tokens ανά "στρώμα": ένα token τιμολογείται μόνο από tokens
+	// που είχαν τιμή στην αρχή του στρώματος, ώστε οι τιμές να πηγάζουν από
+	// τα πιο κοντινά (και πιο liquid) anchors
+	for {
+		layer := make(map[string]oracleQuote)
+		for denom, edges := range graph {
+			if _, priced := pricesUSD[denom]; priced {
+				continue
+			}
+			if quote, ok := o.quote(edges, pricesUSD); ok {
+				layer[denom] = quote
+			}
+		}
+
+		if len(layer) == 0 {
+			break
+		}
+
+		for denom, quote := range layer {
+			pricesUSD[denom] = quote.priceUSD
+			liquidity[denom] = quote.liquidityUSD
+		}
+	}
+
+	// Τα anchors παίρνουν ως liquidity το άθροισμα των pools τους
+	for denom := range o.anchors {
+		if _, ok := pricesUSD[denom]; ok {
+			if quote, ok := o.quote(graph[denom], pricesUSD); ok {
+				liquidity[denom] = quote.liquidityUSD
+			}
+		}
+	}
+
+	osmoUSD := pricesUSD[OsmoDenom]
+	if osmoUSD > 0 && o.assetService != nil {
+		o.assetService.SetOsmoUsdPrice(osmoUSD)
+	}
+	if osmoUSD <= 0 {
+		osmoUSD = o.osmoUSD
+	}
+
+	chain := types.DefaultChain
+	if o.assetService != nil && o.assetService.Chain != "" {
+		chain = o.assetService.Chain
+	}
+
+	timestamp := time.Now()
+	result := make([]types.TokenPrice, 0, len(pricesUSD))
+	for denom, usd := range pricesUSD {
+		tokenPrice := types.TokenPrice{
+			Symbol:       symbols[denom],
+			Denom:        denom,
+			PriceUSD:     usd,
+			LiquidityUSD: liquidity[denom],
+			Chain:        chain,
+			Timestamp:    timestamp,
+		}
+		if osmoUSD > 0 {
+			tokenPrice.PriceOSMO = usd / osmoUSD
+		}
+		result = append(result, tokenPrice)
+	}
+
+	return result
+}
+
+// ApplyLiquidityUSD συμπληρώνει το LiquidityUSD (TVL) κάθε PoolPrice από τα reserves
+// και τις USD τιμές των tokens. Οι εγγραφές ενός multi-asset pool παίρνουν το TVL
+// ολόκληρου του on-chain pool. Αν κάποια assets δεν έχουν τιμή, το TVL προεκτείνεται
+// από τα τιμολογημένα assets με βάση τα weights τους. Τα CL pools δεν έχουν weights:
+// το TVL τους είναι το άθροισμα των πραγματικών balances και μένει άγνωστο
+// (LiquidityUnknown) αν τα balances δεν έχουν ληφθεί ή κάποιο token δεν έχει τιμή.
+func (o *PriceOracle) ApplyLiquidityUSD(poolPrices []types.PoolPrice, tokenPrices []types.TokenPrice) {
+	usd := make(map[string]float64, len(tokenPrices))
+	for _, tokenPrice := range tokenPrices {
+		usd[tokenPrice.Denom] = tokenPrice.PriceUSD
+	}
+
+	type poolAsset struct {
+		amount string
+		weight float64
+	}
+	type onChainPool struct {
+		assets       map[string]poolAsset
+		concentrated bool
+		unknown      bool
+	}
+
+	// on-chain pool -> denom -> asset
+	pools := make(map[string]*onChainPool)
+	for _, price := range poolPrices {
+		poolID := price.OnChainPoolID()
+		pool := pools[poolID]
+		if pool == nil {
+			pool = &onChainPool{
+				assets:       make(map[string]poolAsset),
+				concentrated: price.PoolType == types.PoolTypeConcentrated,
+			}
+			pools[poolID] = pool
+		}
+		pool.unknown = pool.unknown || price.LiquidityUnknown
+		pool.assets[price.Token0Denom] = poolAsset{amount: price.Token0Amount, weight: price.Token0Weight}
+		pool.assets[price.Token1Denom] = poolAsset{amount: price.Token1Amount, weight: price.Token1Weight}
+	}
+
+	tvl := make(map[string]float64, len(pools))
+	unknown := make(map[string]bool)
+	for poolID, pool := range pools {
+		if pool.unknown {
+			unknown[poolID] = true
+			continue
+		}
+
+		var pricedValue, pricedWeight float64
+		allPriced := true
+		for denom, asset := range pool.assets {
+			priceUSD, ok := usd[denom]
+			if !ok || priceUSD <= 0 {
+				allPriced = false
+				continue
+			}
+			pricedValue += o.displayAmount(denom, asset.amount) * priceUSD
+			pricedWeight += asset.weight
+		}
+
+		if pool.concentrated {
+			if !allPriced {
+				unknown[poolID] = true
+				continue
+			}
+			tvl[poolID] = pricedValue
+			continue
+		}
+
+		if pricedValue <= 0 {
+			continue
+		}
+		if pricedWeight > 0 && pricedWeight < 1 {
+			pricedValue /= pricedWeight
+		}
+		tvl[poolID] = pricedValue
+	}
+
+	for i := range poolPrices {
+		poolID := poolPrices[i].OnChainPoolID()
+		poolPrices[i].LiquidityUSD = tvl[poolID]
+		poolPrices[i].LiquidityUnknown = unknown[poolID]
+	}
+}
+
+// buildGraph - denom -> ακμές προς τα tokens με τα οποία μοιράζεται pool
+func (o *PriceOracle) buildGraph(poolPrices []types.PoolPrice) map[string][]oracleEdge {
+	graph := make(map[string][]oracleEdge)
+
+	for _, price := range poolPrices {
+		if price.Token0Denom == price.Token1Denom || !validRate(price.PriceToken0ToToken1) || !validRate(price.PriceToken1ToToken0) {
+			continue
+		}
+
+		reserve0 := o.displayAmount(price.Token0Denom, price.Token0Amount)
+		reserve1 := o.displayAmount(price.Token1Denom, price.Token1Amount)
+		if reserve0 <= 0 || reserve1 <= 0 {
+			continue
+		}
+
+		weight0, weight1 := price.Token0Weight, price.Token1Weight
+		if weight0 <= 0 || weight1 <= 0 {
+			weight0, weight1 = 0.5, 0.5
+		}
+
+		concentrated := price.PoolType == types.PoolTypeConcentrated
+		graph[price.Token0Denom] = append(graph[price.Token0Denom], oracleEdge{
+			neighbor:        price.Token1Denom,
+			rate:            price.PriceToken0ToToken1,
+			neighborReserve: reserve1,
+			neighborWeight:  weight1,
+			ownReserve:      reserve0,
+			concentrated:    concentrated,
+		})
+		graph[price.Token1Denom] = append(graph[price.Token1Denom], oracleEdge{
+			neighbor:        price.Token0Denom,
+			rate:            price.PriceToken1ToToken0,
+			neighborReserve: reserve0,
+			neighborWeight:  weight0,
+			ownReserve:      reserve1,
+			concentrated:    concentrated,
+		})
+	}
+
+	return graph
+}
+
+// quote - Μέση τιμή σταθμισμένη με τη liquidity, από όλα τα pools με ήδη τιμολογημένο neighbor.
+// Η liquidity ενός pool εκτιμάται από την αξία της τιμολογημένης πλευράς διά το weight της
+// (στα CL pools από τα balances και των δύο πλευρών).
+func (o *PriceOracle) quote(edges []oracleEdge, pricesUSD map[string]float64) (oracleQuote, bool) {
+	var weightedSum, totalLiquidity float64
+
+	for _, edge := range edges {
+		neighborUSD, ok := pricesUSD[edge.neighbor]
+		if !ok || neighborUSD <= 0 {
+			continue
+		}
+
+		poolLiquidity := edge.neighborReserve * neighborUSD / edge.neighborWeight
+		if edge.concentrated {
+			// Τα balances ενός CL pool δεν ακολουθούν weights: η δική μας πλευρά αποτιμάται
+			// με την τιμή του pool
+			poolLiquidity = (edge.neighborReserve + edge.ownReserve*edge.rate) * neighborUSD
+		}
+		if poolLiquidity < minOracleLiquidityUSD {
+			continue
+		}
+
+		weightedSum += edge.rate * neighborUSD * poolLiquidity
+		totalLiquidity += poolLiquidity
+	}
+
+	if totalLiquidity == 0 {
+		return oracleQuote{}, false
+	}
+
+	return oracleQuote{
+		priceUSD:     weightedSum / totalLiquidity,
+		liquidityUSD: totalLiquidity,
+	}, true
+}
+
+// displayAmount μετατρέπει ένα raw amount σε display units. Η διαίρεση με 10^exp γίνεται
+// σε decimal, ώστε reserves πέρα από τα 2^53 να μη χάνουν ψηφία πριν τη μετατροπή.
+func (o *PriceOracle) displayAmount(denom, amount string) float64 {
+	raw, err := decimal.Parse(amount)
+	if err != nil {
+		return 0
+	}
+	exp := 0
+	if o.assetService != nil {
+		exp = o.assetService.GetExponent(denom)
+	}
+	return raw.MulPow10(-exp).Float64()
+}
+
+// validRate - Θετικός και πεπερασμένος αριθμός
+func validRate(rate float64) bool {
+	return rate > 0 && !math.IsInf(rate, 0) && !math.IsNaN(rate)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// oracleTestPool - Ισοβαρές pool δύο tokens με τιμή από τα reserves (display units = base units)
+func oracleTestPool(id, denom0, amount0, denom1, amount1 string, rate0To1 float64) types.PoolPrice {
+	return types.PoolPrice{
+		PoolID:              id,
+		Token0Denom:         denom0,
+		Token1Denom:         denom1,
+		Token0Amount:        amount0,
+		Token1Amount:        amount1,
+		Token0Weight:        0.5,
+		Token1Weight:        0.5,
+		PriceToken0ToToken1: rate0To1,
+		PriceToken1ToToken0: 1 / rate0To1,
+	}
+}
+
+func TestPriceOracleLayers(t *testing.T) {
+	const usdc = "uusdc"
+	oracle := NewChainPriceOracle(nil, map[string]float64{usdc: 1}, 0)
+
+	pools := []types.PoolPrice{
+		// Δύο USDC/OSMO pools: 0.5 USD με liquidity 2000 και 0.6 USD με liquidity 6000
+		oracleTestPool("1", usdc, "1000", OsmoDenom, "2000", 2),
+		oracleTestPool("2", usdc, "3000", OsmoDenom, "5000", 5.0/3.0),
+		// ATOM μόνο μέσω OSMO: 1 ATOM = 10 OSMO
+		oracleTestPool("3", AtomDenom, "1000", OsmoDenom, "10000", 10),
+		// Long-tail token: 1 LONG = 4 OSMO σε βαθύ pool και ένα USDC pool 80 USD
+		// (κάτω από το minOracleLiquidityUSD) με τιμή 10 USD που πρέπει να αγνοηθεί
+		oracleTestPool("4", "ulong", "2500", OsmoDenom, "10000", 4),
+		oracleTestPool("5", "ulong", "4", usdc, "40", 10),
+		// Δεύτερο στρώμα: 1 DEEP = 0.5 LONG
+		oracleTestPool("6", "udeep", "2000", "ulong", "1000", 0.5),
+		// Χωρίς διαδρομή προς κάποιο anchor
+		oracleTestPool("7", "uisland", "1000", "uother", "1000", 1),
+	}
+
+	prices := make(map[string]types.TokenPrice)
+	for _, price := range oracle.ComputeTokenPrices(pools) {
+		prices[price.Denom] = price
+	}
+
+	osmoUSD := (0.5*2000 + 0.6*6000) / 8000 // 0.575
+	tests := []struct {
+		denom         string
+		wantUSD       float64
+		wantLiquidity float64
+	}{
+		{usdc, 1, (2000 + 5000) * osmoUSD / 0.5}, // Η liquidity των anchors μετριέται από την άλλη πλευρά· το pool 5 μένει εκτός
+		{OsmoDenom, osmoUSD, 8000},
+		{AtomDenom, 10 * osmoUSD, 10000 * osmoUSD / 0.5},
+		{"ulong", 4 * osmoUSD, 10000 * osmoUSD / 0.5},
+		{"udeep", 2 * osmoUSD, 1000 * 4 * osmoUSD / 0.5},
+	}
+	for _, tt := range tests {
+		price, ok := prices[tt.denom]
+		if !ok {
+			t.Errorf("%s: not priced", tt.denom)
+			continue
+		}
+		if !closeTo(price.PriceUSD, tt.wantUSD, 1e-12) {
+			t.Errorf("%s: price %v USD, want %v", tt.denom, price.PriceUSD, tt.wantUSD)
+		}
+		if !closeTo(price.LiquidityUSD, tt.wantLiquidity, 1e-12) {
+			t.Errorf("%s: liquidity %v USD, want %v", tt.denom, price.LiquidityUSD, tt.wantLiquidity)
+		}
+		if !closeTo(price.PriceOSMO, tt.wantUSD/osmoUSD, 1e-12) {
+			t.Errorf("%s: price %v OSMO, want %v", tt.denom, price.PriceOSMO, tt.wantUSD/osmoUSD)
+		}
+	}
+
+	for _, denom := range []string{"uisland", "uother"} {
+		if _, ok := prices[denom]; ok {
+			t.Errorf("%s priced without a path to an anchor", denom)
+		}
+	}
+}
+
+func TestPriceOracleMinLiquidity(t *testing.T) {
+	oracle := NewChainPriceOracle(nil, map[string]float64{"uusdc": 1}, 0)
+
+	// 49 USDC σε ισοβαρές pool = 98 USD liquidity: κάτω από το όριο, το token μένει χωρίς τιμή
+	prices := oracle.ComputeTokenPrices([]types.PoolPrice{
+		oracleTestPool("1", "uusdc", "49", "ushallow", "49", 1),
+	})
+	for _, price := range prices {
+		if price.Denom == "ushallow" {
+			t.Errorf("ushallow priced at %v USD from a pool below %v USD", price.PriceUSD, minOracleLiquidityUSD)
+		}
+	}
+
+	// 50 USDC = 100 USD: ακριβώς στο όριο
+	prices = oracle.ComputeTokenPrices([]types.PoolPrice{
+		oracleTestPool("1", "uusdc", "50", "ushallow", "50", 1),
+	})
+	found := false
+	for _, price := range prices {
+		if price.Denom == "ushallow" {
+			found = true
+			if !closeTo(price.PriceUSD, 1, 1e-12) {
+				t.Errorf("ushallow: price %v USD, want 1", price.PriceUSD)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("ushallow not priced from a pool at %v USD", minOracleLiquidityUSD)
+	}
+}
+
+func TestApplyLiquidityUSD(t *testing.T) {
+	oracle := NewChainPriceOracle(nil, map[string]float64{"uusdc": 1}, 0)
+	tokenPrices := []types.TokenPrice{
+		{Denom: "uusdc", PriceUSD: 1},
+		{Denom: OsmoDenom, PriceUSD: 0.5},
+	}
+
+	weighted := oracleTestPool("1", "uusdc", "1000", OsmoDenom, "2000", 2)
+	// 80/20 pool όπου μόνο το OSMO (20%) έχει τιμή: 100 USD / 0.2
+	extrapolated := oracleTestPool("2", OsmoDenom, "200", "ulong", "1", 1)
+	extrapolated.Token0Weight, extrapolated.Token1Weight = 0.2, 0.8
+
+	cl := oracleTestPool("3", "uusdc", "300", OsmoDenom, "100", 2)
+	cl.PoolType = types.PoolTypeConcentrated
+	clUnpriced := oracleTestPool("4", OsmoDenom, "100", "ulong", "100", 1)
+	clUnpriced.PoolType = types.PoolTypeConcentrated
+	clNoBalances := oracleTestPool("5", "uusdc", "0", OsmoDenom, "0", 2)
+	clNoBalances.PoolType = types.PoolTypeConcentrated
+	clNoBalances.LiquidityUnknown = true
+
+	pools := []types.PoolPrice{weighted, extrapolated, cl, clUnpriced, clNoBalances}
+	oracle.ApplyLiquidityUSD(pools, tokenPrices)
+
+	tests := []struct {
+		wantTVL     float64
+		wantUnknown bool
+	}{
+		{2000, false},
+		{500, false},
+		{350, false}, // Τα balances δεν είναι 50/50: 300 + 100·0.5
+		{0, true},
+		{0, true},
+	}
+	for i, tt := range tests {
+		p := pools[i]
+		if !closeTo(p.LiquidityUSD, tt.wantTVL, 1e-12) || p.LiquidityUnknown != tt.wantUnknown {
+			t.Errorf("pool %s: TVL %v (unknown %v), want %v (unknown %v)", p.PoolID, p.LiquidityUSD, p.LiquidityUnknown, tt.wantTVL, tt.wantUnknown)
+		}
+	}
+}
+
+func TestPriceOracleConcentratedLiquidity(t *testing.T) {
+	oracle := NewChainPriceOracle(nil, map[string]float64{"uusdc": 1}, 0)
+
+	// CL pool με 60 USDC και 1000 OSMO στα 0.1 USD: 160 USD liquidity, πάνω από το όριο,
+	// αν και η πλευρά του USDC διά 0.5 θα έδινε 120
+	cl := oracleTestPool("1", "uusdc", "60", OsmoDenom, "1000", 10)
+	cl.PoolType = types.PoolTypeConcentrated
+
+	for _, price := range oracle.ComputeTokenPrices([]types.PoolPrice{cl}) {
+		if price.Denom != OsmoDenom {
+			continue
+		}
+		if !closeTo(price.PriceUSD, 0.1, 1e-12) || !closeTo(price.LiquidityUSD, 160, 1e-12) {
+			t.Errorf("OSMO: %v USD with liquidity %v, want 0.1 with 160", price.PriceUSD, price.LiquidityUSD)
+		}
+		return
+	}
+	t.Error("OSMO not priced from the CL pool")
+}
@@ -0,0 +1,414 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"portofoliov1/types"
+)
+
+const (
+	// streamBufferSize - Μηνύματα σε αναμονή ανά client πριν αρχίσουμε να τα πετάμε
+	streamBufferSize = 16
+	// streamHeartbeat - Keep-alive για SSE (comment) και WebSocket (ping)
+	streamHeartbeat = 15 * time.Second
+	// streamWriteTimeout - Όριο εγγραφής ενός μηνύματος σε WebSocket
+	streamWriteTimeout = 10 * time.Second
+)
+
+// StreamMessage - Μήνυμα που στέλνεται στους subscribers του /api/stream
+type StreamMessage struct {
+	Type          string                       `json:"type"` // "pool_prices" ή "arbitrage"
+	Prices        []types.PoolPrice            `json:"prices,omitempty"`
+	Opportunities []types.ArbitrageOpportunity `json:"opportunities,omitempty"`
+	Timestamp     time.Time                    `json:"timestamp"`
+}
+
+// Τύποι events του /api/stream
+const (
+	StreamEventPoolPrices = "pool_prices"
+	StreamEventArbitrage  = "arbitrage"
+)
+
+// streamSubscription - Τα events και τα tokens/pools που ενδιαφέρουν έναν client
+type streamSubscription struct {
+	events map[string]bool // Κενό = μόνο pool_prices
+	tokens map[string]bool // UPPER(symbol) ή denom
+	pools  map[string]bool // pool_id ή on-chain id
+}
+
+// wants - Έχει ζητήσει ο client αυτόν τον τύπο event;
+func (s streamSubscription) wants(event string) bool {
+	if len(s.events) == 0 {
+		return event == StreamEventPoolPrices
+	}
+	return s.events[event]
+}
+
+// matches - Κενή συνδρομή σημαίνει όλες οι αλλαγές
+func (s streamSubscription) matches(price types.PoolPrice) bool {
+	if len(s.tokens) == 0 && len(s.pools) == 0 {
+		return true
+	}
+	return s.pools[price.PoolID] || s.pools[price.OnChainPoolID()] ||
+		s.tokens[strings.ToUpper(price.Token0Symbol)] || s.tokens[strings.ToUpper(price.Token1Symbol)] ||
+		s.tokens[price.Token0Denom] || s.tokens[price.Token1Denom]
+}
+
+// matchesArbitrage - Ο κύκλος περνά από κάποιο token ή pool της συνδρομής
+func (s streamSubscription) matchesArbitrage(opportunity types.ArbitrageOpportunity) bool {
+	if len(s.tokens) == 0 && len(s.pools) == 0 {
+		return true
+	}
+	for _, hop := range opportunity.Hops {
+		if s.pools[hop.PoolID] || s.tokens[strings.ToUpper(hop.FromSymbol)] || s.tokens[hop.FromDenom] {
+			return true
+		}
+	}
+	return false
+}
+
+// update προσθέτει ή αφαιρεί events και tokens/pools από τη συνδρομή
+func (s *streamSubscription) update(events, tokens, pools []string, add bool) {
+	for _, event := range events {
+		if event = strings.ToLower(strings.TrimSpace(event)); event != "" {
+			setStreamKey(s.events, event, add)
+		}
+	}
+	// Κάθε token κρατιέται και ως denom (όπως δόθηκε) και ως symbol (κεφαλαία)
+	for _, token := range tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			setStreamKey(s.tokens, token, add)
+			setStreamKey(s.tokens, strings.ToUpper(token), add)
+		}
+	}
+	for _, pool := range pools {
+		if pool = strings.TrimSpace(pool); pool != "" {
+			setStreamKey(s.pools, pool, add)
+		}
+	}
+}
+
+func setStreamKey(keys map[string]bool, key string, add bool) {
+	if add {
+		keys[key] = true
+	} else {
+		delete(keys, key)
+	}
+}
+
+// streamClient - Ένας συνδεδεμένος client (WebSocket ή SSE)
+type streamClient struct {
+	mu           sync.Mutex
+	subscription streamSubscription
+	messages     chan StreamMessage
+}
+
+// send - Χωρίς αναμονή: αργοί clients χάνουν μηνύματα
+func (c *streamClient) send(message StreamMessage) {
+	select {
+	case c.messages <- message:
+	default:
+	}
+}
+
+// PriceStreamHub - Μοιράζει τις αλλαγές τιμών στους clients με βάση τη συνδρομή τους
+type PriceStreamHub struct {
+	mu        sync.RWMutex
+	clients   map[*streamClient]struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPriceStreamHub - Δημιουργία hub χωρίς clients
+func NewPriceStreamHub() *PriceStreamHub {
+	return &PriceStreamHub{
+		clients: make(map[*streamClient]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Close τερματίζει όλα τα ενεργά streams (κατά το shutdown του server)
+func (h *PriceStreamHub) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+// Publish στέλνει σε κάθε client μόνο τις αλλαγές που τον αφορούν. Αργοί clients
+// χάνουν μηνύματα αντί να καθυστερούν τον collector.
+func (h *PriceStreamHub) Publish(changed []types.PoolPrice) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	timestamp := time.Now()
+	for client := range h.clients {
+		client.mu.Lock()
+		var prices []types.PoolPrice
+		if client.subscription.wants(StreamEventPoolPrices) {
+			for _, price := range changed {
+				if client.subscription.matches(price) {
+					prices = append(prices, price)
+				}
+			}
+		}
+		client.mu.Unlock()
+
+		if len(prices) == 0 {
+			continue
+		}
+		client.send(StreamMessage{Type: StreamEventPoolPrices, Prices: prices, Timestamp: timestamp})
+	}
+}
+
+// PublishArbitrage στέλνει τους νέους κύκλους arbitrage στους clients που ζήτησαν
+// το event "arbitrage" και τους αφορούν
+func (h *PriceStreamHub) PublishArbitrage(opportunities []types.ArbitrageOpportunity) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	timestamp := time.Now()
+	for client := range h.clients {
+		client.mu.Lock()
+		var matched []types.ArbitrageOpportunity
+		if client.subscription.wants(StreamEventArbitrage) {
+			for _, opportunity := range opportunities {
+				if client.subscription.matchesArbitrage(opportunity) {
+					matched = append(matched, opportunity)
+				}
+			}
+		}
+		client.mu.Unlock()
+
+		if len(matched) == 0 {
+			continue
+		}
+		client.send(StreamMessage{Type: StreamEventArbitrage, Opportunities: matched, Timestamp: timestamp})
+	}
+}
+
+// ClientCount - Πλήθος συνδεδεμένων clients
+func (h *PriceStreamHub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+func (h *PriceStreamHub) register(events, tokens, pools []string) *streamClient {
+	client := &streamClient{
+		subscription: streamSubscription{
+			events: make(map[string]bool),
+			tokens: make(map[string]bool),
+			pools:  make(map[string]bool),
+		},
+		messages: make(chan StreamMessage, streamBufferSize),
+	}
+	client.subscription.update(events, tokens, pools, true)
+
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+	return client
+}
+
+func (h *PriceStreamHub) unregister(client *streamClient) {
+	h.mu.Lock()
+	delete(h.clients, client)
+	h.mu.Unlock()
+}
+
+// newStreamUpgrader - Τα WebSocket του /api/stream δέχονται μόνο το ίδιο origin και όσα
+// έχουν οριστεί με SetStreamAllowedOrigins
+func (s *HTTPServer) newStreamUpgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     s.checkStreamOrigin,
+	}
+}
+
+// checkStreamOrigin - Requests χωρίς Origin (όχι από browser) περνούν· αλλιώς το Origin πρέπει
+// να έχει τον ίδιο host με το request ή να είναι στη λίστα ("*" = όλα)
+func (s *HTTPServer) checkStreamOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+
+	s.streamMu.RLock()
+	defer s.streamMu.RUnlock()
+	return s.streamOrigins["*"] || s.streamOrigins[strings.ToLower(strings.TrimSuffix(origin, "/"))]
+}
+
+// SetStreamAllowedOrigins - Origins (π.χ. "https://app.example.com") που δέχεται το WebSocket
+// του /api/stream εκτός από το ίδιο origin
+func (s *HTTPServer) SetStreamAllowedOrigins(origins []string) {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+		}
+	}
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	s.streamOrigins = allowed
+}
+
+// handleStream - /api/stream?tokens=OSMO,ATOM&pools=1,1135&events=pool_prices,arbitrage
+// WebSocket αν το request ζητά upgrade, αλλιώς Server-Sent Events.
+func (s *HTTPServer) handleStream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	events := splitStreamParam(query.Get("events"))
+	tokens := splitStreamParam(query.Get("tokens"))
+	pools := splitStreamParam(query.Get("pools"))
+
+	if websocket.IsWebSocketUpgrade(r) {
+		s.serveWebSocketStream(w, r, events, tokens, pools)
+		return
+	}
+	s.serveSSEStream(w, r, events, tokens, pools)
+}
+
+// serveSSEStream - text/event-stream με ένα event ανά ενημέρωση ("pool_prices" ή "arbitrage")
+func (s *HTTPServer) serveSSEStream(w http.ResponseWriter, r *http.Request, events, tokens, pools []string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	client := s.streamHub.register(events, tokens, pools)
+	defer s.streamHub.unregister(client)
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case message := <-client.messages:
+			data, err := json.Marshal(message)
+			if err != nil {
+				log.Printf("⚠️  Stream encode error: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.streamHub.done:
+			return
+		}
+	}
+}
+
+// streamCommand - Μήνυμα client σε WebSocket: {"action":"subscribe","tokens":["OSMO"],"pools":["1"],"events":["arbitrage"]}
+type streamCommand struct {
+	Action string   `json:"action"` // subscribe | unsubscribe
+	Events []string `json:"events"`
+	Tokens []string `json:"tokens"`
+	Pools  []string `json:"pools"`
+}
+
+// serveWebSocketStream - Ένας writer (αυτό το goroutine) και ένας reader για τα subscribe/unsubscribe
+func (s *HTTPServer) serveWebSocketStream(w http.ResponseWriter, r *http.Request, events, tokens, pools []string) {
+	conn, err := s.newStreamUpgrader().Upgrade(w, r, nil)
+	if err != nil {
+		return // Το Upgrade έχει ήδη απαντήσει με σφάλμα
+	}
+	defer conn.Close()
+
+	client := s.streamHub.register(events, tokens, pools)
+	defer s.streamHub.unregister(client)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetReadDeadline(time.Now().Add(2 * streamHeartbeat))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * streamHeartbeat))
+		})
+
+		for {
+			var command streamCommand
+			if err := conn.ReadJSON(&command); err != nil {
+				return
+			}
+			conn.SetReadDeadline(time.Now().Add(2 * streamHeartbeat))
+
+			switch command.Action {
+			case "subscribe", "unsubscribe":
+				client.mu.Lock()
+				client.subscription.update(command.Events, command.Tokens, command.Pools, command.Action == "subscribe")
+				client.mu.Unlock()
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case message := <-client.messages:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-s.streamHub.done:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutdown"),
+				time.Now().Add(streamWriteTimeout))
+			return
+		}
+	}
+}
+
+// splitStreamParam - "OSMO, ATOM" -> ["OSMO", "ATOM"]
+func splitStreamParam(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
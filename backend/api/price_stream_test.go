@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCheckStreamOrigin(t *testing.T) {
+	server := NewHTTPServer(0, nil, nil)
+	server.SetStreamAllowedOrigins([]string{"https://App.Example.com/", " "})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"", true}, // Όχι browser
+		{"http://localhost:8080", true},
+		{"https://app.example.com", true},
+		{"https://evil.example.com", false},
+		{"http://localhost:9090", false},
+		{"null", false},
+	}
+
+	for _, tt := range tests {
+		request := httptest.NewRequest("GET", "http://localhost:8080/api/stream", nil)
+		if tt.origin != "" {
+			request.Header.Set("Origin", tt.origin)
+		}
+		if got := server.checkStreamOrigin(request); got != tt.want {
+			t.Errorf("origin %q: allowed = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+
+	server.SetStreamAllowedOrigins([]string{"*"})
+	request := httptest.NewRequest("GET", "http://localhost:8080/api/stream", nil)
+	request.Header.Set("Origin", "https://evil.example.com")
+	if !server.checkStreamOrigin(request) {
+		t.Error(`"*" does not allow every origin`)
+	}
+}
+
+func TestStreamWebSocketRejectsCrossOrigin(t *testing.T) {
+	server := NewHTTPServer(0, nil, nil)
+	defer server.streamHub.Close()
+	ts := httptest.NewServer(http.HandlerFunc(server.handleStream))
+	defer ts.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+	if conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header); err == nil {
+		conn.Close()
+		t.Fatal("cross-origin WebSocket accepted")
+	} else if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("cross-origin dial: %v, want 403", err)
+	}
+
+	header = http.Header{"Origin": []string{ts.URL}}
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("same-origin dial: %v", err)
+	}
+	conn.Close()
+}
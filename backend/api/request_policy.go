@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// maxEndpointAttempts - Σε πόσα διαφορετικά endpoints δοκιμάζεται κάθε γύρος ενός request
+const maxEndpointAttempts = 3
+
+// RetryPolicy - Επαναλήψεις για idempotent κλήσεις (GET)
+type RetryPolicy struct {
+	MaxRetries    int           // Επιπλέον γύροι μετά τον πρώτο
+	BaseDelay     time.Duration // Αρχική καθυστέρηση, διπλασιάζεται ανά γύρο
+	MaxDelay      time.Duration // Ανώτατη καθυστέρηση backoff
+	MaxRetryAfter time.Duration // Ανώτατη αναμονή που δεχόμαστε από Retry-After
+}
+
+// DefaultRetryPolicy - Σύντομες επαναλήψεις ώστε να χωράνε στον κύκλο ανανέωσης
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:    2,
+		BaseDelay:     250 * time.Millisecond,
+		MaxDelay:      4 * time.Second,
+		MaxRetryAfter: 10 * time.Second,
+	}
+}
+
+// backoff - Εκθετικό backoff με full jitter: τυχαίο διάστημα στο [0, min(MaxDelay, Base·2ⁿ)]
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	ceiling := p.BaseDelay << retry
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// get εκτελεί ένα GET (idempotent: με retries)
+func (c *LCDClient) get(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, path, nil)
+}
+
+// post εκτελεί ένα POST (JSON, χωρίς retries)
+func (c *LCDClient) post(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, path, body)
+}
+
+// do - Το κοινό middleware όλων των κλήσεων: circuit breaker, failover μεταξύ endpoints,
+// retries με jittered backoff (μόνο GET) και σεβασμός του Retry-After. Ένα 429 δεν
+// μετράει ως αποτυχία στον breaker. Επιστρέφει μόνο απαντήσεις 200· κάθε άλλη έκβαση
+// είναι *UpstreamError, ErrCircuitOpen ή το σφάλμα του ctx (ακύρωση/λήξη), που δεν
+// χρεώνεται ούτε στους nodes ούτε στον breaker.
+func (c *LCDClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	retries := 0
+	if method == http.MethodGet {
+		retries = c.retry.MaxRetries
+	}
+
+	var lastErr *UpstreamError
+	for round := 0; round <= retries; round++ {
+		if round > 0 {
+			delay := c.retry.backoff(round - 1)
+			if lastErr.Kind == ErrRateLimited && lastErr.RetryAfter > delay {
+				if lastErr.RetryAfter > c.retry.MaxRetryAfter {
+					break // Δεν περιμένουμε τόσο· ο caller θα δει ErrRateLimited
+				}
+				delay = lastErr.RetryAfter
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+
+		if !c.breaker.Allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		resp, err := c.tryEndpoints(ctx, method, path, body)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			c.breaker.Release()
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctxErr
+		}
+		if err == nil {
+			c.breaker.Success()
+			return resp, nil
+		}
+
+		lastErr = err
+		if !err.retryable() {
+			// Ο node απάντησε κανονικά (π.χ. 404): δεν είναι αποτυχία upstream
+			c.breaker.Success()
+			return nil, err
+		}
+		if err.Kind == ErrRateLimited {
+			// Ο node λειτουργεί αλλά μας περιορίζει: δεν ανοίγουμε τον breaker,
+			// ο επόμενος γύρος περιμένει το Retry-After
+			c.breaker.Release()
+			continue
+		}
+		c.breaker.Failure()
+	}
+
+	return nil, lastErr
+}
+
+// tryEndpoints - Ένας γύρος: δοκιμάζει έως maxEndpointAttempts endpoints. Network errors,
+// 5xx και 429 μετράνε στο score του node και οδηγούν στο επόμενο.
+func (c *LCDClient) tryEndpoints(ctx context.Context, method, path string, body []byte) (*http.Response, *UpstreamError) {
+	tried := make(map[string]bool)
+	var lastErr *UpstreamError
+
+	for attempt := 0; attempt < maxEndpointAttempts; attempt++ {
+		endpoint := c.endpoints.Next(tried)
+		if endpoint == "" {
+			break
+		}
+		tried[endpoint] = true
+
+		req, err := http.NewRequestWithContext(ctx, method, endpoint+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, &UpstreamError{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		latency := time.Since(start)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				// Ακύρωση από τον caller, όχι αποτυχία του node
+				return nil, &UpstreamError{Kind: ErrUpstream, Endpoint: endpoint, Err: ctx.Err()}
+			}
+			lastErr = &UpstreamError{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+			c.endpoints.ReportFailure(endpoint, latency, err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			c.endpoints.ReportSuccess(endpoint, latency)
+			return resp, nil
+		}
+
+		statusErr := newStatusError(endpoint, resp)
+		resp.Body.Close()
+
+		if !statusErr.retryable() {
+			// 4xx: ο node λειτουργεί, το request είναι αυτό που δεν ικανοποιείται
+			c.endpoints.ReportSuccess(endpoint, latency)
+			return nil, statusErr
+		}
+
+		lastErr = statusErr
+		c.endpoints.ReportFailure(endpoint, latency, statusErr)
+	}
+
+	if lastErr == nil {
+		lastErr = &UpstreamError{Kind: ErrUpstream, Err: errors.New("δεν υπάρχουν διαθέσιμα endpoints")}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// statusServer - LCD που απαντά με τη σειρά τα statuses (το τελευταίο επαναλαμβάνεται)
+func statusServer(t *testing.T, retryAfter string, statuses ...int) (*httptest.Server, *atomic.Int64) {
+	requests := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1)) - 1
+		status := statuses[min(n, len(statuses)-1)]
+		if status == http.StatusTooManyRequests && retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestLCDClientTypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		kind     error // nil = επιτυχία
+		requests int64
+	}{
+		{"ok", http.MethodGet, []int{200}, nil, 1},
+		{"retry 5xx", http.MethodGet, []int{500, 502, 200}, nil, 3},
+		{"5xx exhausts retries", http.MethodGet, []int{503}, ErrUpstream, 3},
+		{"404 is not retried", http.MethodGet, []int{404}, ErrNotFound, 1},
+		{"400 is not retried", http.MethodGet, []int{400}, ErrUpstream, 1},
+		{"429 exhausts retries", http.MethodGet, []int{429}, ErrRateLimited, 3},
+		{"post is not retried", http.MethodPost, []int{500, 200}, ErrUpstream, 1},
+	}
+	for _, tt := range tests {
+		server, requests := statusServer(t, "", tt.statuses...)
+		client := testLCDClient(server.URL)
+
+		resp, err := client.do(context.Background(), tt.method, "/test", nil)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if tt.kind == nil && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if tt.kind != nil && !errors.Is(err, tt.kind) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.kind)
+		}
+		if got := requests.Load(); got != tt.requests {
+			t.Errorf("%s: %d requests, want %d", tt.name, got, tt.requests)
+		}
+		if state := client.breaker.Status().State; state != CircuitClosed {
+			t.Errorf("%s: breaker %s after one call", tt.name, state)
+		}
+	}
+}
+
+func TestLCDClientUpstreamErrorDetails(t *testing.T) {
+	server, _ := statusServer(t, "", http.StatusBadGateway)
+	client := testLCDClient(server.URL)
+	client.retry.MaxRetries = 0
+
+	_, err := client.get(context.Background(), "/test")
+	var upstream *UpstreamError
+	if !errors.As(err, &upstream) {
+		t.Fatalf("error %T %v, want *UpstreamError", err, err)
+	}
+	if upstream.StatusCode != http.StatusBadGateway || upstream.Endpoint != server.URL {
+		t.Errorf("status %d from %s, want 502 from %s", upstream.StatusCode, upstream.Endpoint, server.URL)
+	}
+
+	// Network error: κανένα status code
+	server.Close()
+	_, err = client.get(context.Background(), "/test")
+	if !errors.As(err, &upstream) || !errors.Is(err, ErrUpstream) || upstream.StatusCode != 0 {
+		t.Errorf("closed server: %v, want ErrUpstream without status", err)
+	}
+}
+
+func TestLCDClientFailover(t *testing.T) {
+	failing, failingRequests := statusServer(t, "", http.StatusInternalServerError)
+	healthy, healthyRequests := statusServer(t, "", http.StatusOK)
+	client := testLCDClient(failing.URL, healthy.URL)
+	client.retry.MaxRetries = 0
+
+	// Στον ίδιο γύρο το request περνά στον επόμενο node, όποιος κι αν δοκιμαστεί πρώτος
+	for i := 0; i < 4; i++ {
+		resp, err := client.get(context.Background(), "/test")
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+	if healthyRequests.Load() != 4 {
+		t.Errorf("healthy node got %d requests, want 4", healthyRequests.Load())
+	}
+	if failingRequests.Load() == 0 {
+		t.Error("failing node was never tried")
+	}
+}
+
+func TestLCDClientHonoursRetryAfter(t *testing.T) {
+	server, requests := statusServer(t, "1", http.StatusTooManyRequests, http.StatusOK)
+	client := testLCDClient(server.URL)
+
+	start := time.Now()
+	resp, err := client.get(context.Background(), "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", elapsed)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("%d requests, want 2", requests.Load())
+	}
+}
+
+func TestLCDClientRetryAfterTooLong(t *testing.T) {
+	server, requests := statusServer(t, "60", http.StatusTooManyRequests, http.StatusOK)
+	client := testLCDClient(server.URL)
+
+	start := time.Now()
+	_, err := client.get(context.Background(), "/test")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("error %v, want ErrRateLimited", err)
+	}
+	var upstream *UpstreamError
+	if !errors.As(err, &upstream) || upstream.RetryAfter != time.Minute {
+		t.Errorf("error %+v, want RetryAfter 1m", err)
+	}
+	// Πάνω από το MaxRetryAfter δεν περιμένουμε: ο caller παίρνει αμέσως το σφάλμα
+	if elapsed := time.Since(start); elapsed > time.Second || requests.Load() != 1 {
+		t.Errorf("%d requests in %v, want 1 without waiting", requests.Load(), elapsed)
+	}
+}
+
+func TestLCDClientRateLimitKeepsBreakerClosed(t *testing.T) {
+	server, _ := statusServer(t, "", http.StatusTooManyRequests)
+	client := testLCDClient(server.URL)
+
+	for i := 0; i < 3*client.breaker.threshold; i++ {
+		if _, err := client.get(context.Background(), "/test"); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("call %d: %v, want ErrRateLimited", i, err)
+		}
+	}
+	if status := client.breaker.Status(); status.State != CircuitClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("breaker %s with %d failures after 429s, want closed", status.State, status.ConsecutiveFailures)
+	}
+}
+
+func TestLCDClientOpensBreaker(t *testing.T) {
+	server, requests := statusServer(t, "", http.StatusInternalServerError)
+	client := testLCDClient(server.URL)
+	client.retry.MaxRetries = 0
+
+	for i := 0; i < client.breaker.threshold; i++ {
+		if _, err := client.get(context.Background(), "/test"); !errors.Is(err, ErrUpstream) {
+			t.Fatalf("call %d: %v, want ErrUpstream", i, err)
+		}
+	}
+	sent := requests.Load()
+
+	if _, err := client.get(context.Background(), "/test"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("error %v, want ErrCircuitOpen", err)
+	}
+	if requests.Load() != sent {
+		t.Error("request sent while the breaker is open")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{"", 0, 0},
+		{"5", 5 * time.Second, 5 * time.Second},
+		{"0", 0, 0},
+		{"-3", 0, 0},
+		{"soon", 0, 0},
+		{time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat), 28 * time.Second, 30 * time.Second},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v..%v", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for retry := 0; retry < 6; retry++ {
+		ceiling := min(policy.BaseDelay<<retry, policy.MaxDelay)
+		for i := 0; i < 50; i++ {
+			if delay := policy.backoff(retry); delay < 0 || delay > ceiling {
+				t.Fatalf("backoff(%d) = %v, want 0..%v", retry, delay, ceiling)
+			}
+		}
+	}
+	if delay := (RetryPolicy{}).backoff(3); delay != 0 {
+		t.Errorf("zero policy backoff = %v, want 0", delay)
+	}
+}
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// DefaultSlippageTolerance - 0.5%, όταν το request δεν ορίζει slippage
+const DefaultSlippageTolerance = 0.005
+
+// RouterConfig - Παράμετροι του SwapRouter
+type RouterConfig struct {
+	MaxHops         int     // Μέγιστο μήκος διαδρομής
+	MaxRoutes       int     // Μέγιστο πλήθος παράλληλων διαδρομών σε ένα split
+	MaxCandidates   int     // Διαδρομές (οι καλύτερες για το πλήρες ποσό) που μπαίνουν στο split
+	MaxBranching    int     // Ενδιάμεσα βήματα ανά token στην αναζήτηση, τα πιο liquid πρώτα
+	SplitSteps      int     // Το ποσό μοιράζεται σε τόσα ίσα κομμάτια
+	MinLiquidityUSD float64 // Pools με μικρότερη TVL αγνοούνται (0 = όλα)
+}
+
+// DefaultRouterConfig - Διαδρομές έως 3 pools, split σε έως 3 διαδρομές με βήμα 5%
+func DefaultRouterConfig() RouterConfig {
+	return RouterConfig{
+		MaxHops:       3,
+		MaxRoutes:     3,
+		MaxCandidates: 10,
+		MaxBranching:  8,
+		SplitSteps:    20,
+	}
+}
+
+// routeEdge - Μια κατεύθυνση ενός pool στον γράφο του router
+type routeEdge struct {
+	poolID    string // On-chain pool
+	denomIn   string
+	denomOut  string
+	symbolIn  string
+	symbolOut string
+	liquidity float64
+}
+
+// routeHopResult - Το αποτέλεσμα της προσομοίωσης ενός hop
+type routeHopResult struct {
+	edge routeEdge
+	pool *types.OsmosisPool
+	swap *types.SimulateSwapResponse
+}
+
+// SwapRouter - Smart order routing πάνω στα pools της cache: βρίσκει διαδρομές έως MaxHops
+// pools και μοιράζει το ποσό σε παράλληλες διαδρομές ώστε να μειωθεί το price impact.
+// Κάθε swap προσομοιώνεται με το μοντέλο του pool του (weighted, stableswap, ...).
+type SwapRouter struct {
+	config     RouterConfig
+	poolPrices []types.PoolPrice
+	getPool    func(poolID string) (*types.OsmosisPool, error)
+	priceModel func(types.OsmosisPool) PriceModel
+	pools      map[string]*types.OsmosisPool // Cache των getPool για ένα request
+}
+
+// NewSwapRouter - poolPrices μιας αλυσίδας· το getPool δίνει τα reserves κάθε pool
+// (π.χ. PriceStorage.GetPool). Μηδενικά πεδία του config παίρνουν τις default τιμές.
+func NewSwapRouter(config RouterConfig, poolPrices []types.PoolPrice, getPool func(poolID string) (*types.OsmosisPool, error)) *SwapRouter {
+	defaults := DefaultRouterConfig()
+	if config.MaxHops <= 0 {
+		config.MaxHops = defaults.MaxHops
+	}
+	if config.MaxRoutes <= 0 {
+		config.MaxRoutes = defaults.MaxRoutes
+	}
+	if config.MaxCandidates <= 0 {
+		config.MaxCandidates = defaults.MaxCandidates
+	}
+	if config.MaxBranching <= 0 {
+		config.MaxBranching = defaults.MaxBranching
+	}
+	if config.SplitSteps <= 0 {
+		config.SplitSteps = defaults.SplitSteps
+	}
+
+	return &SwapRouter{
+		config:     config,
+		poolPrices: poolPrices,
+		getPool:    getPool,
+		priceModel: PriceModelForPool,
+		pools:      make(map[string]*types.OsmosisPool),
+	}
+}
+
+// ResolveDenom - Denom όπως δόθηκε αν υπάρχει σε κάποιο pool, αλλιώς το denom του symbol
+// στο πιο liquid pool που το περιέχει
+func (r *SwapRouter) ResolveDenom(token string) string {
+	token = strings.TrimSpace(token)
+
+	var denom string
+	var bestLiquidity float64
+	for _, price := range r.poolPrices {
+		if price.Token0Denom == token || price.Token1Denom == token {
+			return token
+		}
+		for _, side := range [][2]string{{price.Token0Symbol, price.Token0Denom}, {price.Token1Symbol, price.Token1Denom}} {
+			if strings.EqualFold(side[0], token) && (denom == "" || price.LiquidityUSD > bestLiquidity) {
+				denom, bestLiquidity = side[1], price.LiquidityUSD
+			}
+		}
+	}
+
+	if denom == "" {
+		return token
+	}
+	return denom
+}
+
+// Route - Η καλύτερη διανομή του tokenIn (base units, denom ή symbol) σε διαδρομές προς το
+// tokenOut. Το MinAmountOut είναι το αναμενόμενο output μείον το slippage tolerance.
+func (r *SwapRouter) Route(tokenIn types.BasicCoin, tokenOut string, slippage float64) (*types.OsmosisRouteResponse, error) {
+	tokenIn.Denom = r.ResolveDenom(tokenIn.Denom)
+	tokenOut = r.ResolveDenom(tokenOut)
+	if tokenIn.Denom == tokenOut {
+		return nil, fmt.Errorf("token in και token out είναι ίδια: %s", tokenOut)
+	}
+	if slippage < 0 || slippage >= 1 {
+		return nil, fmt.Errorf("μη έγκυρο slippage tolerance: %v", slippage)
+	}
+	amountIn, ok := new(big.Int).SetString(strings.TrimSpace(tokenIn.Amount), 10)
+	if !ok || amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("μη έγκυρη ποσότητα εισόδου: %s", tokenIn.Amount)
+	}
+	tokenIn.Amount = amountIn.String()
+
+	paths := r.candidatePaths(tokenIn.Denom, tokenOut)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: δεν βρέθηκε διαδρομή από %s προς %s", ErrNotFound, tokenIn.Denom, tokenOut)
+	}
+
+	// Οι καλύτερες διαδρομές για ολόκληρο το ποσό μπαίνουν στο split
+	type candidate struct {
+		path []routeEdge
+		out  *big.Int
+	}
+	var candidates []candidate
+	for _, path := range paths {
+		if _, out, err := r.simulatePath(path, amountIn); err == nil && out.Sign() > 0 {
+			candidates = append(candidates, candidate{path: path, out: out})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: καμία διαδρομή από %s προς %s δεν δίνει output", ErrNotFound, tokenIn.Denom, tokenOut)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].out.Cmp(candidates[j].out) > 0
+	})
+	if len(candidates) > r.config.MaxCandidates {
+		candidates = candidates[:r.config.MaxCandidates]
+	}
+
+	paths = make([][]routeEdge, len(candidates))
+	for i, c := range candidates {
+		paths[i] = c.path
+	}
+	allocations, total := r.splitAmount(paths, amountIn)
+
+	single := candidates[0].out
+	if total.Cmp(single) < 0 {
+		// Το split δεν βελτίωσε την καλύτερη διαδρομή (π.χ. λόγω στρογγυλοποιήσεων)
+		allocations = make([]*big.Int, len(paths))
+		allocations[0] = amountIn
+	}
+
+	return r.buildRouteResponse(tokenIn, tokenOut, slippage, paths, allocations, single)
+}
+
+// candidatePaths - Απλές διαδρομές (χωρίς επανάληψη token ή pool) έως MaxHops. Σε κάθε
+// token εξετάζονται όλα τα pools προς το tokenOut και τα MaxBranching πιο liquid προς
+// ενδιάμεσα tokens.
+func (r *SwapRouter) candidatePaths(denomIn, denomOut string) [][]routeEdge {
+	graph := make(map[string][]routeEdge)
+	for _, price := range r.poolPrices {
+		if price.LiquidityUSD < r.config.MinLiquidityUSD || price.Token0Denom == price.Token1Denom {
+			continue
+		}
+		poolID := price.OnChainPoolID()
+		graph[price.Token0Denom] = append(graph[price.Token0Denom], routeEdge{
+			poolID: poolID, denomIn: price.Token0Denom, denomOut: price.Token1Denom,
+			symbolIn: price.Token0Symbol, symbolOut: price.Token1Symbol, liquidity: price.LiquidityUSD,
+		})
+		graph[price.Token1Denom] = append(graph[price.Token1Denom], routeEdge{
+			poolID: poolID, denomIn: price.Token1Denom, denomOut: price.Token0Denom,
+			symbolIn: price.Token1Symbol, symbolOut: price.Token0Symbol, liquidity: price.LiquidityUSD,
+		})
+	}
+	for denom := range graph {
+		edges := graph[denom]
+		sort.SliceStable(edges, func(i, j int) bool { return edges[i].liquidity > edges[j].liquidity })
+	}
+
+	var paths [][]routeEdge
+	visited := map[string]bool{denomIn: true}
+	usedPools := make(map[string]bool)
+
+	var walk func(denom string, path []routeEdge)
+	walk = func(denom string, path []routeEdge) {
+		branches := 0
+		for _, edge := range graph[denom] {
+			if usedPools[edge.poolID] {
+				continue
+			}
+			if edge.denomOut == denomOut {
+				paths = append(paths, append(append([]routeEdge(nil), path...), edge))
+				continue
+			}
+			if len(path)+2 > r.config.MaxHops || visited[edge.denomOut] || branches >= r.config.MaxBranching {
+				continue
+			}
+			branches++
+
+			visited[edge.denomOut], usedPools[edge.poolID] = true, true
+			walk(edge.denomOut, append(path, edge))
+			delete(visited, edge.denomOut)
+			delete(usedPools, edge.poolID)
+		}
+	}
+	walk(denomIn, nil)
+
+	return paths
+}
+
+// pool - Τα reserves ενός pool, μία φορά ανά request
+func (r *SwapRouter) pool(poolID string) (*types.OsmosisPool, error) {
+	if pool, ok := r.pools[poolID]; ok {
+		return pool, nil
+	}
+	pool, err := r.getPool(poolID)
+	if err != nil {
+		return nil, err
+	}
+	r.pools[poolID] = pool
+	return pool, nil
+}
+
+// simulatePath - Διαδοχικά swaps του amountIn μέσα από τα pools της διαδρομής
+func (r *SwapRouter) simulatePath(path []routeEdge, amountIn *big.Int) ([]routeHopResult, *big.Int, error) {
+	results := make([]routeHopResult, len(path))
+	amount := amountIn
+
+	for i, edge := range path {
+		pool, err := r.pool(edge.poolID)
+		if err != nil {
+			return nil, nil, err
+		}
+		swap, err := r.priceModel(*pool).SimulateSwap(*pool, types.BasicCoin{Denom: edge.denomIn, Amount: amount.String()}, edge.denomOut)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, ok := new(big.Int).SetString(swap.TokenOut.Amount, 10)
+		if !ok || out.Sign() <= 0 {
+			return nil, nil, fmt.Errorf("μηδενικό output στο pool %s", edge.poolID)
+		}
+
+		results[i] = routeHopResult{edge: edge, pool: pool, swap: swap}
+		amount = out
+	}
+
+	return results, amount, nil
+}
+
+// splitAmount - Το ποσό μοιράζεται σε SplitSteps κομμάτια· κάθε κομμάτι πηγαίνει στη
+// διαδρομή με το μεγαλύτερο οριακό output. Οι διαδρομές του split δεν μοιράζονται pools,
+// ώστε η προσομοίωση της καθεμιάς να μην επηρεάζεται από τις άλλες.
+func (r *SwapRouter) splitAmount(paths [][]routeEdge, amountIn *big.Int) ([]*big.Int, *big.Int) {
+	allocations := make([]*big.Int, len(paths))
+	outputs := make([]*big.Int, len(paths))
+	for i := range paths {
+		allocations[i], outputs[i] = new(big.Int), new(big.Int)
+	}
+	usedPools := make(map[string]bool)
+	active := 0
+
+	steps := big.NewInt(int64(r.config.SplitSteps))
+	chunk := new(big.Int).Quo(amountIn, steps)
+	remaining := new(big.Int).Set(amountIn)
+
+	for remaining.Sign() > 0 {
+		size := chunk
+		if size.Sign() == 0 || remaining.Cmp(new(big.Int).Mul(chunk, big.NewInt(2))) < 0 {
+			size = remaining // Το τελευταίο κομμάτι παίρνει και το υπόλοιπο της διαίρεσης
+		}
+
+		best := -1
+		var bestGain, bestOut *big.Int
+		for i, path := range paths {
+			if allocations[i].Sign() == 0 && (active >= r.config.MaxRoutes || sharesPool(path, usedPools)) {
+				continue
+			}
+			_, out, err := r.simulatePath(path, new(big.Int).Add(allocations[i], size))
+			if err != nil {
+				continue
+			}
+			if gain := new(big.Int).Sub(out, outputs[i]); bestGain == nil || gain.Cmp(bestGain) > 0 {
+				best, bestGain, bestOut = i, gain, out
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		if allocations[best].Sign() == 0 {
+			active++
+			for _, edge := range paths[best] {
+				usedPools[edge.poolID] = true
+			}
+		}
+		allocations[best].Add(allocations[best], size)
+		outputs[best] = bestOut
+		remaining.Sub(remaining, size)
+	}
+
+	total := new(big.Int)
+	if remaining.Sign() > 0 {
+		return allocations, total // Κάποιο κομμάτι δεν χωρά πουθενά: κρατάμε τη μία διαδρομή
+	}
+	for _, out := range outputs {
+		total.Add(total, out)
+	}
+	return allocations, total
+}
+
+// sharesPool - Περνά η διαδρομή από pool που χρησιμοποιεί ήδη άλλη διαδρομή του split;
+func sharesPool(path []routeEdge, usedPools map[string]bool) bool {
+	for _, edge := range path {
+		if usedPools[edge.poolID] {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRouteResponse - Προσομοίωση κάθε διαδρομής με το ποσό της και σύνοψη του swap
+func (r *SwapRouter) buildRouteResponse(tokenIn types.BasicCoin, tokenOut string, slippage float64, paths [][]routeEdge, allocations []*big.Int, single *big.Int) (*types.OsmosisRouteResponse, error) {
+	amountIn := decimal.MustParse(tokenIn.Amount)
+	total := new(big.Int)
+	var expected float64 // Output στις spot τιμές μετά τα fees, για το συνολικό price impact
+
+	routes := []types.OsmosisSplitRoute{}
+	for i, path := range paths {
+		allocation := allocations[i]
+		if allocation == nil || allocation.Sign() == 0 {
+			continue
+		}
+		results, out, err := r.simulatePath(path, allocation)
+		if err != nil {
+			return nil, err
+		}
+
+		spotOut, _ := new(big.Float).SetInt(allocation).Float64()
+		hops := make([]types.OsmosisRouteHop, len(results))
+		for h, result := range results {
+			swapFee := parseSwapFee(*result.pool).Float64()
+			spotOut *= result.swap.SpotPrice * (1 - swapFee)
+			hops[h] = types.OsmosisRouteHop{
+				OsmosisSwapRoute: types.OsmosisSwapRoute{
+					PoolId:        result.edge.poolID,
+					TokenInDenom:  result.edge.denomIn,
+					TokenOutDenom: result.edge.denomOut,
+				},
+				PoolType:       result.pool.PoolType(),
+				TokenInSymbol:  result.edge.symbolIn,
+				TokenOutSymbol: result.edge.symbolOut,
+				AmountIn:       result.swap.TokenIn.Amount,
+				AmountOut:      result.swap.TokenOut.Amount,
+				Fee:            result.swap.Fee.Amount,
+				SwapFee:        swapFee,
+				SpotPrice:      result.swap.SpotPrice,
+				PriceImpact:    result.swap.PriceImpact,
+			}
+		}
+		expected += spotOut
+		total.Add(total, out)
+
+		routes = append(routes, types.OsmosisSplitRoute{
+			Hops:      hops,
+			Share:     decimal.NewFromBigInt(allocation).Quo(amountIn).Float64(),
+			AmountIn:  allocation.String(),
+			AmountOut: out.String(),
+		})
+	}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Share > routes[j].Share })
+
+	tolerance, err := decimal.NewFromFloat(slippage)
+	if err != nil {
+		return nil, err
+	}
+	totalDec := decimal.NewFromBigInt(total)
+	minOut := totalDec.Mul(decimal.One().Sub(tolerance)).FloorInt()
+
+	var priceImpact float64
+	if expected > 0 {
+		received, _ := new(big.Float).SetInt(total).Float64()
+		priceImpact = 1 - received/expected
+	}
+
+	return &types.OsmosisRouteResponse{
+		Routes:               routes,
+		TokenIn:              tokenIn,
+		TokenOutDenom:        tokenOut,
+		AmountOut:            total.String(),
+		MinAmountOut:         minOut.String(),
+		SlippageTolerance:    slippage,
+		EffectivePrice:       totalDec.Quo(amountIn).Float64(),
+		PriceImpact:          priceImpact,
+		SingleRouteAmountOut: single.String(),
+	}, nil
+}
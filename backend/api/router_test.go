@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// routerTestRouter - SwapRouter πάνω σε pools στη μνήμη: δύο παράλληλα ATOM/OSMO pools
+// ίδιου βάθους και δύο διαδρομές μέσω USDC που μοιράζονται το ATOM/USDC pool "3"
+func routerTestRouter(config RouterConfig) *SwapRouter {
+	const weight = "1000000"
+	pools := map[string]types.OsmosisPool{}
+	for _, pool := range []types.OsmosisPool{
+		weightedTestPool("1", "0.002", [3]string{"uatom", "1000000000", weight}, [3]string{"uosmo", "10000000000", weight}),
+		weightedTestPool("2", "0.003", [3]string{"uatom", "1000000000", weight}, [3]string{"uosmo", "10000000000", weight}),
+		weightedTestPool("3", "0.002", [3]string{"uatom", "2000000000", weight}, [3]string{"uusdc", "20000000000", weight}),
+		weightedTestPool("4", "0.002", [3]string{"uusdc", "10000000000", weight}, [3]string{"uosmo", "10000000000", weight}),
+		weightedTestPool("5", "0.002", [3]string{"uusdc", "10000000000", weight}, [3]string{"uosmo", "10000000000", weight}),
+	} {
+		pools[pool.Id] = pool
+	}
+
+	var prices []types.PoolPrice
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		prices = append(prices, reservePoolPrice(pools[id]))
+	}
+
+	return NewSwapRouter(config, prices, func(poolID string) (*types.OsmosisPool, error) {
+		pool, ok := pools[poolID]
+		if !ok {
+			return nil, fmt.Errorf("%w: pool %s", ErrNotFound, poolID)
+		}
+		return &pool, nil
+	})
+}
+
+func TestRouteSplitBeatsSingleRoute(t *testing.T) {
+	// 10% του reserve ATOM: το price impact κάνει το split συμφέρον
+	route, err := routerTestRouter(RouterConfig{}).Route(types.BasicCoin{Denom: "uatom", Amount: "100000000"}, "uosmo", DefaultSlippageTolerance)
+	if err != nil {
+		t.Fatalf("Route: %v", err)
+	}
+
+	if len(route.Routes) < 2 {
+		t.Fatalf("got %d routes, want a split: %+v", len(route.Routes), route.Routes)
+	}
+	out, _ := new(big.Int).SetString(route.AmountOut, 10)
+	single, _ := new(big.Int).SetString(route.SingleRouteAmountOut, 10)
+	if out.Cmp(single) <= 0 {
+		t.Errorf("split output %s does not beat the single route %s", out, single)
+	}
+
+	// Το single route είναι η καλύτερη μεμονωμένη διαδρομή: το pool 1 (χαμηλότερο fee)
+	router := routerTestRouter(RouterConfig{MaxRoutes: 1})
+	best, err := router.Route(types.BasicCoin{Denom: "uatom", Amount: "100000000"}, "uosmo", DefaultSlippageTolerance)
+	if err != nil {
+		t.Fatalf("Route with one route: %v", err)
+	}
+	if len(best.Routes) != 1 || best.AmountOut != route.SingleRouteAmountOut {
+		t.Errorf("MaxRoutes=1 gives %s over %d routes, want %s over 1", best.AmountOut, len(best.Routes), route.SingleRouteAmountOut)
+	}
+
+	total := new(big.Int)
+	for _, r := range route.Routes {
+		amount, _ := new(big.Int).SetString(r.AmountIn, 10)
+		total.Add(total, amount)
+	}
+	if total.String() != route.TokenIn.Amount {
+		t.Errorf("routes spend %s, want %s", total, route.TokenIn.Amount)
+	}
+}
+
+func TestRouteNeverSharesPool(t *testing.T) {
+	// Οι διαδρομές 3 -> 4 και 3 -> 5 είναι καλές και οι δύο, αλλά περνούν από το pool 3
+	for _, amount := range []string{"1000000", "100000000", "500000000"} {
+		route, err := routerTestRouter(RouterConfig{MaxRoutes: 4}).Route(types.BasicCoin{Denom: "ATOM", Amount: amount}, "OSMO", DefaultSlippageTolerance)
+		if err != nil {
+			t.Fatalf("Route %s: %v", amount, err)
+		}
+
+		used := make(map[string]bool)
+		for _, r := range route.Routes {
+			for _, hop := range r.Hops {
+				if used[hop.PoolId] {
+					t.Errorf("amount %s: pool %s is used by more than one route", amount, hop.PoolId)
+				}
+				used[hop.PoolId] = true
+			}
+		}
+	}
+}
+
+func TestRouteMinAmountOut(t *testing.T) {
+	router := routerTestRouter(RouterConfig{})
+	for _, slippage := range []float64{0, 0.003, DefaultSlippageTolerance, 0.1234} {
+		route, err := router.Route(types.BasicCoin{Denom: "uatom", Amount: "123456789"}, "uosmo", slippage)
+		if err != nil {
+			t.Fatalf("Route with slippage %v: %v", slippage, err)
+		}
+
+		// floor(out·(1-slippage)) με ακέραια: out·(10000 - slippage·10000) / 10000
+		out, _ := new(big.Int).SetString(route.AmountOut, 10)
+		keep := big.NewInt(10000 - int64(slippage*10000+0.5))
+		want := new(big.Int).Quo(new(big.Int).Mul(out, keep), big.NewInt(10000))
+		if route.MinAmountOut != want.String() {
+			t.Errorf("slippage %v: MinAmountOut = %s, want %s (amount out %s)", slippage, route.MinAmountOut, want, out)
+		}
+	}
+
+	if _, err := router.Route(types.BasicCoin{Denom: "uatom", Amount: "1000"}, "uosmo", 1); err == nil {
+		t.Error("slippage 1 accepted")
+	}
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"fmt"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// Όρια για τις Newton επαναλήψεις των stableswap invariants
+const stableswapMaxIterations = 255
+
+// stableswapTolerance - Σχετική διαφορά δύο επαναλήψεων κάτω από την οποία σταματάμε
+var stableswapTolerance = decimal.MustParse("0.000000000000000000000000000001")
+
+// StableswapModel - Το CFMM των Osmosis stableswap pools (x/gamm/pool-models/stableswap):
+//
+//	x·y·(x² + y² + w) = k
+//
+// όπου x, y τα reserves των δύο denoms του swap διαιρεμένα με τα scaling factors του pool
+// και w το άθροισμα των τετραγώνων των υπόλοιπων (κλιμακωμένων) reserves.
+type StableswapModel struct{}
+
+// stableswapState - Κλιμακωμένα reserves ενός stableswap pool
+type stableswapState struct {
+	denoms  []string
+	scaled  []decimal.Dec // reserve / scaling factor
+	factors []decimal.Dec
+}
+
+// SpotPrice - Οριακή τιμή του CFMM στο τρέχον σημείο
+func (StableswapModel) SpotPrice(pool types.OsmosisPool, denomIn, denomOut string) (decimal.Dec, error) {
+	state, err := newStableswapState(pool)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	i, j, err := state.indexes(pool.Id, denomIn, denomOut)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return state.spotPrice(i, j), nil
+}
+
+// SimulateSwap - Λύνει το CFMM για το νέο reserve του denomOut, με σταθερό k
+func (StableswapModel) SimulateSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, denomOut string) (*types.SimulateSwapResponse, error) {
+	amountIn, err := parseSwapAmount(tokenIn, denomOut)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := newStableswapState(pool)
+	if err != nil {
+		return nil, err
+	}
+	i, j, err := state.indexes(pool.Id, tokenIn.Denom, denomOut)
+	if err != nil {
+		return nil, err
+	}
+
+	feeAmount := amountIn.Mul(parseSwapFee(pool))
+	scaledIn := amountIn.Sub(feeAmount).Quo(state.factors[i])
+
+	x, y, w := state.scaled[j], state.scaled[i], state.sumSquaresExcept(i, j)
+	newX := solveCFMM(x, y, w, scaledIn)
+	amountOut := x.Sub(newX).Mul(state.factors[j])
+
+	return buildSwapResponse(tokenIn, denomOut, amountIn, feeAmount, amountOut, state.spotPrice(i, j)), nil
+}
+
+// newStableswapState διαβάζει reserves και scaling factors του pool
+func newStableswapState(pool types.OsmosisPool) (*stableswapState, error) {
+	n := len(pool.PoolAssets)
+	if n < 2 {
+		return nil, fmt.Errorf("το stableswap pool %s έχει λιγότερα από 2 assets", pool.Id)
+	}
+
+	state := &stableswapState{
+		denoms:  make([]string, n),
+		scaled:  make([]decimal.Dec, n),
+		factors: make([]decimal.Dec, n),
+	}
+	for k, asset := range pool.PoolAssets {
+		reserve, err := decimal.Parse(asset.Token.Amount)
+		if err != nil || !reserve.IsPositive() {
+			return nil, fmt.Errorf("μη έγκυρο reserve για %s στο pool %s", asset.Token.Denom, pool.Id)
+		}
+
+		factor := decimal.One()
+		if k < len(pool.ScalingFactors) {
+			if f, err := decimal.Parse(pool.ScalingFactors[k]); err == nil && f.IsPositive() {
+				factor = f
+			}
+		}
+
+		state.denoms[k] = asset.Token.Denom
+		state.factors[k] = factor
+		state.scaled[k] = reserve.Quo(factor)
+	}
+
+	return state, nil
+}
+
+// indexes επιστρέφει τις θέσεις των δύο denoms στο pool
+func (s *stableswapState) indexes(poolID, denomIn, denomOut string) (int, int, error) {
+	return denomIndexes(s.denoms, poolID, denomIn, denomOut)
+}
+
+// sumSquaresExcept - w = Σ x_k² για κάθε k εκτός από τα i, j
+func (s *stableswapState) sumSquaresExcept(i, j int) decimal.Dec {
+	w := decimal.Zero()
+	for k, v := range s.scaled {
+		if k != i && k != j {
+			w = w.Add(v.Mul(v))
+		}
+	}
+	return w
+}
+
+// spotPrice - Πόσα j (base units) δίνει 1 i (base units): ο λόγος των μερικών παραγώγων
+// ∂k/∂x_i / ∂k/∂x_j = x_j·(3x_i² + x_j² + w) / (x_i·(x_i² + 3x_j² + w))
+func (s *stableswapState) spotPrice(i, j int) decimal.Dec {
+	three := decimal.NewFromInt(3)
+	xi, xj, w := s.scaled[i], s.scaled[j], s.sumSquaresExcept(i, j)
+	xi2, xj2 := xi.Mul(xi), xj.Mul(xj)
+
+	numerator := xj.Mul(three.Mul(xi2).Add(xj2).Add(w)).Mul(s.factors[j])
+	denominator := xi.Mul(xi2.Add(three.Mul(xj2)).Add(w)).Mul(s.factors[i])
+
+	// Μετατροπή από κλιμακωμένες σε base μονάδες μέσω των factors
+	return numerator.Quo(denominator)
+}
+
+// cfmm - k = x·y·(x² + y² + w)
+func cfmm(x, y, w decimal.Dec) decimal.Dec {
+	return x.Mul(y).Mul(x.Mul(x).Add(y.Mul(y)).Add(w))
+}
+
+// solveCFMM βρίσκει το x' με cfmm(x', y + yIn, w) = cfmm(x, y, w). Ως προς x' η εξίσωση
+// είναι f(x') = y'·x'³ + y'·(y'² + w)·x' - k, αύξουσα και κυρτή για x' > 0, οπότε ο Newton
+// από το x (όπου f > 0) συγκλίνει μονότονα από πάνω: το output x - x' δεν υπερεκτιμάται.
+func solveCFMM(x, y, w, yIn decimal.Dec) decimal.Dec {
+	k := cfmm(x, y, w)
+	newY := y.Add(yIn)
+	linear := newY.Mul(newY.Mul(newY).Add(w))
+	three := decimal.NewFromInt(3)
+
+	newX := x
+	for iter := 0; iter < stableswapMaxIterations; iter++ {
+		prev := newX
+		x2 := newX.Mul(newX)
+		f := newY.Mul(x2).Add(linear).Mul(newX).Sub(k)
+		df := three.Mul(newY).Mul(x2).Add(linear)
+		newX = newX.Sub(f.Quo(df))
+		if converged(newX, prev) {
+			break
+		}
+	}
+	return newX
+}
+
+// denomIndexes επιστρέφει τις θέσεις των δύο denoms στη λίστα του pool
+func denomIndexes(denoms []string, poolID, denomIn, denomOut string) (int, int, error) {
+	i, j := -1, -1
+	for k, denom := range denoms {
+		if denom == denomIn {
+			i = k
+		}
+		if denom == denomOut {
+			j = k
+		}
+	}
+	if i < 0 {
+		return 0, 0, fmt.Errorf("το denom %s δεν υπάρχει στο pool %s", denomIn, poolID)
+	}
+	if j < 0 {
+		return 0, 0, fmt.Errorf("το denom %s δεν υπάρχει στο pool %s", denomOut, poolID)
+	}
+	return i, j, nil
+}
+
+// converged - |a - b| <= tolerance·|a|
+func converged(a, b decimal.Dec) bool {
+	return a.Sub(b).Abs().Cmp(a.Abs().Mul(stableswapTolerance)) <= 0
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// stableswapTestPool - Osmosis stableswap pool με reserves και scaling factors ανά denom
+func stableswapTestPool(swapFee string, assets ...[3]string) types.OsmosisPool {
+	pool := types.OsmosisPool{Type: "/osmosis.gamm.poolmodels.stableswap.v1beta1.Pool", Id: "1212"}
+	pool.PoolParams.SwapFee = swapFee
+	for _, asset := range assets {
+		pool.PoolAssets = append(pool.PoolAssets, types.BasicPoolAsset{
+			Token: types.BasicCoin{Denom: asset[0], Amount: asset[1]},
+		})
+		pool.ScalingFactors = append(pool.ScalingFactors, asset[2])
+	}
+	return pool
+}
+
+func TestSimulateStableswap(t *testing.T) {
+	// Τα αναμενόμενα ποσά λύνουν το x·y·(x² + y² + w) = k με bisection σε 100 ψηφία
+	tests := []struct {
+		name     string
+		pool     types.OsmosisPool
+		tokenIn  types.BasicCoin
+		denomOut string
+		wantOut  string
+		wantFee  string
+	}{
+		{
+			name: "balanced",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "1000000", "1"}),
+			tokenIn:  types.BasicCoin{Denom: "uusdc", Amount: "10000"},
+			denomOut: "uusdt",
+			wantOut:  "9999", // 9999.995
+			wantFee:  "0",
+		},
+		{
+			name: "balanced with fee",
+			pool: stableswapTestPool("0.0005",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "1000000", "1"}),
+			tokenIn:  types.BasicCoin{Denom: "uusdc", Amount: "10000"},
+			denomOut: "uusdt",
+			wantOut:  "9994",
+			wantFee:  "5",
+		},
+		{
+			name: "imbalanced",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "2000000", "1"}),
+			tokenIn:  types.BasicCoin{Denom: "uusdc", Amount: "100000"},
+			denomOut: "uusdt",
+			wantOut:  "105587",
+			wantFee:  "0",
+		},
+		{
+			// 6 και 18 decimals: το scaling factor 10^12 φέρνει τα reserves στην ίδια κλίμακα
+			name: "scaling factors",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"ausdc", "1000000000000000000", "1000000000000"}),
+			tokenIn:  types.BasicCoin{Denom: "uusdc", Amount: "10000"},
+			denomOut: "ausdc",
+			wantOut:  "9999995000005018",
+			wantFee:  "0",
+		},
+		{
+			// Το τρίτο reserve μπαίνει στο w = Σ x_k² των υπόλοιπων assets
+			name: "three assets",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "1000000", "1"},
+				[3]string{"udai", "1000000", "1"}),
+			tokenIn:  types.BasicCoin{Denom: "uusdc", Amount: "10000"},
+			denomOut: "uusdt",
+			wantOut:  "9980",
+			wantFee:  "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if model := PriceModelForPool(tt.pool); model != (StableswapModel{}) {
+				t.Fatalf("PriceModelForPool = %T, want StableswapModel", model)
+			}
+			result, err := StableswapModel{}.SimulateSwap(tt.pool, tt.tokenIn, tt.denomOut)
+			if err != nil {
+				t.Fatalf("SimulateSwap: %v", err)
+			}
+			if result.TokenOut.Amount != tt.wantOut || result.Fee.Amount != tt.wantFee {
+				t.Errorf("out %s, fee %s, want %s, %s", result.TokenOut.Amount, result.Fee.Amount, tt.wantOut, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestStableswapSpotPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		pool              types.OsmosisPool
+		denomIn, denomOut string
+		want              float64
+	}{
+		{
+			name: "balanced",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "1000000", "1"}),
+			denomIn: "uusdc", denomOut: "uusdt",
+			want: 1,
+		},
+		{
+			// x_j·(3x_i² + x_j²) / (x_i·(x_i² + 3x_j²)) = 2·7/13
+			name: "imbalanced",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"uusdt", "2000000", "1"}),
+			denomIn: "uusdc", denomOut: "uusdt",
+			want: 14.0 / 13.0,
+		},
+		{
+			name: "scaling factors",
+			pool: stableswapTestPool("0",
+				[3]string{"uusdc", "1000000", "1"},
+				[3]string{"ausdc", "1000000000000000000", "1000000000000"}),
+			denomIn: "uusdc", denomOut: "ausdc",
+			want: 1e12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := StableswapModel{}.SpotPrice(tt.pool, tt.denomIn, tt.denomOut)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !closeTo(price.Float64(), tt.want, 1e-15) {
+				t.Errorf("spot price = %s, want %v", price, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurveStableswapNeedsAmplification(t *testing.T) {
+	pool := types.OsmosisPool{Type: "stable", Id: "archway:pair"}
+	pool.PoolAssets = []types.BasicPoolAsset{
+		{Token: types.BasicCoin{Denom: "uusdc", Amount: "1000000"}},
+		{Token: types.BasicCoin{Denom: "uusdt", Amount: "1000000"}},
+	}
+
+	if model := PriceModelForPool(pool); model != (CurveStableswapModel{}) {
+		t.Fatalf("PriceModelForPool = %T, want CurveStableswapModel", model)
+	}
+	if _, err := (CurveStableswapModel{}).SpotPrice(pool, "uusdc", "uusdt"); err == nil || !strings.Contains(err.Error(), "amplification") {
+		t.Errorf("error = %v, want missing amplification", err)
+	}
+
+	pool.Amplification = "100"
+	price, err := CurveStableswapModel{}.SpotPrice(pool, "uusdc", "uusdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closeTo(price.Float64(), 1, 1e-15) {
+		t.Errorf("spot price = %s, want 1", price)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// PriceModel - Μοντέλο τιμολόγησης ενός pool (weighted, stableswap, ...)
+type PriceModel interface {
+	// SpotPrice επιστρέφει πόσα denomOut αντιστοιχούν σε 1 denomIn (base units, χωρίς fee)
+	SpotPrice(pool types.OsmosisPool, denomIn, denomOut string) (decimal.Dec, error)
+	// SimulateSwap προσομοιώνει τοπικά ένα swap tokenIn -> denomOut
+	SimulateSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, denomOut string) (*types.SimulateSwapResponse, error)
+}
+
+// PriceModelForPool επιλέγει το μοντέλο τιμολόγησης με βάση το "@type" του pool
+func PriceModelForPool(pool types.OsmosisPool) PriceModel {
+	switch pool.PoolType() {
+	case types.PoolTypeStableswap:
+		// Τα Osmosis stableswap pools έχουν δικό τους CFMM· τα stable pairs άλλων DEX είναι Curve
+		if strings.HasPrefix(pool.Type, "/osmosis.") {
+			return StableswapModel{}
+		}
+		return CurveStableswapModel{}
+	case types.PoolTypeConcentrated:
+		return ConcentratedModel{}
+	default:
+		return WeightedModel{}
+	}
+}
+
+// SimulateSwapLocal προσομοιώνει ένα swap με το μοντέλο που αντιστοιχεί στο pool
+func SimulateSwapLocal(pool types.OsmosisPool, tokenIn types.BasicCoin, tokenOutDenom string) (*types.SimulateSwapResponse, error) {
+	return PriceModelForPool(pool).SimulateSwap(pool, tokenIn, tokenOutDenom)
+}
+
+// WeightedModel - Balancer weighted pools (και constant-product ως ειδική περίπτωση)
+type WeightedModel struct{}
+
+// SpotPrice - (Bo / Wo) / (Bi / Wi)
+func (WeightedModel) SpotPrice(pool types.OsmosisPool, denomIn, denomOut string) (decimal.Dec, error) {
+	assetIn, err := findWeightedAsset(pool, denomIn)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	assetOut, err := findWeightedAsset(pool, denomOut)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return weightedSpotPrice(assetIn, assetOut), nil
+}
+
+// SimulateSwap - Βλ. SimulateWeightedSwap
+func (WeightedModel) SimulateSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, denomOut string) (*types.SimulateSwapResponse, error) {
+	return SimulateWeightedSwap(pool, tokenIn, denomOut)
+}
+
+// weightedAsset - Reserve και weight ενός asset μέσα σε balancer pool
+type weightedAsset struct {
+	denom   string
+	reserve decimal.Dec
+	weight  decimal.Dec
+}
+
+// SimulateWeightedSwap προσομοιώνει τοπικά ένα swap σε balancer weighted pool,
+// χρησιμοποιώντας τα reserves, τα weights και το swap fee του pool.
+//
+// Τύπος: out = Bo * (1 - (Bi / (Bi + Ai*(1-fee)))^(Wi/Wo))
+func SimulateWeightedSwap(pool types.OsmosisPool, tokenIn types.BasicCoin, tokenOutDenom string) (*types.SimulateSwapResponse, error) {
+	amountIn, err := parseSwapAmount(tokenIn, tokenOutDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	assetIn, err := findWeightedAsset(pool, tokenIn.Denom)
+	if err != nil {
+		return nil, err
+	}
+	assetOut, err := findWeightedAsset(pool, tokenOutDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	feeAmount := amountIn.Mul(parseSwapFee(pool))
+	amountInAfterFee := amountIn.Sub(feeAmount)
+
+	// Ο λόγος είναι στο (0, 1], άρα εντός του πεδίου του Pow
+	ratio := assetIn.reserve.Quo(assetIn.reserve.Add(amountInAfterFee))
+	power, err := ratio.Pow(assetIn.weight.Quo(assetOut.weight))
+	if err != nil {
+		return nil, fmt.Errorf("σφάλμα στον υπολογισμό του swap στο pool %s: %w", pool.Id, err)
+	}
+	amountOut := assetOut.reserve.Mul(decimal.One().Sub(power))
+
+	return buildSwapResponse(tokenIn, tokenOutDenom, amountIn, feeAmount, amountOut, weightedSpotPrice(assetIn, assetOut)), nil
+}
+
+// weightedSpotPrice επιστρέφει πόσα assetOut αντιστοιχούν σε 1 assetIn (base units, χωρίς fee).
+// Υπολογίζεται ως (Bo·Wi) / (Bi·Wo) ώστε να γίνεται μία μόνο διαίρεση.
+func weightedSpotPrice(assetIn, assetOut weightedAsset) decimal.Dec {
+	denominator := assetIn.reserve.Mul(assetOut.weight)
+	if !denominator.IsPositive() {
+		return decimal.Zero()
+	}
+	return assetOut.reserve.Mul(assetIn.weight).Quo(denominator)
+}
+
+// findWeightedAsset επιστρέφει reserve και weight ενός denom μέσα στο pool
+func findWeightedAsset(pool types.OsmosisPool, denom string) (weightedAsset, error) {
+	for _, asset := range pool.PoolAssets {
+		if asset.Token.Denom != denom {
+			continue
+		}
+
+		reserve, err := decimal.Parse(asset.Token.Amount)
+		if err != nil || !reserve.IsPositive() {
+			return weightedAsset{}, fmt.Errorf("μη έγκυρο reserve για %s στο pool %s", denom, pool.Id)
+		}
+
+		// Pools χωρίς weights αντιμετωπίζονται ως ισοβαρή
+		weight, err := decimal.Parse(asset.Weight)
+		if err != nil || !weight.IsPositive() {
+			weight = decimal.One()
+		}
+
+		return weightedAsset{denom: denom, reserve: reserve, weight: weight}, nil
+	}
+
+	return weightedAsset{}, fmt.Errorf("το denom %s δεν υπάρχει στο pool %s", denom, pool.Id)
+}
+
+// parseSwapAmount ελέγχει το tokenIn και επιστρέφει την ποσότητα εισόδου
+func parseSwapAmount(tokenIn types.BasicCoin, tokenOutDenom string) (decimal.Dec, error) {
+	if tokenIn.Denom == tokenOutDenom {
+		return decimal.Dec{}, fmt.Errorf("token in και token out είναι ίδια: %s", tokenIn.Denom)
+	}
+
+	amountIn, err := decimal.Parse(tokenIn.Amount)
+	if err != nil || !amountIn.IsPositive() {
+		return decimal.Dec{}, fmt.Errorf("μη έγκυρη ποσότητα εισόδου: %s", tokenIn.Amount)
+	}
+	return amountIn, nil
+}
+
+// buildSwapResponse συμπληρώνει το SimulateSwapResponse (output, fee, price impact).
+// Το output στρογγυλεύεται προς τα κάτω και το fee προς τα πάνω, όπως on-chain.
+func buildSwapResponse(tokenIn types.BasicCoin, tokenOutDenom string, amountIn, feeAmount, amountOut, spotPrice decimal.Dec) *types.SimulateSwapResponse {
+	if amountOut.Sign() < 0 {
+		amountOut = decimal.Zero()
+	}
+
+	var priceImpact float64
+	if expected := amountIn.Sub(feeAmount).Mul(spotPrice); expected.IsPositive() {
+		priceImpact = decimal.One().Sub(amountOut.Quo(expected)).Float64()
+	}
+
+	return &types.SimulateSwapResponse{
+		TokenIn: tokenIn,
+		TokenOut: types.BasicCoin{
+			Denom:  tokenOutDenom,
+			Amount: amountOut.FloorInt().String(),
+		},
+		Fee: types.BasicCoin{
+			Denom:  tokenIn.Denom,
+			Amount: feeAmount.CeilInt().String(),
+		},
+		SpotPrice:      spotPrice.Float64(),
+		EffectivePrice: amountOut.Quo(amountIn).Float64(),
+		PriceImpact:    priceImpact,
+	}
+}
+
+// parseSwapFee επιστρέφει το swap fee του pool ως κλάσμα (0.002 = 0.2%)
+func parseSwapFee(pool types.OsmosisPool) decimal.Dec {
+	fee, err := decimal.Parse(pool.PoolParams.SwapFee)
+	if err != nil || fee.Sign() < 0 || fee.Cmp(decimal.One()) >= 0 {
+		return decimal.Zero()
+	}
+	return fee
+}
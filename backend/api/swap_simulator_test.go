@@ -0,0 +1,206 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// weightedTestPool - Balancer pool με reserves/weights ανά denom ("" weight = χωρίς weights)
+func weightedTestPool(id, swapFee string, assets ...[3]string) types.OsmosisPool {
+	pool := types.OsmosisPool{Type: "/osmosis.gamm.v1beta1.Pool", Id: id}
+	pool.PoolParams.SwapFee = swapFee
+	for _, asset := range assets {
+		pool.PoolAssets = append(pool.PoolAssets, types.BasicPoolAsset{
+			Token:  types.BasicCoin{Denom: asset[0], Amount: asset[1]},
+			Weight: asset[2],
+		})
+	}
+	return pool
+}
+
+func TestSimulateWeightedSwap(t *testing.T) {
+	// Τα αναμενόμενα ποσά είναι out = Bo·(1 - (Bi/(Bi + Ai·(1-fee)))^(Wi/Wo)) σε 80 ψηφία,
+	// με το output προς τα κάτω και το fee προς τα πάνω, όπως στο x/gamm του Osmosis
+	tests := []struct {
+		name     string
+		pool     types.OsmosisPool
+		tokenIn  types.BasicCoin
+		denomOut string
+		wantOut  string
+		wantFee  string
+	}{
+		{
+			name: "equal weights",
+			pool: weightedTestPool("1", "0.002000000000000000",
+				[3]string{"uatom", "1000000", "536870912000000"},
+				[3]string{"uosmo", "2000000", "536870912000000"}),
+			tokenIn:  types.BasicCoin{Denom: "uatom", Amount: "10000"},
+			denomOut: "uosmo",
+			wantOut:  "19762",
+			wantFee:  "20",
+		},
+		{
+			name: "80/20 into the heavy side (integer exponent)",
+			pool: weightedTestPool("2", "0.003",
+				[3]string{"uatom", "1000000", "80"},
+				[3]string{"uosmo", "1000000", "20"}),
+			tokenIn:  types.BasicCoin{Denom: "uatom", Amount: "10000"},
+			denomOut: "uosmo",
+			wantOut:  "38905",
+			wantFee:  "30",
+		},
+		{
+			name: "80/20 into the light side (fractional exponent)",
+			pool: weightedTestPool("2", "0.003",
+				[3]string{"uatom", "1000000", "80"},
+				[3]string{"uosmo", "1000000", "20"}),
+			tokenIn:  types.BasicCoin{Denom: "uosmo", Amount: "10000"},
+			denomOut: "uatom",
+			wantOut:  "2477",
+			wantFee:  "30",
+		},
+		{
+			name: "fee rounds up, output rounds down",
+			pool: weightedTestPool("2", "0.003",
+				[3]string{"uatom", "1000000", "80"},
+				[3]string{"uosmo", "1000000", "20"}),
+			tokenIn:  types.BasicCoin{Denom: "uosmo", Amount: "1001"}, // fee 3.003, out 249.34
+			denomOut: "uatom",
+			wantOut:  "249",
+			wantFee:  "4",
+		},
+		{
+			name: "pool without weights is equal-weighted",
+			pool: weightedTestPool("3", "0.002",
+				[3]string{"uatom", "1000000", ""},
+				[3]string{"uosmo", "2000000", ""}),
+			tokenIn:  types.BasicCoin{Denom: "uatom", Amount: "10000"},
+			denomOut: "uosmo",
+			wantOut:  "19762",
+			wantFee:  "20",
+		},
+		{
+			name: "18-decimal reserves beyond float64",
+			pool: weightedTestPool("4", "0.002",
+				[3]string{"aevmos", "1000000000000000000000000000000", "1"},
+				[3]string{"uosmo", "1000000000000000000000000000000", "1"}),
+			tokenIn:  types.BasicCoin{Denom: "aevmos", Amount: "1000000000000000000000000"},
+			denomOut: "uosmo",
+			wantOut:  "997999003996994010999977",
+			wantFee:  "2000000000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SimulateWeightedSwap(tt.pool, tt.tokenIn, tt.denomOut)
+			if err != nil {
+				t.Fatalf("SimulateWeightedSwap: %v", err)
+			}
+			if result.TokenOut.Amount != tt.wantOut {
+				t.Errorf("token out = %s, want %s", result.TokenOut.Amount, tt.wantOut)
+			}
+			if result.TokenOut.Denom != tt.denomOut {
+				t.Errorf("token out denom = %s, want %s", result.TokenOut.Denom, tt.denomOut)
+			}
+			if result.Fee.Amount != tt.wantFee || result.Fee.Denom != tt.tokenIn.Denom {
+				t.Errorf("fee = %s%s, want %s%s", result.Fee.Amount, result.Fee.Denom, tt.wantFee, tt.tokenIn.Denom)
+			}
+			if result.PriceImpact <= 0 || result.PriceImpact >= 1 {
+				t.Errorf("price impact = %v, want in (0, 1)", result.PriceImpact)
+			}
+		})
+	}
+}
+
+func TestSimulateWeightedSwapPriceImpact(t *testing.T) {
+	pool := weightedTestPool("1", "0",
+		[3]string{"uatom", "1000000", "1"},
+		[3]string{"uosmo", "1000000", "1"})
+
+	// Χωρίς fee, σε ισοβαρές pool: out = Bo·a/(Bi+a), άρα impact = a/(Bi+a)
+	result, err := SimulateWeightedSwap(pool, types.BasicCoin{Denom: "uatom", Amount: "10000"}, "uosmo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TokenOut.Amount != "9900" {
+		t.Errorf("token out = %s, want 9900", result.TokenOut.Amount)
+	}
+	if result.Fee.Amount != "0" {
+		t.Errorf("fee = %s, want 0", result.Fee.Amount)
+	}
+	if want := 10000.0 / 1010000.0; !closeTo(result.PriceImpact, want, 1e-12) {
+		t.Errorf("price impact = %v, want %v", result.PriceImpact, want)
+	}
+	if result.SpotPrice != 1 {
+		t.Errorf("spot price = %v, want 1", result.SpotPrice)
+	}
+}
+
+func TestSimulateWeightedSwapErrors(t *testing.T) {
+	pool := weightedTestPool("1", "0.002",
+		[3]string{"uatom", "1000000", "1"},
+		[3]string{"uosmo", "1000000", "1"})
+
+	tests := []struct {
+		name     string
+		tokenIn  types.BasicCoin
+		denomOut string
+		wantErr  string
+	}{
+		{"same denom", types.BasicCoin{Denom: "uatom", Amount: "1"}, "uatom", "ίδια"},
+		{"zero amount", types.BasicCoin{Denom: "uatom", Amount: "0"}, "uosmo", "ποσότητα"},
+		{"invalid amount", types.BasicCoin{Denom: "uatom", Amount: "abc"}, "uosmo", "ποσότητα"},
+		{"unknown denom in", types.BasicCoin{Denom: "uion", Amount: "1"}, "uosmo", "uion"},
+		{"unknown denom out", types.BasicCoin{Denom: "uatom", Amount: "1"}, "uion", "uion"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SimulateWeightedSwap(pool, tt.tokenIn, tt.denomOut)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSwapFee(t *testing.T) {
+	tests := []struct {
+		fee  string
+		want string
+	}{
+		{"0.002000000000000000", "0.002"},
+		{"0.3", "0.3"},
+		{"", "0"},
+		{"abc", "0"},
+		{"-0.01", "0"},
+		{"1", "0"}, // Fee 100% θα μηδένιζε κάθε swap: θεωρείται άκυρο
+	}
+
+	for _, tt := range tests {
+		pool := types.OsmosisPool{}
+		pool.PoolParams.SwapFee = tt.fee
+		if got := parseSwapFee(pool).String(); got != tt.want {
+			t.Errorf("parseSwapFee(%q) = %s, want %s", tt.fee, got, tt.want)
+		}
+	}
+}
+
+// closeTo - |a - b| <= tolerance·max(1, |b|)
+func closeTo(a, b, tolerance float64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	scale := b
+	if scale < 0 {
+		scale = -scale
+	}
+	if scale < 1 {
+		scale = 1
+	}
+	return diff <= tolerance*scale
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"portofoliov1/decimal"
+	"portofoliov1/types"
+)
+
+// maxConversionHops - Μέγιστος αριθμός pools σε μια διαδρομή μετατροπής
+const maxConversionHops = 4
+
+// conversionEdge - Ακμή του γράφου tokens (ένα pool προς μια κατεύθυνση)
+type conversionEdge struct {
+	poolID    string
+	to        string // Denom
+	rate      float64
+	liquidity float64 // TVL του pool σε USD (0 αν δεν είναι γνωστό)
+	reserve   float64 // Reserve του token εισόδου, για επιλογή του βαθύτερου pool
+}
+
+// TokenConverter μετατρέπει ποσότητες μεταξύ tokens μέσω του γράφου των pool prices.
+// Οι κόμβοι είναι denoms: tokens με ίδιο symbol (π.χ. δύο παραλλαγές USDC) μένουν
+// χωριστά, ώστε μια διαδρομή να περνά μόνο από pools που μοιράζονται token.
+type TokenConverter struct {
+	edges         map[string]map[string]conversionEdge // from denom -> to denom -> καλύτερη ακμή
+	symbols       map[string]string                    // denom -> symbol
+	bySymbol      map[string]string                    // UPPER(symbol) -> το denom του πιο liquid pool
+	bestLiquidity map[string]float64                   // UPPER(symbol) -> liquidity του bySymbol
+}
+
+// NewTokenConverter χτίζει τον γράφο μετατροπής από τις τελευταίες pool prices
+func NewTokenConverter(prices []types.PoolPrice) *TokenConverter {
+	c := &TokenConverter{
+		edges:         make(map[string]map[string]conversionEdge),
+		symbols:       make(map[string]string),
+		bySymbol:      make(map[string]string),
+		bestLiquidity: make(map[string]float64),
+	}
+
+	for _, price := range prices {
+		if price.Token0Denom == "" || price.Token1Denom == "" || price.Token0Denom == price.Token1Denom {
+			continue
+		}
+
+		reserve0, _ := decimal.Parse(price.Token0Amount)
+		reserve1, _ := decimal.Parse(price.Token1Amount)
+
+		poolID := price.OnChainPoolID()
+		forward := c.addEdge(price.Token0Denom, price.Token1Denom, conversionEdge{poolID: poolID, rate: price.PriceToken0ToToken1, liquidity: price.LiquidityUSD, reserve: reserve0.Float64()})
+		backward := c.addEdge(price.Token1Denom, price.Token0Denom, conversionEdge{poolID: poolID, rate: price.PriceToken1ToToken0, liquidity: price.LiquidityUSD, reserve: reserve1.Float64()})
+		if forward || backward {
+			c.addToken(price.Token0Denom, price.Token0Symbol, price.LiquidityUSD)
+			c.addToken(price.Token1Denom, price.Token1Symbol, price.LiquidityUSD)
+		}
+	}
+
+	return c
+}
+
+// addToken καταγράφει το symbol ενός denom· ένα symbol αντιστοιχεί στο denom του πιο
+// liquid pool που το περιέχει, όπως στο SwapRouter.ResolveDenom
+func (c *TokenConverter) addToken(denom, symbol string, liquidity float64) {
+	if symbol == "" {
+		symbol = denom
+	}
+	c.symbols[denom] = symbol
+
+	key := strings.ToUpper(symbol)
+	if current, ok := c.bySymbol[key]; !ok || liquidity > c.bestLiquidity[key] || (liquidity == c.bestLiquidity[key] && denom < current) {
+		c.bySymbol[key] = denom
+		c.bestLiquidity[key] = liquidity
+	}
+}
+
+// addEdge προσθέτει μια ακμή, κρατώντας για κάθε ζεύγος το pool με τη μεγαλύτερη
+// liquidity (USD) και, σε ισοπαλία, το μεγαλύτερο reserve. Επιστρέφει false για άκυρη τιμή.
+func (c *TokenConverter) addEdge(from, to string, edge conversionEdge) bool {
+	if !validRate(edge.rate) {
+		return false
+	}
+
+	if c.edges[from] == nil {
+		c.edges[from] = make(map[string]conversionEdge)
+	}
+
+	if existing, ok := c.edges[from][to]; ok {
+		if existing.liquidity > edge.liquidity || (existing.liquidity == edge.liquidity && existing.reserve >= edge.reserve) {
+			return true
+		}
+	}
+
+	edge.to = to
+	c.edges[from][to] = edge
+	return true
+}
+
+// resolveDenom - Denom όπως δόθηκε αν υπάρχει στον γράφο, αλλιώς το denom του symbol
+// (case-insensitive) στο πιο liquid pool
+func (c *TokenConverter) resolveDenom(token string) (string, bool) {
+	if _, ok := c.symbols[token]; ok {
+		return token, true
+	}
+	denom, ok := c.bySymbol[strings.ToUpper(token)]
+	return denom, ok
+}
+
+// Convert βρίσκει τη διαδρομή με τα λιγότερα hops και μετατρέπει το amount
+func (c *TokenConverter) Convert(from, to string, amount float64) (*types.ConversionResult, error) {
+	fromDenom, ok := c.resolveDenom(from)
+	if !ok {
+		return nil, fmt.Errorf("token %s not found in any pool", from)
+	}
+	toDenom, ok := c.resolveDenom(to)
+	if !ok {
+		return nil, fmt.Errorf("token %s not found in any pool", to)
+	}
+
+	result := &types.ConversionResult{
+		From:      c.symbols[fromDenom],
+		FromDenom: fromDenom,
+		To:        c.symbols[toDenom],
+		ToDenom:   toDenom,
+		Amount:    amount,
+		PoolIDs:   []string{},
+		Hops:      []types.ConversionHop{},
+		Timestamp: time.Now(),
+	}
+
+	if fromDenom == toDenom {
+		result.ConvertedAmount = amount
+		result.Rate = 1
+		return result, nil
+	}
+
+	path := c.findPath(fromDenom, toDenom)
+	if path == nil {
+		return nil, fmt.Errorf("no conversion path from %s to %s within %d hops", result.From, result.To, maxConversionHops)
+	}
+
+	rate := 1.0
+	current := fromDenom
+	for _, edge := range path {
+		rate *= edge.rate
+		result.PoolIDs = append(result.PoolIDs, edge.poolID)
+		result.Hops = append(result.Hops, types.ConversionHop{
+			PoolID:     edge.poolID,
+			FromSymbol: c.symbols[current],
+			FromDenom:  current,
+			ToSymbol:   c.symbols[edge.to],
+			ToDenom:    edge.to,
+			Rate:       edge.rate,
+		})
+		current = edge.to
+	}
+
+	result.Rate = rate
+	result.ConvertedAmount = amount * rate
+	return result, nil
+}
+
+// findPath - BFS στον γράφο tokens, επιστρέφει nil αν δεν υπάρχει διαδρομή
+func (c *TokenConverter) findPath(from, to string) []conversionEdge {
+	type visit struct {
+		prev string
+		edge conversionEdge
+	}
+
+	visited := map[string]visit{from: {}}
+	frontier := []string{from}
+
+	for depth := 0; depth < maxConversionHops && len(frontier) > 0; depth++ {
+		var next []string
+		for _, denom := range frontier {
+			// Ταξινομημένα targets ώστε η επιλογή διαδρομής να είναι ντετερμινιστική
+			targets := make([]string, 0, len(c.edges[denom]))
+			for target := range c.edges[denom] {
+				targets = append(targets, target)
+			}
+			sort.Strings(targets)
+
+			for _, target := range targets {
+				if _, seen := visited[target]; seen {
+					continue
+				}
+				visited[target] = visit{prev: denom, edge: c.edges[denom][target]}
+				next = append(next, target)
+			}
+		}
+
+		if _, found := visited[to]; found {
+			var path []conversionEdge
+			for current := to; current != from; current = visited[current].prev {
+				path = append([]conversionEdge{visited[current].edge}, path...)
+			}
+			return path
+		}
+		frontier = next
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"portofoliov1/types"
+)
+
+// converterTestPool - Pool price δύο tokens με τιμή token0 -> token1 και TVL
+func converterTestPool(id, symbol0, denom0, symbol1, denom1 string, rate, liquidity float64) types.PoolPrice {
+	return types.PoolPrice{
+		PoolID:              id,
+		Token0Symbol:        symbol0,
+		Token0Denom:         denom0,
+		Token0Amount:        "1000000",
+		Token1Symbol:        symbol1,
+		Token1Denom:         denom1,
+		Token1Amount:        "1000000",
+		PriceToken0ToToken1: rate,
+		PriceToken1ToToken0: 1 / rate,
+		LiquidityUSD:        liquidity,
+	}
+}
+
+func converterTestPools() []types.PoolPrice {
+	return []types.PoolPrice{
+		converterTestPool("1", "ATOM", "uatom", "OSMO", "uosmo", 10, 1e6),
+		converterTestPool("2", "OSMO", "uosmo", "USDC", "ibc/USDC.AXL", 0.5, 1e6),
+		// Δεύτερο USDC (άλλο denom, ίδιο symbol) που συνδέεται μόνο με το FOO
+		converterTestPool("3", "FOO", "ufoo", "USDC", "ibc/USDC.NOBLE", 2, 100),
+		// Αλυσίδα T0 - T1 - ... - T5 χωρίς άλλες συνδέσεις
+		converterTestPool("10", "T0", "t0", "T1", "t1", 2, 1000),
+		converterTestPool("11", "T1", "t1", "T2", "t2", 2, 1000),
+		converterTestPool("12", "T2", "t2", "T3", "t3", 2, 1000),
+		converterTestPool("13", "T3", "t3", "T4", "t4", 2, 1000),
+		converterTestPool("14", "T4", "t4", "T5", "t5", 2, 1000),
+	}
+}
+
+func TestTokenConverterConvert(t *testing.T) {
+	converter := NewTokenConverter(converterTestPools())
+
+	tests := []struct {
+		name      string
+		from, to  string
+		amount    float64
+		want      float64
+		pools     []string
+		fromDenom string
+		toDenom   string
+	}{
+		{"direct pair", "ATOM", "OSMO", 3, 30, []string{"1"}, "uatom", "uosmo"},
+		{"reverse direction", "OSMO", "ATOM", 30, 3, []string{"1"}, "uosmo", "uatom"},
+		{"multi-hop", "ATOM", "USDC", 2, 10, []string{"1", "2"}, "uatom", "ibc/USDC.AXL"},
+		{"case-insensitive symbols", "atom", "Osmo", 1, 10, []string{"1"}, "uatom", "uosmo"},
+		{"denom instead of symbol", "ibc/USDC.NOBLE", "FOO", 4, 2, []string{"3"}, "ibc/USDC.NOBLE", "ufoo"},
+		{"four hops", "T0", "T4", 1, 16, []string{"10", "11", "12", "13"}, "t0", "t4"},
+		{"same token", "USDC", "usdc", 7, 7, []string{}, "ibc/USDC.AXL", "ibc/USDC.AXL"},
+	}
+
+	for _, tt := range tests {
+		result, err := converter.Convert(tt.from, tt.to, tt.amount)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !closeTo(result.ConvertedAmount, tt.want, 1e-12) {
+			t.Errorf("%s: converted %v, want %v", tt.name, result.ConvertedAmount, tt.want)
+		}
+		if strings.Join(result.PoolIDs, ",") != strings.Join(tt.pools, ",") {
+			t.Errorf("%s: pools %v, want %v", tt.name, result.PoolIDs, tt.pools)
+		}
+		if result.FromDenom != tt.fromDenom || result.ToDenom != tt.toDenom {
+			t.Errorf("%s: %s -> %s, want %s -> %s", tt.name, result.FromDenom, result.ToDenom, tt.fromDenom, tt.toDenom)
+		}
+
+		// Κάθε hop ξεκινά από το denom όπου τελείωσε το προηγούμενο
+		current := result.FromDenom
+		for _, hop := range result.Hops {
+			if hop.FromDenom != current {
+				t.Errorf("%s: hop through pool %s starts at %s, previous hop ended at %s", tt.name, hop.PoolID, hop.FromDenom, current)
+			}
+			current = hop.ToDenom
+		}
+	}
+}
+
+func TestTokenConverterNoPath(t *testing.T) {
+	converter := NewTokenConverter(converterTestPools())
+
+	tests := []struct {
+		name     string
+		from, to string
+	}{
+		{"beyond maxConversionHops", "T0", "T5"},
+		// Το FOO συνδέεται με το USDC.NOBLE, όχι με το USDC.AXL του OSMO pool
+		{"same symbol, different denom", "FOO", "OSMO"},
+		{"disconnected tokens", "ATOM", "T1"},
+		{"unknown token", "ATOM", "DOGE"},
+	}
+
+	for _, tt := range tests {
+		if result, err := converter.Convert(tt.from, tt.to, 1); err == nil {
+			t.Errorf("%s: converted through %v, want an error", tt.name, result.PoolIDs)
+		}
+	}
+}
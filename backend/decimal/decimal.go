@@ -0,0 +1,287 @@
+// Package decimal - Δεκαδικοί αριθμοί σταθερής υποδιαστολής πάνω σε big.Int, για τα
+// ποσά και τις τιμές των pools. Τα reserves έρχονται ως ακέραια strings που ξεπερνούν
+// την ακρίβεια του float64 (π.χ. tokens με 18 decimals), οπότε όλος ο υπολογισμός
+// τιμών γίνεται εδώ και μόνο το αποτέλεσμα μετατρέπεται σε float64.
+//
+// Η ακρίβεια είναι 36 δεκαδικά ψηφία, όπως το BigDec του Osmosis.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Precision - Δεκαδικά ψηφία μετά την υποδιαστολή
+const Precision = 36
+
+var (
+	scale  = new(big.Int).Exp(big.NewInt(10), big.NewInt(Precision), nil)
+	scaleF = new(big.Float).SetInt(scale)
+)
+
+// ErrDivisionByZero - Διαίρεση με το μηδέν
+var ErrDivisionByZero = errors.New("διαίρεση με το μηδέν")
+
+// Dec - Αμετάβλητος δεκαδικός: η τιμή είναι i / 10^Precision. Η μηδενική τιμή είναι το 0.
+type Dec struct {
+	i *big.Int
+}
+
+// Zero - Το 0
+func Zero() Dec { return Dec{i: new(big.Int)} }
+
+// One - Το 1
+func One() Dec { return Dec{i: new(big.Int).Set(scale)} }
+
+// NewFromInt - Ακέραιος ως Dec
+func NewFromInt(n int64) Dec {
+	return NewFromBigInt(big.NewInt(n))
+}
+
+// NewFromBigInt - Ακέραιος (π.χ. ποσό σε base units) ως Dec
+func NewFromBigInt(n *big.Int) Dec {
+	return Dec{i: new(big.Int).Mul(n, scale)}
+}
+
+// NewFromFloat - Μετατροπή από float64 (μόνο για τιμές που είναι ήδη float, π.χ. από config)
+func NewFromFloat(f float64) (Dec, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Dec{}, fmt.Errorf("μη πεπερασμένος αριθμός: %v", f)
+	}
+	bf := new(big.Float).SetPrec(256).SetFloat64(f)
+	bf.Mul(bf, scaleF)
+	i, _ := bf.Int(nil)
+	return Dec{i: i}, nil
+}
+
+// Parse διαβάζει ακέραια ή δεκαδικά strings ("1000000", "-0.5", "0.002000000000000000").
+// Ψηφία πέρα από το Precision αποκόπτονται.
+func Parse(s string) (Dec, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Dec{}, fmt.Errorf("κενός αριθμός")
+	}
+
+	negative := false
+	switch str[0] {
+	case '-':
+		negative = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(str, ".")
+	if (intPart == "" && fracPart == "") || (hasDot && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Dec{}, fmt.Errorf("μη έγκυρος αριθμός: %q", s)
+	}
+	if len(fracPart) > Precision {
+		fracPart = fracPart[:Precision]
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", Precision-len(fracPart))
+	i, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Dec{}, fmt.Errorf("μη έγκυρος αριθμός: %q", s)
+	}
+	if negative {
+		i.Neg(i)
+	}
+	return Dec{i: i}, nil
+}
+
+// MustParse - Όπως το Parse, για σταθερές του κώδικα
+func MustParse(s string) Dec {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Dec) bigInt() *big.Int {
+	if d.i == nil {
+		return new(big.Int)
+	}
+	return d.i
+}
+
+// Add - d + o
+func (d Dec) Add(o Dec) Dec {
+	return Dec{i: new(big.Int).Add(d.bigInt(), o.bigInt())}
+}
+
+// Sub - d - o
+func (d Dec) Sub(o Dec) Dec {
+	return Dec{i: new(big.Int).Sub(d.bigInt(), o.bigInt())}
+}
+
+// Mul - d × o, στρογγυλεμένο στο Precision
+func (d Dec) Mul(o Dec) Dec {
+	product := new(big.Int).Mul(d.bigInt(), o.bigInt())
+	return Dec{i: roundQuo(product, scale)}
+}
+
+// Quo - d / o, στρογγυλεμένο στο Precision. Πανικοβάλλεται για o = 0, όπως το big.Int·
+// όπου ο διαιρέτης μπορεί να είναι 0 χρησιμοποιείται το SafeQuo.
+func (d Dec) Quo(o Dec) Dec {
+	if o.IsZero() {
+		panic(ErrDivisionByZero)
+	}
+	numerator := new(big.Int).Mul(d.bigInt(), scale)
+	return Dec{i: roundQuo(numerator, o.bigInt())}
+}
+
+// SafeQuo - d / o ή ErrDivisionByZero
+func (d Dec) SafeQuo(o Dec) (Dec, error) {
+	if o.IsZero() {
+		return Dec{}, ErrDivisionByZero
+	}
+	return d.Quo(o), nil
+}
+
+// roundQuo - n / m με στρογγυλοποίηση μισού προς τα έξω (away from zero)
+func roundQuo(n, m *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, m, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(m)) >= 0 {
+		if (n.Sign() < 0) != (m.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// MulPow10 - d × 10^n (n αρνητικό για διαίρεση), π.χ. μετατροπή base -> display units
+func (d Dec) MulPow10(n int) Dec {
+	switch {
+	case n == 0:
+		return Dec{i: new(big.Int).Set(d.bigInt())}
+	case n > 0:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+		return Dec{i: new(big.Int).Mul(d.bigInt(), factor)}
+	default:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-n)), nil)
+		return Dec{i: roundQuo(d.bigInt(), factor)}
+	}
+}
+
+// PowInt - d^n με επαναλαμβανόμενους τετραγωνισμούς
+func (d Dec) PowInt(n uint64) Dec {
+	result := One()
+	base := d
+	for n > 0 {
+		if n&1 == 1 {
+			result = result.Mul(base)
+		}
+		n >>= 1
+		if n > 0 {
+			base = base.Mul(base)
+		}
+	}
+	return result
+}
+
+// Sqrt - √d στρογγυλεμένη προς τα κάτω στο Precision (π.χ. για τις √τιμές των CL ticks)
+func (d Dec) Sqrt() (Dec, error) {
+	if d.Sign() < 0 {
+		return Dec{}, fmt.Errorf("τετραγωνική ρίζα αρνητικού αριθμού: %s", d)
+	}
+	// √(i / 10^P) · 10^P = √(i · 10^P)
+	return Dec{i: new(big.Int).Sqrt(new(big.Int).Mul(d.bigInt(), scale))}, nil
+}
+
+// Neg - -d
+func (d Dec) Neg() Dec { return Dec{i: new(big.Int).Neg(d.bigInt())} }
+
+// Abs - |d|
+func (d Dec) Abs() Dec { return Dec{i: new(big.Int).Abs(d.bigInt())} }
+
+// Sign - -1, 0 ή 1
+func (d Dec) Sign() int { return d.bigInt().Sign() }
+
+// IsZero - d == 0
+func (d Dec) IsZero() bool { return d.Sign() == 0 }
+
+// IsPositive - d > 0
+func (d Dec) IsPositive() bool { return d.Sign() > 0 }
+
+// Cmp - -1, 0 ή 1 για d < o, d == o, d > o
+func (d Dec) Cmp(o Dec) int { return d.bigInt().Cmp(o.bigInt()) }
+
+// Min - Το μικρότερο από τα d, o
+func Min(d, o Dec) Dec {
+	if d.Cmp(o) <= 0 {
+		return d
+	}
+	return o
+}
+
+// TruncateInt - Το ακέραιο μέρος (προς το 0)
+func (d Dec) TruncateInt() *big.Int {
+	return new(big.Int).Quo(d.bigInt(), scale)
+}
+
+// FloorInt - Ο μεγαλύτερος ακέραιος <= d
+func (d Dec) FloorInt() *big.Int {
+	// Η Euclidean διαίρεση του big.Int με θετικό διαιρέτη είναι ήδη floor
+	return new(big.Int).Div(d.bigInt(), scale)
+}
+
+// CeilInt - Ο μικρότερος ακέραιος >= d
+func (d Dec) CeilInt() *big.Int {
+	q, m := new(big.Int).DivMod(d.bigInt(), scale, new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+// Float64 - Η πλησιέστερη τιμή float64 (για τα πεδία float του API και τον oracle)
+func (d Dec) Float64() float64 {
+	bf := new(big.Float).SetPrec(256).SetInt(d.bigInt())
+	bf.Quo(bf, scaleF)
+	f, _ := bf.Float64()
+	return f
+}
+
+// String - Ακριβής δεκαδική μορφή χωρίς εκθέτη και χωρίς μηδενικά στο τέλος ("0.000000123")
+func (d Dec) String() string {
+	i := d.bigInt()
+	digits := new(big.Int).Abs(i).String()
+	if len(digits) <= Precision {
+		digits = strings.Repeat("0", Precision-len(digits)+1) + digits
+	}
+
+	intPart := digits[:len(digits)-Precision]
+	fracPart := strings.TrimRight(digits[len(digits)-Precision:], "0")
+
+	var sb strings.Builder
+	if i.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(intPart)
+	if fracPart != "" {
+		sb.WriteByte('.')
+		sb.WriteString(fracPart)
+	}
+	return sb.String()
+}
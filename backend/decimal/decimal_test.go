@@ -0,0 +1,224 @@
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"0", "0"},
+		{"1000000", "1000000"},
+		{"-0.5", "-0.5"},
+		{"+2.50", "2.5"},
+		{"0.002000000000000000", "0.002"},
+		{"0.000000123", "0.000000123"},
+		{".5", "0.5"},
+		{"7.", ""}, // Άκυρο
+		{"123456789012345678901234567890.000000000000000000000000000000000001", "123456789012345678901234567890.000000000000000000000000000000000001"},
+		{"0.0000000000000000000000000000000000019", "0.000000000000000000000000000000000001"}, // Αποκοπή στο Precision
+		{"1e6", ""},
+		{"", ""},
+		{"-", ""},
+		{"1.2.3", ""},
+	}
+
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Parse(%q) = %s, want error", tt.in, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %s, want %s", tt.in, got, tt.want)
+		}
+		if again := MustParse(d.String()); again.Cmp(d) != 0 {
+			t.Errorf("%s does not survive a second round trip: %s", tt.in, again)
+		}
+	}
+}
+
+func TestQuoRounding(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"1", "3", "0.333333333333333333333333333333333333"},
+		{"2", "3", "0.666666666666666666666666666666666667"}, // Μισό και πάνω: προς τα έξω
+		{"-2", "3", "-0.666666666666666666666666666666666667"},
+		{"2", "-3", "-0.666666666666666666666666666666666667"},
+		{"1", "8", "0.125"},
+		{"0.000000000000000000000000000000000001", "2", "0.000000000000000000000000000000000001"}, // 0.5·10^-36 → 10^-36
+		{"0.000000000000000000000000000000000001", "3", "0"},
+		{"10", "4", "2.5"},
+	}
+
+	for _, tt := range tests {
+		if got := MustParse(tt.a).Quo(MustParse(tt.b)).String(); got != tt.want {
+			t.Errorf("%s / %s = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if _, err := One().SafeQuo(Zero()); err != ErrDivisionByZero {
+		t.Errorf("SafeQuo by zero: %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestMulRounding(t *testing.T) {
+	// 10^-18 · 0.5·10^-18 = 0.5·10^-36 → 10^-36
+	a := MustParse("0.000000000000000001")
+	b := MustParse("0.0000000000000000005")
+	if got := a.Mul(b).String(); got != "0.000000000000000000000000000000000001" {
+		t.Errorf("Mul = %s, want 1e-36", got)
+	}
+	if got := MustParse("1.5").Mul(MustParse("-2")).String(); got != "-3" {
+		t.Errorf("1.5 · -2 = %s, want -3", got)
+	}
+}
+
+func TestIntegerParts(t *testing.T) {
+	tests := []struct {
+		in                     string
+		trunc, floor, ceilWant string
+	}{
+		{"2.5", "2", "2", "3"},
+		{"-2.5", "-2", "-3", "-2"},
+		{"3", "3", "3", "3"},
+		{"-3", "-3", "-3", "-3"},
+		{"0.000000000000000000000000000000000001", "0", "0", "1"},
+		{"-0.000000000000000000000000000000000001", "0", "-1", "0"},
+	}
+
+	for _, tt := range tests {
+		d := MustParse(tt.in)
+		if got := d.TruncateInt().String(); got != tt.trunc {
+			t.Errorf("TruncateInt(%s) = %s, want %s", tt.in, got, tt.trunc)
+		}
+		if got := d.FloorInt().String(); got != tt.floor {
+			t.Errorf("FloorInt(%s) = %s, want %s", tt.in, got, tt.floor)
+		}
+		if got := d.CeilInt().String(); got != tt.ceilWant {
+			t.Errorf("CeilInt(%s) = %s, want %s", tt.in, got, tt.ceilWant)
+		}
+	}
+}
+
+func TestMulPow10(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"1.5", 6, "1500000"},
+		{"1500000", -6, "1.5"},
+		{"123", 0, "123"},
+		{"1000000000000000000000000", -18, "1000000"},
+		{"0.000000000000000000000000000000000005", -1, "0.000000000000000000000000000000000001"}, // Μισό: προς τα έξω
+		{"0.000000000000000000000000000000000004", -1, "0"},
+		{"-0.000000000000000000000000000000000005", -1, "-0.000000000000000000000000000000000001"},
+	}
+
+	for _, tt := range tests {
+		if got := MustParse(tt.in).MulPow10(tt.n).String(); got != tt.want {
+			t.Errorf("%s · 10^%d = %s, want %s", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPowAgainstMathPow(t *testing.T) {
+	bases := []string{"0.000001", "0.001", "0.1", "0.5", "0.99", "1", "1.01", "1.5", "1.9", "1.999"}
+	exponents := []string{"0", "0.1", "0.25", "0.5", "0.8", "1", "1.25", "2.5", "4"}
+
+	for _, b := range bases {
+		for _, e := range exponents {
+			got, err := MustParse(b).Pow(MustParse(e))
+			if err != nil {
+				t.Errorf("%s^%s: %v", b, e, err)
+				continue
+			}
+			want := math.Pow(MustParse(b).Float64(), MustParse(e).Float64())
+			if diff := math.Abs(got.Float64()-want) / want; diff > 1e-14 {
+				t.Errorf("%s^%s = %s, want %v (relative error %g)", b, e, got, want, diff)
+			}
+		}
+	}
+}
+
+func TestPowPrecision(t *testing.T) {
+	// Αναφορά: exp(e·ln(b)) σε 60 ψηφία
+	tests := []struct {
+		base, exp, want string
+	}{
+		{"0.25", "0.5", "0.5"},
+		{"0.5", "0.5", "0.707106781186547524400844362104849039"},
+		{"0.000001", "0.25", "0.031622776601683793319988935444327185"},
+		{"0.99", "0.2", "0.997991951661425800481448732840435888"},
+		{"1.999", "0.75", "1.681162118770755145740029056133835716"},
+		{"1.5", "2.5", "2.755675960631075360471944584044127816"},
+	}
+
+	tolerance := MustParse("0.000000000000000000000000000000001") // 10^-33
+	for _, tt := range tests {
+		got, err := MustParse(tt.base).Pow(MustParse(tt.exp))
+		if err != nil {
+			t.Fatalf("%s^%s: %v", tt.base, tt.exp, err)
+		}
+		if diff := got.Sub(MustParse(tt.want)).Abs(); diff.Cmp(tolerance) > 0 {
+			t.Errorf("%s^%s = %s, want %s (error %s)", tt.base, tt.exp, got, tt.want, diff)
+		}
+	}
+}
+
+func TestPowErrors(t *testing.T) {
+	tests := []struct {
+		base, exp string
+	}{
+		{"0", "0.5"},
+		{"-0.5", "0.5"},
+		{"2", "0.5"},
+		{"0.5", "-1"},
+	}
+
+	for _, tt := range tests {
+		if got, err := MustParse(tt.base).Pow(MustParse(tt.exp)); err == nil {
+			t.Errorf("%s^%s = %s, want error", tt.base, tt.exp, got)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"4", "2"},
+		{"0.25", "0.5"},
+		{"2", "1.414213562373095048801688724209698078"}, // Προς τα κάτω
+		{"0", "0"},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.in).Sqrt()
+		if err != nil || got.String() != tt.want {
+			t.Errorf("Sqrt(%s) = %s, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := MustParse("-1").Sqrt(); err == nil {
+		t.Error("Sqrt(-1) without error")
+	}
+}
+
+func BenchmarkPow(b *testing.B) {
+	// Ο εκθέτης ενός 80/20 pool με βάση κοντά στο 0, όπως σε μεγάλα swaps
+	base := MustParse("0.000123")
+	exp := MustParse("0.25")
+	for i := 0; i < b.N; i++ {
+		base.Pow(exp)
+	}
+}
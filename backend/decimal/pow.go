@@ -0,0 +1,90 @@
+package decimal
+
+import (
+	"fmt"
+)
+
+// seriesMaxTerms - Όριο όρων για τις σειρές του ln και του exp. Μετά τη μείωση του εύρους
+// χρειάζονται ~15 και ~25 όροι αντίστοιχα για να πέσει ο όρος κάτω από 10^-Precision.
+const seriesMaxTerms = 100
+
+var (
+	two = NewFromInt(2)
+	// seriesRange - Οι σειρές εφαρμόζονται μόνο για |base - 1| και |y| έως εδώ
+	seriesRange = MustParse("0.1")
+)
+
+// Pow - base^exp για base στο (0, 2) και exp >= 0, όπως το osmomath.Pow των weighted
+// pools: το ακέραιο μέρος του εκθέτη με PowInt, το κλασματικό ως exp(f·ln(base)).
+func (d Dec) Pow(exp Dec) (Dec, error) {
+	if !d.IsPositive() || d.Cmp(two) >= 0 {
+		return Dec{}, fmt.Errorf("η βάση %s είναι εκτός (0, 2)", d)
+	}
+	if exp.Sign() < 0 {
+		return Dec{}, fmt.Errorf("αρνητικός εκθέτης: %s", exp)
+	}
+
+	integer := exp.TruncateInt()
+	fractional := exp.Sub(NewFromBigInt(integer))
+
+	result := d.PowInt(integer.Uint64())
+	if fractional.IsZero() {
+		return result, nil
+	}
+	return result.Mul(powApprox(d, fractional)), nil
+}
+
+// powApprox - base^exp για exp στο (0, 1): exp(exp·ln(base))
+func powApprox(base, exp Dec) Dec {
+	return expSeries(exp.Mul(lnSeries(base)))
+}
+
+// lnSeries - ln(d) για d > 0. Τετραγωνικές ρίζες φέρνουν το d κοντά στο 1
+// (ln d = 2^k·ln d^(1/2^k)) και μετά ln d = 2·atanh(z) = 2·Σ z^(2n+1)/(2n+1), z = (d-1)/(d+1).
+func lnSeries(d Dec) Dec {
+	one := One()
+	multiplier := int64(2)
+	for d.Sub(one).Abs().Cmp(seriesRange) > 0 {
+		d, _ = d.Sqrt() // d > 0
+		multiplier *= 2
+	}
+
+	z := d.Sub(one).Quo(d.Add(one))
+	z2 := z.Mul(z)
+	power := z
+	sum := z
+	for n := int64(3); n < 2*seriesMaxTerms; n += 2 {
+		power = power.Mul(z2)
+		term := power.Quo(NewFromInt(n))
+		if term.IsZero() {
+			break
+		}
+		sum = sum.Add(term)
+	}
+	return sum.Mul(NewFromInt(multiplier))
+}
+
+// expSeries - e^y με υποδιπλασιασμούς του y μέχρι |y| <= seriesRange, τη σειρά Taylor
+// και ισάριθμους τετραγωνισμούς του αποτελέσματος (e^y = (e^(y/2^k))^(2^k))
+func expSeries(y Dec) Dec {
+	halvings := 0
+	for y.Abs().Cmp(seriesRange) > 0 {
+		y = y.Quo(two)
+		halvings++
+	}
+
+	term := One()
+	sum := One()
+	for n := int64(1); n <= seriesMaxTerms; n++ {
+		term = term.Mul(y).Quo(NewFromInt(n))
+		if term.IsZero() {
+			break
+		}
+		sum = sum.Add(term)
+	}
+
+	for ; halvings > 0; halvings-- {
+		sum = sum.Mul(sum)
+	}
+	return sum
+}
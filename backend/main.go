@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
 	"portofoliov1/api"
@@ -13,30 +20,70 @@ import (
 )
 
 type Config struct {
-	DisplayLimit   int
-	RequestTimeout time.Duration
-	RefreshMinutes time.Duration
-	StorageType    string
-	DataFolder     string
-	Chains         []string // ["osmosis"]
+	DisplayLimit     int
+	RequestTimeout   time.Duration
+	RefreshMinutes   time.Duration
+	StorageType      string
+	DataFolder       string
+	Sources          []api.DexSourceConfig // Μία πηγή pools ανά αλυσίδα (βλ. api.NewDexRegistry)
+	ChainRegistryURL string                // Από πού κατεβαίνουν assetlist/chain/versions
+	Arbitrage        api.ArbitrageConfig   // Ανίχνευση κύκλων arbitrage (/api/arbitrage)
+	ArbitrageLog     bool                  // Καταγραφή κάθε νέου κύκλου στο log
+	AlertsFile       string                // Οι κανόνες των /api/alerts (κενό = χωρίς alerts)
+	AlertSecret      string                // Κλειδί HMAC του X-Alert-Signature (κενό = χωρίς υπογραφή)
+	AlertHosts       []string              // Εσωτερικοί hosts/IPs/CIDR που επιτρέπονται ως webhooks
+	StreamOrigins    []string              // Origins που δέχεται το WebSocket του /api/stream εκτός από το ίδιο origin
 }
 
+// assetWatchInterval - Κάθε πότε ελέγχεται αν άλλαξε στο δίσκο ένα assetlist.json
+const assetWatchInterval = 30 * time.Second
+
+// shutdownTimeout - Χρόνος που δίνουμε στα ενεργά HTTP requests να ολοκληρωθούν
+const shutdownTimeout = 10 * time.Second
+
 var config = Config{
-	DisplayLimit:   25,
-	RequestTimeout: 30 * time.Second,
-	RefreshMinutes: 1 * time.Second,     // ⚡ REAL-TIME: Ανανέωση κάθε 1 δευτερόλεπτο
-	StorageType:    "memory",            // 💾 In-Memory Cache (No persistence)
-	DataFolder:     "data/database",     // Not used for memory storage
-	Chains:         []string{"osmosis"}, // Αλυσίδες που θα παρακολουθούμε
+	DisplayLimit:     25,
+	RequestTimeout:   30 * time.Second,
+	RefreshMinutes:   1 * time.Second, // ⚡ REAL-TIME: Ανανέωση κάθε 1 δευτερόλεπτο
+	StorageType:      "memory",        // 💾 "memory" (χωρίς persistence) ή "sqlite" (ιστορικό τιμών)
+	DataFolder:       "data/database", // Φάκελος της SQLite βάσης (prices.db)
+	ChainRegistryURL: utils.DefaultChainRegistryURL,
+	Arbitrage:        api.DefaultArbitrageConfig(),
+	ArbitrageLog:     false, // 🔺 true για log των νέων κύκλων arbitrage
+	AlertsFile:       "data/alerts.json",
+	AlertSecret:      os.Getenv("ALERT_WEBHOOK_SECRET"),
+	AlertHosts:       []string{}, // π.χ. "alerts.internal", "10.0.0.0/8"
+	StreamOrigins:    []string{}, // π.χ. "https://app.example.com"· "*" δέχεται κάθε origin
+	// Πηγές pools με τη σειρά εκτέλεσης: το Osmosis πρώτο, οι άλλες αλυσίδες παίρνουν τιμές από αυτό
+	Sources: []api.DexSourceConfig{
+		{Kind: api.DexKindOsmosis},
+		// {Kind: api.DexKindCrescent},
+		// {Kind: api.DexKindCosmWasm, Chain: "archway", Contract: "archway1..."}, // Factory contract
+		// {Kind: api.DexKindFile, Chain: "osmosis", Path: "data/fixtures/osmosis_pools.json"},
+	},
+}
+
+// chainCollector - Η πηγή και το assetlist μιας αλυσίδας, κοινά για όλα τα ticks ώστε να
+// διατηρούνται οι μετρήσεις των endpoints και η κατάσταση του circuit breaker
+type chainCollector struct {
+	chain  string
+	assets *types.AssetService
+	source api.DexSource
 }
 
 func main() {
-	// Initialize chain registry updater (1 φορά την εβδομάδα)
-	chainRegistryUpdater := utils.NewChainRegistryUpdater()
+	// SIGINT/SIGTERM ακυρώνουν το ctx: σταματά ο collector και κόβονται τα ενεργά LCD requests
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize chain registry updater (1 φορά την εβδομάδα) για τις αλυσίδες των πηγών
+	chainRegistryUpdater := utils.NewChainRegistryUpdaterWithConfig(utils.ChainRegistryConfig{
+		BaseURL: config.ChainRegistryURL,
+		Chains:  sourceChains(config.Sources),
+	})
 	if err := chainRegistryUpdater.Start(); err != nil {
 		log.Printf("⚠️  Προειδοποίηση: Αποτυχία εκκίνησης chain registry updater: %v", err)
 	}
-	defer chainRegistryUpdater.Stop()
 
 	// Initialize asset service from chain registry
 	assetService, err := types.NewAssetService()
@@ -44,12 +91,49 @@ func main() {
 		log.Fatalf("❌ Σφάλμα αρχικοποίησης AssetService: %v", err)
 	}
 
-	// Initialize In-Memory storage (για real-time data χωρίς persistence)
-	memoryStorage := storage.NewMemoryStorage()
-	log.Println("✅ In-Memory cache initialized")
+	// Initialize storage (in-memory cache ή SQLite με ιστορικό τιμών)
+	priceStorage, err := storage.NewPriceStorage(config.StorageType, config.DataFolder)
+	if err != nil {
+		log.Fatalf("❌ Σφάλμα αρχικοποίησης storage: %v", err)
+	}
+	log.Printf("✅ Storage initialized: %s", priceStorage.GetName())
 
 	// Initialize HTTP server με access στο memory cache
-	httpServer := api.NewHTTPServer(8080, chainRegistryUpdater, memoryStorage)
+	httpServer := api.NewHTTPServer(8080, chainRegistryUpdater, priceStorage)
+	httpServer.SetStreamAllowedOrigins(config.StreamOrigins)
+
+	// Initialize τις πηγές pools από το config: τρίτοι adapters καταχωρούνται εδώ με
+	// dexRegistry.Register πριν το BuildAll
+	dexRegistry := api.NewDexRegistry()
+	collectors := newChainCollectors(dexRegistry.BuildAll(config.Sources), assetService, httpServer)
+
+	// Hot reload των assetlists: μετά από κάθε ενημέρωση του registry και όταν αλλάξει το αρχείο
+	httpServer.SetAssetService(assetService)
+	watchAssetLists(ctx, collectors, assetService, chainRegistryUpdater, httpServer)
+
+	// Οι αλλαγές τιμών προωθούνται στους clients του /api/stream
+	priceStorage.OnPoolPricesChanged(httpServer.PublishPoolPrices)
+
+	// Κύκλοι arbitrage σε κάθε ενημέρωση τιμών (/api/arbitrage και event "arbitrage" του stream)
+	arbitrageDetector := api.NewArbitrageDetector(config.Arbitrage)
+	httpServer.SetArbitrageDetector(arbitrageDetector)
+	if config.ArbitrageLog {
+		arbitrageDetector.OnNewOpportunities(logArbitrageOpportunities)
+	}
+
+	// Alerts: οι κανόνες διαβάζονται από το AlertsFile και αξιολογούνται σε κάθε ανανέωση
+	if config.AlertsFile != "" {
+		alertEngine, err := api.NewAlertEngine(storage.NewFileAlertStore(config.AlertsFile))
+		if err != nil {
+			// Δεν συνεχίζουμε με κενούς κανόνες: η πρώτη αλλαγή θα έσβηνε το αρχείο
+			log.Printf("⚠️  Τα alerts απενεργοποιήθηκαν: %v", err)
+		} else {
+			alertEngine.SetWebhookAllowlist(config.AlertHosts)
+			alertEngine.SetWebhookSecret(config.AlertSecret)
+			alertEngine.Start(ctx)
+			httpServer.SetAlertEngine(alertEngine)
+		}
+	}
 
 	// Start HTTP server σε ξεχωριστό goroutine
 	go func() {
@@ -61,103 +145,321 @@ func main() {
 	showWelcomeMessage()
 
 	if config.RefreshMinutes > 0 {
-		startAutoRefresh(assetService, httpServer, memoryStorage)
+		startAutoRefresh(ctx, collectors, httpServer, priceStorage)
 	} else {
-		runSingleExecution(assetService, httpServer, memoryStorage)
+		runSingleExecution(ctx, collectors, httpServer, priceStorage)
 	}
+
+	shutdown(httpServer, chainRegistryUpdater, priceStorage)
+}
+
+// shutdown - Graceful τερματισμός: HTTP server, chain registry updater, flush & close του storage
+func shutdown(httpServer *api.HTTPServer, chainRegistryUpdater *utils.ChainRegistryUpdater, priceStorage storage.PriceStorage) {
+	log.Println("🛑 Τερματισμός...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️  HTTP server shutdown: %v", err)
+	}
+
+	chainRegistryUpdater.Stop()
+
+	if err := priceStorage.Flush(); err != nil {
+		log.Printf("❌ Failed to flush storage: %v", err)
+	}
+	if err := priceStorage.Close(); err != nil {
+		log.Printf("❌ Failed to close storage: %v", err)
+	}
+
+	log.Println("👋 Ο collector σταμάτησε")
 }
 
 func showWelcomeMessage() {
 	fmt.Println("🚀 Professional Osmosis Data Collector")
-	fmt.Printf("💾 Storage: In-Memory Cache (Real-time)\n")
-	fmt.Printf("⛓️  Chains: %v\n", config.Chains)
+	fmt.Printf("💾 Storage: %s (%s)\n", config.StorageType, config.DataFolder)
+	for _, source := range config.Sources {
+		fmt.Printf("⛓️  Source: %s %s\n", source.Kind, source.Chain)
+	}
 	fmt.Println("⚡ Update Interval: 1 SECOND (Real-time)")
 	fmt.Println("================================")
 }
 
-func runSingleExecution(assetService *types.AssetService, httpServer *api.HTTPServer, memoryStorage *storage.MemoryStorage) {
-	// Εκτέλεση για κάθε αλυσίδα
-	for _, chain := range config.Chains {
-		// fmt.Printf("\n🎯 ΕΠΕΞΕΡΓΑΣΙΑ ΑΛΥΣΙΔΑΣ: %s\n", strings.ToUpper(chain))
-		// fmt.Println("------------------------------")
+// sourceChains - Οι αλυσίδες των πηγών (πάντα με το osmosis, που δίνει τις τιμές USD)
+func sourceChains(sources []api.DexSourceConfig) []string {
+	chains := []string{types.DefaultChain}
+	seen := map[string]bool{types.DefaultChain: true}
+	for _, source := range sources {
+		chain := source.ChainName()
+		if chain != "" && !seen[chain] {
+			seen[chain] = true
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
 
-		_, err := fetchChainData(chain, assetService, httpServer, memoryStorage)
-		if err != nil {
-			log.Printf("❌ Σφάλμα για %s: %v", chain, err)
-			continue
+// newChainCollectors - Ένας collector ανά πηγή, με το assetlist της αλυσίδας της. Το Osmosis
+// χρησιμοποιεί το κοινό AssetService της εφαρμογής.
+func newChainCollectors(sources []api.DexSource, osmosisAssets *types.AssetService, httpServer *api.HTTPServer) []*chainCollector {
+	collectors := make([]*chainCollector, 0, len(sources))
+
+	for _, source := range sources {
+		chain := source.Chain()
+		assets := osmosisAssets
+		if chain != types.DefaultChain {
+			var err error
+			if assets, err = types.NewAssetServiceForChain(chain); err != nil {
+				log.Printf("⚠️  Η πηγή %s παραλείπεται: %v", source.Name(), err)
+				continue
+			}
 		}
+
+		if lcdSource, ok := source.(api.LCDBacked); ok {
+			httpServer.SetLCDClient(lcdSource.LCD())
+			log.Printf("✅ %s: %d LCD endpoints", source.Name(), lcdSource.LCD().Endpoints().Len())
+		} else {
+			log.Printf("✅ %s (%s)", source.Name(), chain)
+		}
+
+		collectors = append(collectors, &chainCollector{chain: chain, assets: assets, source: source})
 	}
+
+	return collectors
 }
 
-func fetchChainData(chain string, assetService *types.AssetService, httpServer *api.HTTPServer, memoryStorage *storage.MemoryStorage) ([]types.TokenInfo, error) {
-	switch chain {
-	case "osmosis":
-		return fetchOsmosisData(assetService, httpServer, memoryStorage)
-	default:
-		return nil, fmt.Errorf("μη υποστηριζόμενη αλυσίδα: %s", chain)
+// watchAssetLists - Reload του AssetService κάθε αλυσίδας όταν ο updater την ενημερώσει
+// ή όταν αλλάξει το assetlist.json της στο δίσκο. Οι collectors κρατούν τον ίδιο pointer,
+// οπότε ο επόμενος κύκλος χρησιμοποιεί ήδη τα νέα symbols και exponents.
+func watchAssetLists(ctx context.Context, collectors []*chainCollector, osmosisAssets *types.AssetService, updater *utils.ChainRegistryUpdater, httpServer *api.HTTPServer) {
+	assets := map[string]*types.AssetService{types.DefaultChain: osmosisAssets}
+	for _, collector := range collectors {
+		if collector.assets != osmosisAssets {
+			assets[collector.chain] = collector.assets
+			collector.assets.OnChange(httpServer.RecordAssetChange)
+		}
+	}
+
+	updater.OnUpdate(func(chains []string) {
+		for _, chain := range chains {
+			if service, ok := assets[chain]; ok {
+				if _, err := service.Reload(); err != nil {
+					log.Printf("⚠️  Αποτυχία reload του assetlist %s: %v", chain, err)
+				}
+			}
+		}
+	})
+
+	for _, service := range assets {
+		go service.Watch(ctx, assetWatchInterval)
 	}
 }
 
-func fetchOsmosisData(assetService *types.AssetService, httpServer *api.HTTPServer, memoryStorage *storage.MemoryStorage) ([]types.TokenInfo, error) {
-	// Αρχικοποίηση του νέου Osmosis Pool Client
-	osmosisClient := api.NewOsmosisPoolClient()
+func runSingleExecution(ctx context.Context, collectors []*chainCollector, httpServer *api.HTTPServer, priceStorage storage.PriceStorage) {
+	// Κάθε κύκλος έχει όριο χρόνου, ώστε ένας αργός node να μην κρατά τον collector
+	ctx, cancel := context.WithTimeout(ctx, config.RequestTimeout)
+	defer cancel()
 
-	// 1. Λήψη pools
-	pools, err := osmosisClient.GetAllPools(1000, 0)
-	if err != nil {
+	// Εκτέλεση για κάθε αλυσίδα με τη σειρά του config
+	for _, collector := range collectors {
+		chain := collector.chain
+		tokens, err := fetchChainData(ctx, collector, httpServer, priceStorage)
+		switch {
+		case errors.Is(err, context.Canceled):
+			// Shutdown: τα δεδομένα του προηγούμενου κύκλου μένουν ως έχουν
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Printf("⏱️  Ο κύκλος για %s ξεπέρασε τα %v και ακυρώθηκε", chain, config.RequestTimeout)
+			return
+		case err != nil:
+			log.Printf("❌ Σφάλμα για %s: %v", chain, err)
+		case tokens != nil:
+			httpServer.SetChainTokens(chain, tokens)
+		}
+	}
+}
+
+// fetchChainData - Pools → pool prices → token prices (USD/OSMO) → storage για μία αλυσίδα.
+// Επιστρέφει nil tokens όταν ο κύκλος παραλείπεται (breaker ανοιχτός, rate limit).
+func fetchChainData(ctx context.Context, collector *chainCollector, httpServer *api.HTTPServer, priceStorage storage.PriceStorage) ([]types.TokenInfo, error) {
+	// 1. Λήψη pools από την πηγή της αλυσίδας (όλες οι σελίδες)
+	pools, reports, err := collector.source.FetchPools(ctx)
+	if ctx.Err() != nil {
+		// Ακυρωμένος κύκλος: δεν ενημερώνουμε την cache
+		return nil, ctx.Err()
+	}
+	for _, report := range reports {
+		httpServer.SetPoolFetchReport(report)
+	}
+	switch {
+	case errors.Is(err, api.ErrCircuitOpen):
+		// Ο breaker έχει ήδη καταγράψει το άνοιγμα: κρατάμε τα προηγούμενα δεδομένα σιωπηλά
+		return nil, nil
+	case errors.Is(err, api.ErrRateLimited):
+		log.Printf("⏳ Rate limited από τους LCD nodes του %s, παράλειψη κύκλου: %v", collector.chain, err)
+		return nil, nil
+	case err != nil:
 		return nil, err
 	}
 
 	// 2. Υπολογισμός τιμών για ΟΛΟΥΣ τους pools (στη μνήμη)
-	poolPrices, err := osmosisClient.GetAllPoolPrices(pools, assetService)
+	poolPrices, err := api.ComputePoolPricesWithModel(pools, collector.assets, collector.source.PriceModel)
 	if err != nil {
 		fmt.Printf("   ⚠️  Προειδοποίηση: αποτυχία υπολογισμού τιμών pools: %v\n", err)
 		poolPrices = []types.PoolPrice{}
 	}
 
-	// 3. ⚡ ΑΠΟΘΗΚΕΥΣΗ ΣΕ MEMORY CACHE (Real-time)
+	// 2β. USD/OSMO τιμές για κάθε token μέσω του price graph και TVL κάθε pool
+	priceOracle := chainPriceOracle(collector, priceStorage)
+	tokenPrices := priceOracle.ComputeTokenPrices(poolPrices)
+	priceOracle.ApplyLiquidityUSD(poolPrices, tokenPrices)
+
+	// 2γ. Κύκλοι arbitrage πάνω στο ίδιο snapshot
+	if detector := httpServer.ArbitrageDetector(); detector != nil {
+		detector.Update(api.ArbitrageSnapshot{
+			Chain:       collector.chain,
+			Pools:       pools,
+			PoolPrices:  poolPrices,
+			TokenPrices: tokenPrices,
+			Assets:      collector.assets,
+			PriceModel:  collector.source.PriceModel,
+		})
+	}
+
+	// 2δ. Κανόνες alerts (τα webhooks στέλνονται στο παρασκήνιο)
+	if alertEngine := httpServer.AlertEngine(); alertEngine != nil {
+		alertEngine.Evaluate(collector.chain, poolPrices, tokenPrices)
+	}
+
+	// 3. ⚡ ΑΠΟΘΗΚΕΥΣΗ (memory cache και, για sqlite, ιστορικό)
 	// Αποθήκευση ΟΛΩΝ των pools (raw data)
-	if err := memoryStorage.SavePools(pools); err != nil {
+	if err := priceStorage.SavePools(pools); err != nil {
 		log.Printf("❌ Failed to save pools: %v", err)
 	}
 
 	// Αποθήκευση pool prices (υπολογισμένες τιμές μεταξύ tokens)
-	if err := memoryStorage.SavePoolPrices(poolPrices); err != nil {
+	if err := priceStorage.SavePoolPrices(poolPrices); err != nil {
 		log.Printf("❌ Failed to save pool prices: %v", err)
 	}
 
+	// Αποθήκευση token prices (USD & OSMO)
+	if err := priceStorage.SaveTokenPrices(tokenPrices); err != nil {
+		log.Printf("❌ Failed to save token prices: %v", err)
+	}
+
 	// Silent mode - μόνο errors
 
-	// Επιστρέφουμε κενό slice καθώς δε χρειαζόμαστε πλέον τα token infos
-	return []types.TokenInfo{}, nil
+	return chainTokenInfos(collector, tokenPrices, poolPrices), nil
 }
 
-// startAutoRefresh - Αρχή auto-refresh λειτουργίας
-func startAutoRefresh(assetService *types.AssetService, httpServer *api.HTTPServer, memoryStorage *storage.MemoryStorage) {
+// logArbitrageOpportunities - Μία γραμμή ανά νέο κύκλο arbitrage
+func logArbitrageOpportunities(opportunities []types.ArbitrageOpportunity) {
+	for _, opportunity := range opportunities {
+		log.Printf("🔺 Arbitrage %s: %s | +%.4f%% (spot %.4f%%) ≈ $%.2f",
+			opportunity.Chain, strings.Join(opportunity.Path, " → "),
+			opportunity.ProfitPct*100, opportunity.SpotReturn*100, opportunity.ProfitUSD)
+	}
+}
+
+// chainPriceOracle - Το Osmosis τιμολογείται από τα default anchors· οι άλλες αλυσίδες από τα
+// stablecoins του assetlist τους και τις τιμές του Osmosis για tokens με ίδιο symbol
+func chainPriceOracle(collector *chainCollector, priceStorage storage.PriceStorage) *api.PriceOracle {
+	if collector.chain == types.DefaultChain {
+		return api.NewPriceOracle(collector.assets)
+	}
+
+	osmosisUSD := func(symbol string) (float64, bool) {
+		price, err := priceStorage.GetTokenPrice(symbol)
+		if err != nil || (price.Chain != "" && price.Chain != types.DefaultChain) {
+			return 0, false
+		}
+		return price.PriceUSD, true
+	}
+
+	var osmoUSD float64
+	if price, ok := osmosisUSD(api.OsmoDenom); ok {
+		osmoUSD = price
+	}
+
+	return api.NewChainPriceOracle(collector.assets, api.ChainUSDAnchors(collector.assets, osmosisUSD), osmoUSD)
+}
+
+// chainTokenInfos - Ένα TokenInfo ανά τιμολογημένο token της αλυσίδας, τα πιο liquid πρώτα
+func chainTokenInfos(collector *chainCollector, tokenPrices []types.TokenPrice, poolPrices []types.PoolPrice) []types.TokenInfo {
+	pools := make(map[string]map[string]bool) // denom -> on-chain pool IDs
+	for _, price := range poolPrices {
+		for _, denom := range []string{price.Token0Denom, price.Token1Denom} {
+			if pools[denom] == nil {
+				pools[denom] = make(map[string]bool)
+			}
+			pools[denom][price.OnChainPoolID()] = true
+		}
+	}
+
+	tokens := make([]types.TokenInfo, 0, len(tokenPrices))
+	for _, price := range tokenPrices {
+		token := types.TokenInfo{
+			Denom:     price.Denom,
+			Symbol:    price.Symbol,
+			Price:     price.PriceUSD,
+			Liquidity: price.LiquidityUSD,
+			PoolCount: len(pools[price.Denom]),
+			Source:    collector.source.Name(),
+			Chain:     collector.chain,
+			LogoURI:   collector.assets.GetLogoURL(price.Denom),
+		}
+		if asset, ok := collector.assets.GetAsset(price.Denom); ok {
+			token.Name = asset.Name
+		}
+		tokens = append(tokens, token)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Liquidity > tokens[j].Liquidity
+	})
+	return tokens
+}
+
+// startAutoRefresh - Αρχή auto-refresh λειτουργίας μέχρι την ακύρωση του ctx
+func startAutoRefresh(ctx context.Context, collectors []*chainCollector, httpServer *api.HTTPServer, priceStorage storage.PriceStorage) {
 	fmt.Printf("⚡ Real-Time Mode - Update κάθε %v\n", config.RefreshMinutes)
-	fmt.Printf("💾 Storage: In-Memory Cache (No persistence)\n")
 	fmt.Println("🌐 API: http://localhost:8080")
 	fmt.Println("📊 Cache ανανεώνεται κάθε δευτερόλεπτο...")
 	fmt.Println("   Πατήστε Ctrl+C για διακοπή")
 	fmt.Println()
 
 	// Τρέχει αμέσως την πρώτη φορά
-	runSingleExecution(assetService, httpServer, memoryStorage)
+	runSingleExecution(ctx, collectors, httpServer, priceStorage)
 
 	// Δημιουργία ticker για auto-refresh
 	ticker := time.NewTicker(config.RefreshMinutes)
 	defer ticker.Stop()
 
 	executionCount := 1
+	skippedTicks := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 
-	for range ticker.C {
 		executionCount++
-		runSingleExecution(assetService, httpServer, memoryStorage)
+		runSingleExecution(ctx, collectors, httpServer, priceStorage)
+
+		// Ένας κύκλος που ξεπέρασε το interval αφήνει ένα tick σε αναμονή:
+		// το πετάμε ώστε ο επόμενος κύκλος να ξεκινήσει στο επόμενο κανονικό tick
+		select {
+		case <-ticker.C:
+			skippedTicks++
+		default:
+		}
 
 		// Κάθε 60 δευτερόλεπτα δείχνε stats
 		if executionCount%60 == 0 {
-			fmt.Printf("\n📊 [%d cache updates] - %s\n",
-				executionCount, time.Now().Format("15:04:05"))
+			fmt.Printf("\n📊 [%d cache updates, %d skipped ticks] - %s\n",
+				executionCount, skippedTicks, time.Now().Format("15:04:05"))
 		}
 	}
 }
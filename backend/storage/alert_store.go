@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"portofoliov1/types"
+)
+
+// FileAlertStore - Οι κανόνες των alerts σε ένα JSON αρχείο, ώστε να επιβιώνουν από restarts
+type FileAlertStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// alertRulesFile - Η μορφή του αρχείου
+type alertRulesFile struct {
+	Rules []types.AlertRule `json:"rules"`
+}
+
+// NewFileAlertStore - Το αρχείο δημιουργείται στην πρώτη αποθήκευση
+func NewFileAlertStore(path string) *FileAlertStore {
+	return &FileAlertStore{path: path}
+}
+
+// Path - Το αρχείο των κανόνων
+func (s *FileAlertStore) Path() string {
+	return s.path
+}
+
+// LoadAlertRules - Οι αποθηκευμένοι κανόνες (κανένας αν το αρχείο δεν υπάρχει ακόμα)
+func (s *FileAlertStore) LoadAlertRules() ([]types.AlertRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []types.AlertRule{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rules: %w", err)
+	}
+
+	var file alertRulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules %s: %w", s.path, err)
+	}
+	if file.Rules == nil {
+		file.Rules = []types.AlertRule{}
+	}
+	return file.Rules, nil
+}
+
+// SaveAlertRules αντικαθιστά ατομικά (temp file + rename) όλους τους κανόνες
+func (s *FileAlertStore) SaveAlertRules(rules []types.AlertRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(alertRulesFile{Rules: rules}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode alert rules: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create alerts folder: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to save alert rules: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op μετά το rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save alert rules: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save alert rules: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save alert rules: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save alert rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// CandleInterval - Διάρκεια candle και πόσα candles κρατάμε στη μνήμη
+type CandleInterval struct {
+	Name       string
+	Duration   time.Duration
+	MaxCandles int
+}
+
+// CandleIntervals - Υποστηριζόμενα intervals (4 ώρες 1m, 1 ημέρα 5m, 1 εβδομάδα 1h, 90 ημέρες 1d)
+var CandleIntervals = []CandleInterval{
+	{Name: "1m", Duration: time.Minute, MaxCandles: 240},
+	{Name: "5m", Duration: 5 * time.Minute, MaxCandles: 288},
+	{Name: "1h", Duration: time.Hour, MaxCandles: 168},
+	{Name: "1d", Duration: 24 * time.Hour, MaxCandles: 90},
+}
+
+// maxVolumeGap - Μετά από μεγαλύτερο κενό μεταξύ snapshots (π.χ. restart) η μεταβολή
+// των reserves δεν μετράει ως volume
+const maxVolumeGap = 5 * time.Minute
+
+// candleEvictionInterval - Κάθε πότε ελέγχουμε για σειρές χωρίς ενημερώσεις
+const candleEvictionInterval = time.Hour
+
+// candleRetention - Η μεγαλύτερη περίοδος που καλύπτει ένα interval (90 ημέρες 1d). Μια σειρά
+// χωρίς ενημέρωση για περισσότερο (π.χ. pool που αφαιρέθηκε) δεν έχει πια candles και αφαιρείται.
+var candleRetention = maxCandleRetention()
+
+func maxCandleRetention() time.Duration {
+	var retention time.Duration
+	for _, interval := range CandleIntervals {
+		retention = max(retention, interval.Duration*time.Duration(interval.MaxCandles))
+	}
+	return retention
+}
+
+// ParseCandleInterval - Επιστρέφει το interval με το συγκεκριμένο όνομα
+func ParseCandleInterval(name string) (CandleInterval, error) {
+	for _, interval := range CandleIntervals {
+		if interval.Name == name {
+			return interval, nil
+		}
+	}
+	return CandleInterval{}, fmt.Errorf("unsupported interval: %s", name)
+}
+
+// candleSeries - interval name -> candles σε χρονολογική σειρά
+type candleSeries map[string][]types.Candle
+
+// poolReserves - Τα reserves ενός pool στο προηγούμενο snapshot
+type poolReserves struct {
+	amount0   string
+	amount1   string
+	timestamp time.Time
+}
+
+// CandleAggregator - Rolling OHLCV aggregation πάνω στα snapshots του collector.
+// Η τιμή ενός pool είναι το PriceToken0ToToken1, ενός token το PriceUSD. Το volume
+// εκτιμάται από τη μεταβολή των reserves μεταξύ διαδοχικών snapshots, αποτιμημένη
+// σε USD μέσω του TVL και του weight κάθε asset.
+type CandleAggregator struct {
+	pools         map[string]candleSeries // pool_id -> series
+	tokens        map[string]candleSeries // denom -> series
+	lastReserves  map[string]poolReserves // pool_id -> reserves
+	pendingVolume map[string]float64      // denom -> volume USD μέχρι την επόμενη token price
+	lastEviction  time.Time               // Τελευταίος έλεγχος για σειρές χωρίς ενημερώσεις
+	mu            sync.RWMutex
+}
+
+// NewCandleAggregator - Δημιουργία κενού aggregator
+func NewCandleAggregator() *CandleAggregator {
+	return &CandleAggregator{
+		pools:         make(map[string]candleSeries),
+		tokens:        make(map[string]candleSeries),
+		lastReserves:  make(map[string]poolReserves),
+		pendingVolume: make(map[string]float64),
+	}
+}
+
+// AddPoolPrices - Ενημέρωση των pool candles και συσσώρευση volume ανά token
+func (a *CandleAggregator) AddPoolPrices(prices []types.PoolPrice) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Τα pairs ενός multi-asset pool μοιράζονται reserves: κάθε (pool, denom) μετράει μία φορά
+	counted := make(map[string]bool)
+	var latest time.Time
+
+	for _, price := range prices {
+		if !finite(price.PriceToken0ToToken1) || price.PriceToken0ToToken1 <= 0 {
+			continue
+		}
+
+		timestamp := price.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		if timestamp.After(latest) {
+			latest = timestamp
+		}
+
+		var volume0, volume1 float64
+		if previous, ok := a.lastReserves[price.PoolID]; ok && timestamp.Sub(previous.timestamp) <= maxVolumeGap {
+			volume0 = reserveChangeUSD(previous.amount0, price.Token0Amount, price.Token0Weight, price.LiquidityUSD)
+			volume1 = reserveChangeUSD(previous.amount1, price.Token1Amount, price.Token1Weight, price.LiquidityUSD)
+		}
+		a.lastReserves[price.PoolID] = poolReserves{amount0: price.Token0Amount, amount1: price.Token1Amount, timestamp: timestamp}
+
+		if a.pools[price.PoolID] == nil {
+			a.pools[price.PoolID] = make(candleSeries)
+		}
+		// Σε ένα swap το ένα reserve αυξάνεται και το άλλο μειώνεται κατά περίπου ίση αξία
+		a.pools[price.PoolID].add(timestamp, price.PriceToken0ToToken1, (volume0+volume1)/2)
+
+		for denom, volume := range map[string]float64{price.Token0Denom: volume0, price.Token1Denom: volume1} {
+			key := price.OnChainPoolID() + "|" + denom
+			if counted[key] {
+				continue
+			}
+			counted[key] = true
+			a.pendingVolume[denom] += volume
+		}
+	}
+
+	a.evictStale(latest)
+}
+
+// AddTokenPrices - Ενημέρωση των token candles (USD) με το volume που συσσωρεύτηκε
+func (a *CandleAggregator) AddTokenPrices(prices []types.TokenPrice) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var latest time.Time
+	for _, price := range prices {
+		if !finite(price.PriceUSD) || price.PriceUSD <= 0 {
+			continue
+		}
+
+		timestamp := price.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		if timestamp.After(latest) {
+			latest = timestamp
+		}
+
+		if a.tokens[price.Denom] == nil {
+			a.tokens[price.Denom] = make(candleSeries)
+		}
+		a.tokens[price.Denom].add(timestamp, price.PriceUSD, a.pendingVolume[price.Denom])
+		delete(a.pendingVolume, price.Denom)
+	}
+
+	a.evictStale(latest)
+}
+
+// evictStale αφαιρεί pools και tokens χωρίς ενημέρωση για περισσότερο από το candleRetention
+// (σε σχέση με το πιο πρόσφατο snapshot), μαζί με τα reserves τους. Τρέχει το πολύ μία
+// φορά ανά candleEvictionInterval.
+func (a *CandleAggregator) evictStale(now time.Time) {
+	if now.IsZero() || now.Sub(a.lastEviction) < candleEvictionInterval {
+		return
+	}
+	a.lastEviction = now
+
+	cutoff := now.Add(-candleRetention)
+	for poolID, series := range a.pools {
+		if series.lastUpdate().Before(cutoff) {
+			delete(a.pools, poolID)
+		}
+	}
+	for denom, series := range a.tokens {
+		if series.lastUpdate().Before(cutoff) {
+			delete(a.tokens, denom)
+		}
+	}
+	for poolID, reserves := range a.lastReserves {
+		if reserves.timestamp.Before(cutoff) {
+			delete(a.lastReserves, poolID)
+		}
+	}
+}
+
+// PoolCandles - Candles ενός pool στο [from, to]
+func (a *CandleAggregator) PoolCandles(poolID string, interval CandleInterval, from, to time.Time) ([]types.Candle, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	series, ok := a.pools[poolID]
+	if !ok {
+		return nil, fmt.Errorf("no candles for pool %s", poolID)
+	}
+	return series.between(interval, from, to), nil
+}
+
+// TokenCandles - Candles ενός token (denom) στο [from, to]
+func (a *CandleAggregator) TokenCandles(denom string, interval CandleInterval, from, to time.Time) ([]types.Candle, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	series, ok := a.tokens[denom]
+	if !ok {
+		return nil, fmt.Errorf("no candles for token %s", denom)
+	}
+	return series.between(interval, from, to), nil
+}
+
+// add - Ενημερώνει το τρέχον candle κάθε interval ή ανοίγει νέο
+func (s candleSeries) add(timestamp time.Time, value, volume float64) {
+	for _, interval := range CandleIntervals {
+		start := timestamp.Truncate(interval.Duration)
+		candles := s[interval.Name]
+
+		if n := len(candles); n > 0 && candles[n-1].Time.Equal(start) {
+			last := &candles[n-1]
+			last.High = math.Max(last.High, value)
+			last.Low = math.Min(last.Low, value)
+			last.Close = value
+			last.VolumeUSD += volume
+			last.Samples++
+			continue
+		}
+
+		candles = append(candles, types.Candle{
+			Time:      start,
+			Open:      value,
+			High:      value,
+			Low:       value,
+			Close:     value,
+			VolumeUSD: volume,
+			Samples:   1,
+		})
+		if len(candles) > interval.MaxCandles {
+			candles = append(candles[:0:0], candles[len(candles)-interval.MaxCandles:]...)
+		}
+		s[interval.Name] = candles
+	}
+}
+
+// lastUpdate - Η αρχή του πιο πρόσφατου candle (στο μικρότερο interval, άρα στο λεπτό)
+func (s candleSeries) lastUpdate() time.Time {
+	var latest time.Time
+	for _, candles := range s {
+		if n := len(candles); n > 0 && candles[n-1].Time.After(latest) {
+			latest = candles[n-1].Time
+		}
+	}
+	return latest
+}
+
+// between - Αντίγραφο των candles που ξεκινούν μέσα στο [from, to]
+func (s candleSeries) between(interval CandleInterval, from, to time.Time) []types.Candle {
+	result := []types.Candle{}
+	for _, candle := range s[interval.Name] {
+		if candle.Time.Before(from.Truncate(interval.Duration)) || candle.Time.After(to) {
+			continue
+		}
+		result = append(result, candle)
+	}
+	return result
+}
+
+// reserveChangeUSD - |Δreserve| αποτιμημένο σε USD: η αξία ενός asset στο pool είναι weight × TVL
+func reserveChangeUSD(previous, current string, weight, liquidityUSD float64) float64 {
+	if liquidityUSD <= 0 {
+		return 0
+	}
+	prev, err := strconv.ParseFloat(previous, 64)
+	if err != nil {
+		return 0
+	}
+	curr, err := strconv.ParseFloat(current, 64)
+	if err != nil || curr <= 0 {
+		return 0
+	}
+	if weight <= 0 {
+		weight = 0.5
+	}
+	return math.Abs(curr-prev) * weight * liquidityUSD / curr
+}
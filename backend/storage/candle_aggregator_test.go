@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// candleBase - Αρχή ενός λεπτού, ώρας και ημέρας για προβλέψιμα buckets
+var candleBase = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func candlePoolPrice(poolID string, at time.Duration, price float64, amount0, amount1 string) types.PoolPrice {
+	return types.PoolPrice{
+		PoolID:              poolID,
+		Token0Denom:         "uatom",
+		Token0Amount:        amount0,
+		Token0Weight:        0.8,
+		Token1Denom:         "uosmo",
+		Token1Amount:        amount1,
+		Token1Weight:        0.2,
+		PriceToken0ToToken1: price,
+		LiquidityUSD:        1000,
+		Timestamp:           candleBase.Add(at),
+	}
+}
+
+func mustParseInterval(t *testing.T, name string) CandleInterval {
+	t.Helper()
+	interval, err := ParseCandleInterval(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return interval
+}
+
+func TestCandleAggregatorOHLC(t *testing.T) {
+	aggregator := NewCandleAggregator()
+	for _, snapshot := range []struct {
+		at    time.Duration
+		price float64
+	}{
+		{10 * time.Second, 2},
+		{40 * time.Second, 3},
+		{45 * time.Second, 0},          // Μη έγκυρη τιμή: αγνοείται
+		{48 * time.Second, math.NaN()}, // Επίσης
+		{50 * time.Second, 1},
+		{80 * time.Second, 1.5},
+		{5*time.Minute + 30*time.Second, 4},
+	} {
+		aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("1", snapshot.at, snapshot.price, "1000", "1000")})
+	}
+
+	tests := []struct {
+		interval string
+		want     []types.Candle
+	}{
+		{"1m", []types.Candle{
+			{Time: candleBase, Open: 2, High: 3, Low: 1, Close: 1, Samples: 3},
+			{Time: candleBase.Add(time.Minute), Open: 1.5, High: 1.5, Low: 1.5, Close: 1.5, Samples: 1},
+			{Time: candleBase.Add(5 * time.Minute), Open: 4, High: 4, Low: 4, Close: 4, Samples: 1},
+		}},
+		{"5m", []types.Candle{
+			{Time: candleBase, Open: 2, High: 3, Low: 1, Close: 1.5, Samples: 4},
+			{Time: candleBase.Add(5 * time.Minute), Open: 4, High: 4, Low: 4, Close: 4, Samples: 1},
+		}},
+		{"1h", []types.Candle{{Time: candleBase, Open: 2, High: 4, Low: 1, Close: 4, Samples: 5}}},
+		{"1d", []types.Candle{{Time: candleBase.Truncate(24 * time.Hour), Open: 2, High: 4, Low: 1, Close: 4, Samples: 5}}},
+	}
+	for _, tt := range tests {
+		candles, err := aggregator.PoolCandles("1", mustParseInterval(t, tt.interval), candleBase.Add(-48*time.Hour), candleBase.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.interval, err)
+		}
+		if len(candles) != len(tt.want) {
+			t.Errorf("%s: %d candles, want %d: %+v", tt.interval, len(candles), len(tt.want), candles)
+			continue
+		}
+		for i, want := range tt.want {
+			if candles[i] != want {
+				t.Errorf("%s candle %d: %+v, want %+v", tt.interval, i, candles[i], want)
+			}
+		}
+	}
+
+	// Το [from, to] φιλτράρει με την αρχή του candle
+	candles, _ := aggregator.PoolCandles("1", mustParseInterval(t, "1m"), candleBase.Add(90*time.Second), candleBase.Add(4*time.Minute))
+	if len(candles) != 1 || !candles[0].Time.Equal(candleBase.Add(time.Minute)) {
+		t.Errorf("1m candles in range: %+v", candles)
+	}
+
+	if _, err := aggregator.PoolCandles("2", mustParseInterval(t, "1m"), candleBase, candleBase); err == nil {
+		t.Error("candles for an unknown pool")
+	}
+	if _, err := ParseCandleInterval("2m"); err == nil {
+		t.Error("unsupported interval parsed")
+	}
+}
+
+func TestCandleAggregatorMaxCandles(t *testing.T) {
+	aggregator := NewCandleAggregator()
+	interval := mustParseInterval(t, "1m")
+	for i := 0; i < interval.MaxCandles+10; i++ {
+		aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("1", time.Duration(i)*time.Minute, float64(i+1), "1000", "1000")})
+	}
+
+	candles, _ := aggregator.PoolCandles("1", interval, candleBase, candleBase.Add(24*time.Hour))
+	if len(candles) != interval.MaxCandles || candles[0].Open != 11 {
+		t.Errorf("%d candles from %v, want the latest %d", len(candles), candles[0].Open, interval.MaxCandles)
+	}
+}
+
+func TestCandleAggregatorVolume(t *testing.T) {
+	aggregator := NewCandleAggregator()
+	hour := mustParseInterval(t, "1h")
+
+	// Πρώτο snapshot: δεν υπάρχει προηγούμενο, κανένα volume
+	aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("1", 0, 2, "1000", "2000")})
+	aggregator.AddTokenPrices([]types.TokenPrice{{Denom: "uatom", PriceUSD: 10, Timestamp: candleBase}})
+
+	// |Δ| × weight × TVL / reserve: ATOM 100 × 0.8 × 1000 / 1100, OSMO 100 × 0.2 × 1000 / 1900
+	aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("1", time.Minute, 2.2, "1100", "1900")})
+	atomVolume := 100 * 0.8 * 1000 / 1100.0
+	osmoVolume := 100 * 0.2 * 1000 / 1900.0
+
+	candles, _ := aggregator.PoolCandles("1", hour, candleBase, candleBase.Add(time.Hour))
+	if len(candles) != 1 || math.Abs(candles[0].VolumeUSD-(atomVolume+osmoVolume)/2) > 1e-9 {
+		t.Errorf("pool volume %+v, want %v", candles, (atomVolume+osmoVolume)/2)
+	}
+
+	// Το volume του token πηγαίνει στην επόμενη τιμή του
+	aggregator.AddTokenPrices([]types.TokenPrice{{Denom: "uatom", PriceUSD: 11, Timestamp: candleBase.Add(time.Minute)}})
+	candles, _ = aggregator.TokenCandles("uatom", hour, candleBase, candleBase.Add(time.Hour))
+	if len(candles) != 1 || math.Abs(candles[0].VolumeUSD-atomVolume) > 1e-9 || candles[0].Close != 11 {
+		t.Errorf("token candles %+v, want volume %v", candles, atomVolume)
+	}
+
+	// Μετά από κενό μεγαλύτερο του maxVolumeGap η μεταβολή δεν μετράει
+	aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("1", time.Minute+maxVolumeGap+time.Second, 2, "5000", "500")})
+	candles, _ = aggregator.PoolCandles("1", hour, candleBase, candleBase.Add(time.Hour))
+	if math.Abs(candles[0].VolumeUSD-(atomVolume+osmoVolume)/2) > 1e-9 {
+		t.Errorf("volume %v after a gap, want unchanged %v", candles[0].VolumeUSD, (atomVolume+osmoVolume)/2)
+	}
+}
+
+func TestCandleAggregatorMultiAssetVolume(t *testing.T) {
+	aggregator := NewCandleAggregator()
+	pair := func(id, denom1 string, at time.Duration, amount0 string) types.PoolPrice {
+		price := candlePoolPrice(id, at, 1, amount0, "1000")
+		price.ParentPoolID = "7"
+		price.Token1Denom = denom1
+		return price
+	}
+
+	aggregator.AddPoolPrices([]types.PoolPrice{pair("7:0-1", "uosmo", 0, "1000"), pair("7:0-2", "uion", 0, "1000")})
+	aggregator.AddPoolPrices([]types.PoolPrice{pair("7:0-1", "uosmo", time.Minute, "1100"), pair("7:0-2", "uion", time.Minute, "1100")})
+	aggregator.AddTokenPrices([]types.TokenPrice{{Denom: "uatom", PriceUSD: 10, Timestamp: candleBase.Add(time.Minute)}})
+
+	// Τα δύο pairs μοιράζονται το ATOM reserve του pool 7: μετράει μία φορά
+	candles, _ := aggregator.TokenCandles("uatom", mustParseInterval(t, "1h"), candleBase, candleBase.Add(time.Hour))
+	if want := 100 * 0.8 * 1000 / 1100.0; len(candles) != 1 || math.Abs(candles[0].VolumeUSD-want) > 1e-9 {
+		t.Errorf("token candles %+v, want volume %v", candles, want)
+	}
+}
+
+func TestReserveChangeUSD(t *testing.T) {
+	tests := []struct {
+		name              string
+		previous, current string
+		weight, liquidity float64
+		want              float64
+	}{
+		{"increase", "1000", "1100", 0.5, 1000, 100 * 0.5 * 1000 / 1100.0},
+		{"decrease", "1000", "800", 0.2, 1000, 200 * 0.2 * 1000 / 800.0},
+		{"default weight", "1000", "1100", 0, 1000, 100 * 0.5 * 1000 / 1100.0},
+		{"unchanged", "1000", "1000", 0.5, 1000, 0},
+		{"no liquidity", "1000", "1100", 0.5, 0, 0},
+		{"empty reserve", "1000", "0", 0.5, 1000, 0},
+		{"invalid amount", "abc", "1100", 0.5, 1000, 0},
+	}
+	for _, tt := range tests {
+		if got := reserveChangeUSD(tt.previous, tt.current, tt.weight, tt.liquidity); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCandleAggregatorEvictsStaleSeries(t *testing.T) {
+	aggregator := NewCandleAggregator()
+	minute := mustParseInterval(t, "1m")
+
+	aggregator.AddPoolPrices([]types.PoolPrice{
+		candlePoolPrice("removed", 0, 1, "1000", "1000"),
+		candlePoolPrice("live", 0, 1, "1000", "1000"),
+	})
+	aggregator.AddTokenPrices([]types.TokenPrice{
+		{Denom: "udelisted", PriceUSD: 1, Timestamp: candleBase},
+		{Denom: "uatom", PriceUSD: 10, Timestamp: candleBase},
+	})
+
+	// Μέσα στο retention οι σειρές μένουν
+	within := candleRetention - 24*time.Hour
+	aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("live", within, 1, "1000", "1000")})
+	aggregator.AddTokenPrices([]types.TokenPrice{{Denom: "uatom", PriceUSD: 10, Timestamp: candleBase.Add(within)}})
+	if _, err := aggregator.PoolCandles("removed", minute, candleBase, candleBase); err != nil {
+		t.Fatalf("pool evicted within the retention: %v", err)
+	}
+
+	after := candleRetention + time.Hour
+	aggregator.AddPoolPrices([]types.PoolPrice{candlePoolPrice("live", after, 1, "1000", "1000")})
+	aggregator.AddTokenPrices([]types.TokenPrice{{Denom: "uatom", PriceUSD: 10, Timestamp: candleBase.Add(after)}})
+
+	if _, err := aggregator.PoolCandles("removed", minute, candleBase, candleBase); err == nil {
+		t.Error("stale pool series kept")
+	}
+	if _, err := aggregator.TokenCandles("udelisted", minute, candleBase, candleBase); err == nil {
+		t.Error("stale token series kept")
+	}
+	if _, ok := aggregator.lastReserves["removed"]; ok {
+		t.Error("stale pool reserves kept")
+	}
+	if _, err := aggregator.PoolCandles("live", minute, candleBase, candleBase.Add(after)); err != nil {
+		t.Errorf("live pool evicted: %v", err)
+	}
+	if _, err := aggregator.TokenCandles("uatom", minute, candleBase, candleBase.Add(after)); err != nil {
+		t.Errorf("live token evicted: %v", err)
+	}
+}
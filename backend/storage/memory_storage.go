@@ -3,6 +3,7 @@ package storage
 import (
 	"fmt"
 	"portofoliov1/types"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,17 +13,28 @@ type MemoryStorage struct {
 	pools      map[string]types.OsmosisPool // pool_id -> pool
 	poolPrices map[string]types.PoolPrice   // pool_id -> latest price
 	tokenPools map[string][]string          // token_symbol -> []pool_ids
-	lastUpdate time.Time
-	mu         sync.RWMutex // Thread-safe access
+	// Token prices από το PriceOracle
+	tokenPrices  map[string]types.TokenPrice // denom -> latest price
+	symbolDenoms map[string]string           // UPPER(symbol) -> denom με τη μεγαλύτερη liquidity
+	candles      *CandleAggregator           // Rolling OHLCV ανά pool και token
+	listeners    []PoolPriceListener         // Ειδοποιούνται για αλλαγές τιμών
+	lastUpdate   time.Time
+	mu           sync.RWMutex // Thread-safe access
 }
 
+// PoolPriceListener - Callback με τις pool prices που άλλαξαν σε ένα SavePoolPrices
+type PoolPriceListener func(changed []types.PoolPrice)
+
 // NewMemoryStorage - Δημιουργία νέου in-memory storage
 func NewMemoryStorage() *MemoryStorage {
 	return &MemoryStorage{
-		pools:      make(map[string]types.OsmosisPool),
-		poolPrices: make(map[string]types.PoolPrice),
-		tokenPools: make(map[string][]string),
-		lastUpdate: time.Now(),
+		pools:        make(map[string]types.OsmosisPool),
+		poolPrices:   make(map[string]types.PoolPrice),
+		tokenPools:   make(map[string][]string),
+		tokenPrices:  make(map[string]types.TokenPrice),
+		symbolDenoms: make(map[string]string),
+		candles:      NewCandleAggregator(),
+		lastUpdate:   time.Now(),
 	}
 }
 
@@ -39,18 +51,22 @@ func (m *MemoryStorage) SavePools(pools []types.OsmosisPool) error {
 	return nil
 }
 
-// SavePoolPrices - Αποθήκευση pool prices στη μνήμη
+// SavePoolPrices - Αποθήκευση pool prices στη μνήμη και ειδοποίηση των listeners.
+// Κάθε αλυσίδα αποθηκεύεται χωριστά, οπότε το token->pools index ξαναχτίζεται από όλες.
 func (m *MemoryStorage) SavePoolPrices(prices []types.PoolPrice) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Clear old token->pools mappings
-	m.tokenPools = make(map[string][]string)
 
+	var changed []types.PoolPrice
 	for _, price := range prices {
+		if previous, ok := m.poolPrices[price.PoolID]; !ok || poolPriceChanged(previous, price) {
+			changed = append(changed, price)
+		}
 		m.poolPrices[price.PoolID] = price
+	}
 
-		// Build token->pools index
+	// Build token->pools index
+	m.tokenPools = make(map[string][]string)
+	for _, price := range m.poolPrices {
 		if price.Token0Symbol != "" {
 			m.tokenPools[price.Token0Symbol] = append(m.tokenPools[price.Token0Symbol], price.PoolID)
 		}
@@ -59,10 +75,50 @@ func (m *MemoryStorage) SavePoolPrices(prices []types.PoolPrice) error {
 		}
 	}
 
+	m.candles.AddPoolPrices(prices)
+
 	m.lastUpdate = time.Now()
+	listeners := m.listeners
+	m.mu.Unlock()
+
+	// Εκτός lock ώστε οι listeners να μπορούν να διαβάσουν το storage
+	if len(changed) > 0 {
+		for _, listener := range listeners {
+			listener(changed)
+		}
+	}
 	return nil
 }
 
+// OnPoolPricesChanged - Εγγραφή listener για τις αλλαγές τιμών μετά από κάθε SavePoolPrices
+func (m *MemoryStorage) OnPoolPricesChanged(listener PoolPriceListener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.listeners = append(m.listeners, listener)
+}
+
+// poolPriceChanged - Άλλαξε η τιμή ή τα reserves του pool;
+func poolPriceChanged(previous, current types.PoolPrice) bool {
+	return previous.PriceToken0ToToken1 != current.PriceToken0ToToken1 ||
+		previous.PriceToken1ToToken0 != current.PriceToken1ToToken0 ||
+		previous.Token0Amount != current.Token0Amount ||
+		previous.Token1Amount != current.Token1Amount
+}
+
+// GetPool - Επιστρέφει το raw pool για ένα pool_id
+func (m *MemoryStorage) GetPool(poolID string) (*types.OsmosisPool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pool, exists := m.pools[poolID]
+	if !exists {
+		return nil, fmt.Errorf("pool %s not found", poolID)
+	}
+
+	return &pool, nil
+}
+
 // GetAllPoolsForToken - Επιστρέφει όλα τα pools που περιέχουν ένα token
 func (m *MemoryStorage) GetAllPoolsForToken(symbol string) ([]types.PoolPrice, error) {
 	m.mu.RLock()
@@ -109,18 +165,37 @@ func (m *MemoryStorage) GetDatabaseStats() (map[string]interface{}, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	poolsByType := make(map[string]int)
+	poolsByChain := make(map[string]int)
+	for _, pool := range m.pools {
+		poolsByType[pool.PoolType()]++
+		chain := pool.Chain
+		if chain == "" {
+			chain = types.DefaultChain
+		}
+		poolsByChain[chain]++
+	}
+
 	stats := map[string]interface{}{
-		"storage_type":      "in-memory",
-		"pools_count":       len(m.pools),
-		"pool_prices_count": len(m.poolPrices),
-		"tokens_count":      len(m.tokenPools),
-		"last_update":       m.lastUpdate,
-		"uptime_seconds":    time.Since(m.lastUpdate).Seconds(),
+		"storage_type":       "in-memory",
+		"pools_count":        len(m.pools),
+		"pools_by_type":      poolsByType,
+		"pools_by_chain":     poolsByChain,
+		"pool_prices_count":  len(m.poolPrices),
+		"tokens_count":       len(m.tokenPools),
+		"token_prices_count": len(m.tokenPrices),
+		"last_update":        m.lastUpdate,
+		"uptime_seconds":     time.Since(m.lastUpdate).Seconds(),
 	}
 
 	return stats, nil
 }
 
+// Flush - No-op για in-memory storage (δεν υπάρχει κάτι να γραφτεί)
+func (m *MemoryStorage) Flush() error {
+	return nil
+}
+
 // Close - No-op για in-memory storage
 func (m *MemoryStorage) Close() error {
 	return nil
@@ -136,24 +211,108 @@ func (m *MemoryStorage) Save(tokens []types.TokenInfo) error {
 	return nil
 }
 
-// SaveTokenPrices - Not implemented για τώρα
+// SaveTokenPrices - Αποθήκευση token prices (USD/OSMO) στη μνήμη. Οι τιμές αντικαθιστούν
+// μόνο όσες προέρχονται από τις ίδιες αλυσίδες, ώστε κάθε αλυσίδα να ενημερώνεται χωριστά.
 func (m *MemoryStorage) SaveTokenPrices(prices []types.TokenPrice) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	chains := make(map[string]bool)
+	for _, price := range prices {
+		chains[price.Chain] = true
+	}
+	for denom, price := range m.tokenPrices {
+		if chains[price.Chain] {
+			delete(m.tokenPrices, denom)
+		}
+	}
+	for _, price := range prices {
+		m.tokenPrices[price.Denom] = price
+	}
+
+	m.symbolDenoms = make(map[string]string, len(m.tokenPrices))
+	for _, price := range m.tokenPrices {
+		// Για symbols με πολλά denoms (π.χ. USDC) κρατάμε το πιο liquid, με προτεραιότητα στο Osmosis
+		key := strings.ToUpper(price.Symbol)
+		if denom, exists := m.symbolDenoms[key]; exists && !preferTokenPrice(price, m.tokenPrices[denom]) {
+			continue
+		}
+		m.symbolDenoms[key] = price.Denom
+	}
+
+	m.candles.AddTokenPrices(prices)
+
+	m.lastUpdate = time.Now()
 	return nil
 }
 
-// GetLatestTokenPrices - Not implemented για in-memory (δεν χρειάζεται)
+// preferTokenPrice - Η τιμή του Osmosis (ή χωρίς αλυσίδα, από παλιό ιστορικό) προηγείται,
+// αλλιώς κερδίζει η μεγαλύτερη liquidity
+func preferTokenPrice(candidate, current types.TokenPrice) bool {
+	candidateDefault := candidate.Chain == "" || candidate.Chain == types.DefaultChain
+	currentDefault := current.Chain == "" || current.Chain == types.DefaultChain
+	if candidateDefault != currentDefault {
+		return candidateDefault
+	}
+	return candidate.LiquidityUSD > current.LiquidityUSD
+}
+
+// GetPoolCandles - OHLCV candles ενός pool (τιμή token0 -> token1)
+func (m *MemoryStorage) GetPoolCandles(poolID, interval string, from, to time.Time) ([]types.Candle, error) {
+	candleInterval, err := ParseCandleInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	return m.candles.PoolCandles(poolID, candleInterval, from, to)
+}
+
+// GetTokenCandles - OHLCV candles ενός token σε USD (symbol ή denom)
+func (m *MemoryStorage) GetTokenCandles(symbol, interval string, from, to time.Time) ([]types.Candle, error) {
+	candleInterval, err := ParseCandleInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	price, err := m.GetTokenPrice(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return m.candles.TokenCandles(price.Denom, candleInterval, from, to)
+}
+
+// GetLatestTokenPrices - Επιστρέφει όλες τις τελευταίες token prices
 func (m *MemoryStorage) GetLatestTokenPrices() ([]types.TokenPrice, error) {
-	return []types.TokenPrice{}, nil
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]types.TokenPrice, 0, len(m.tokenPrices))
+	for _, price := range m.tokenPrices {
+		result = append(result, price)
+	}
+
+	return result, nil
 }
 
-// GetTokenPrice - Not implemented για in-memory
+// GetTokenPrice - Επιστρέφει την τιμή ενός token με βάση symbol ή denom
 func (m *MemoryStorage) GetTokenPrice(symbol string) (*types.TokenPrice, error) {
-	return nil, fmt.Errorf("not implemented for in-memory storage")
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if price, ok := m.tokenPrices[symbol]; ok {
+		return &price, nil
+	}
+
+	denom, ok := m.symbolDenoms[strings.ToUpper(symbol)]
+	if !ok {
+		return nil, fmt.Errorf("no price found for token %s", symbol)
+	}
+
+	price := m.tokenPrices[denom]
+	return &price, nil
 }
 
-// GetTokenPriceFromPools - Not implemented για in-memory
+// GetTokenPriceFromPools - Οι τιμές προέρχονται ήδη από τα pools (PriceOracle)
 func (m *MemoryStorage) GetTokenPriceFromPools(symbol string) (*types.TokenPrice, error) {
-	return nil, fmt.Errorf("not implemented for in-memory storage")
+	return m.GetTokenPrice(symbol)
 }
 
 // GetAllUniqueTokens - Επιστρέφει όλα τα unique tokens από τα pools
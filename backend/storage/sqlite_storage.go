@@ -0,0 +1,644 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"portofoliov1/types"
+
+	_ "modernc.org/sqlite" // Pure-Go SQLite driver
+)
+
+// Αναλύσεις (resolutions) του ιστορικού
+const (
+	ResolutionRaw    = "raw"
+	ResolutionHourly = "1h"
+	ResolutionDaily  = "1d"
+)
+
+// RetentionPolicy - Πολιτική διατήρησης και downsampling του ιστορικού
+type RetentionPolicy struct {
+	SnapshotInterval    time.Duration // Ελάχιστη απόσταση μεταξύ αποθηκευμένων raw snapshots
+	RawRetention        time.Duration // Πόσο κρατάμε τα raw snapshots πριν γίνουν hourly
+	HourlyRetention     time.Duration // Πόσο κρατάμε τα hourly πριν γίνουν daily
+	DailyRetention      time.Duration // Πόσο κρατάμε τα daily (0 = για πάντα)
+	MaintenanceInterval time.Duration // Κάθε πότε τρέχει το downsampling/cleanup
+}
+
+// DefaultRetentionPolicy - 1 snapshot/λεπτό για 7 ημέρες, hourly για 90 ημέρες, daily για 2 χρόνια
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		SnapshotInterval:    1 * time.Minute,
+		RawRetention:        7 * 24 * time.Hour,
+		HourlyRetention:     90 * 24 * time.Hour,
+		DailyRetention:      2 * 365 * 24 * time.Hour,
+		MaintenanceInterval: 1 * time.Hour,
+	}
+}
+
+// SQLiteStorage - Persistent αποθήκευση ιστορικού τιμών σε SQLite (pure Go).
+// Οι τελευταίες τιμές σερβίρονται από το ενσωματωμένο MemoryStorage, ενώ κάθε
+// snapshot γράφεται και στη βάση ως time series.
+type SQLiteStorage struct {
+	*MemoryStorage
+	db     *sql.DB
+	path   string
+	policy RetentionPolicy
+
+	mu                sync.Mutex
+	lastPoolSnapshot  map[string]time.Time // chain -> τελευταίο snapshot στο ιστορικό
+	lastTokenSnapshot map[string]time.Time
+	stopChan          chan struct{}
+	wg                sync.WaitGroup
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS pool_price_history (
+	resolution    TEXT    NOT NULL,
+	bucket        INTEGER NOT NULL,
+	pool_id       TEXT    NOT NULL,
+	parent_pool_id TEXT   NOT NULL DEFAULT '',
+	pool_type     TEXT    NOT NULL DEFAULT '',
+	token0_symbol TEXT    NOT NULL,
+	token0_denom  TEXT    NOT NULL,
+	token0_amount TEXT    NOT NULL,
+	token1_symbol TEXT    NOT NULL,
+	token1_denom  TEXT    NOT NULL,
+	token1_amount TEXT    NOT NULL,
+	token0_weight REAL    NOT NULL DEFAULT 0,
+	token1_weight REAL    NOT NULL DEFAULT 0,
+	price_0_1     REAL    NOT NULL,
+	price_1_0     REAL    NOT NULL,
+	liquidity_usd REAL    NOT NULL DEFAULT 0,
+	chain         TEXT    NOT NULL DEFAULT 'osmosis',
+	price_0_1_exact TEXT  NOT NULL DEFAULT '',
+	price_1_0_exact TEXT  NOT NULL DEFAULT '',
+	PRIMARY KEY (resolution, pool_id, bucket)
+);
+CREATE INDEX IF NOT EXISTS idx_pool_history_bucket ON pool_price_history (resolution, bucket);
+
+CREATE TABLE IF NOT EXISTS token_price_history (
+	resolution    TEXT    NOT NULL,
+	bucket        INTEGER NOT NULL,
+	denom         TEXT    NOT NULL,
+	symbol        TEXT    NOT NULL,
+	price_usd     REAL    NOT NULL,
+	price_osmo    REAL    NOT NULL,
+	liquidity_usd REAL    NOT NULL DEFAULT 0,
+	chain         TEXT    NOT NULL DEFAULT 'osmosis',
+	PRIMARY KEY (resolution, denom, bucket)
+);
+CREATE INDEX IF NOT EXISTS idx_token_history_bucket ON token_price_history (resolution, bucket);
+CREATE INDEX IF NOT EXISTS idx_token_history_symbol ON token_price_history (resolution, symbol, bucket);
+
+CREATE TABLE IF NOT EXISTS downsample_watermark (
+	resolution    TEXT    PRIMARY KEY,
+	bucket        INTEGER NOT NULL
+);
+`
+
+// NewSQLiteStorage - Άνοιγμα (ή δημιουργία) της βάσης στο dataFolder
+func NewSQLiteStorage(dataFolder string, policy RetentionPolicy) (*SQLiteStorage, error) {
+	if err := os.MkdirAll(dataFolder, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data folder: %w", err)
+	}
+
+	path := filepath.Join(dataFolder, "prices.db")
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_pragma=busy_timeout(5000)", filepath.ToSlash(path))
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// Ένας writer αρκεί και αποφεύγει "database is locked"
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	// Βάσεις από εκδόσεις χωρίς multi-chain: οι υπάρχουσες εγγραφές είναι του Osmosis
+	for _, table := range []string{"pool_price_history", "token_price_history"} {
+		if err := ensureColumn(db, table, "chain", "TEXT NOT NULL DEFAULT 'osmosis'"); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	// Ακριβείς τιμές (decimal strings)· οι εγγραφές παλαιότερων εκδόσεων μένουν κενές
+	for _, column := range []string{"price_0_1_exact", "price_1_0_exact"} {
+		if err := ensureColumn(db, "pool_price_history", column, "TEXT NOT NULL DEFAULT ''"); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	s := &SQLiteStorage{
+		MemoryStorage:     NewMemoryStorage(),
+		db:                db,
+		path:              path,
+		policy:            policy,
+		lastPoolSnapshot:  make(map[string]time.Time),
+		lastTokenSnapshot: make(map[string]time.Time),
+		stopChan:          make(chan struct{}),
+	}
+
+	if err := s.loadLatestSnapshot(); err != nil {
+		log.Printf("⚠️  Αποτυχία φόρτωσης τελευταίου snapshot: %v", err)
+	}
+
+	s.wg.Add(1)
+	go s.maintenanceLoop()
+
+	return s, nil
+}
+
+// SavePoolPrices - Ενημέρωση cache και, ανά SnapshotInterval και αλυσίδα, εγγραφή στο ιστορικό
+func (s *SQLiteStorage) SavePoolPrices(prices []types.PoolPrice) error {
+	if err := s.MemoryStorage.SavePoolPrices(prices); err != nil {
+		return err
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if !s.snapshotDue(s.lastPoolSnapshot, prices[0].Chain, now) {
+		return nil
+	}
+	return s.insertPoolPrices(ResolutionRaw, s.bucket(now), prices)
+}
+
+// SaveTokenPrices - Ενημέρωση cache και, ανά SnapshotInterval και αλυσίδα, εγγραφή στο ιστορικό
+func (s *SQLiteStorage) SaveTokenPrices(prices []types.TokenPrice) error {
+	if err := s.MemoryStorage.SaveTokenPrices(prices); err != nil {
+		return err
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if !s.snapshotDue(s.lastTokenSnapshot, prices[0].Chain, now) {
+		return nil
+	}
+	return s.insertTokenPrices(ResolutionRaw, s.bucket(now), prices)
+}
+
+// snapshotDue - Πέρασε το SnapshotInterval από το τελευταίο snapshot της αλυσίδας;
+// Κάθε αλυσίδα αποθηκεύεται με δικό της Save, οπότε το throttling είναι ανά αλυσίδα.
+func (s *SQLiteStorage) snapshotDue(last map[string]time.Time, chain string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(last[chain]) < s.policy.SnapshotInterval {
+		return false
+	}
+	last[chain] = now
+	return true
+}
+
+// ensureColumn - Προσθήκη στήλης σε υπάρχοντα πίνακα (migration παλαιότερων βάσεων)
+func ensureColumn(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// bucket - Unix timestamp στρογγυλεμένο στο SnapshotInterval
+func (s *SQLiteStorage) bucket(t time.Time) int64 {
+	interval := s.policy.SnapshotInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return t.Truncate(interval).Unix()
+}
+
+func (s *SQLiteStorage) insertPoolPrices(resolution string, bucket int64, prices []types.PoolPrice) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO pool_price_history (
+		resolution, bucket, pool_id, parent_pool_id, pool_type,
+		token0_symbol, token0_denom, token0_amount,
+		token1_symbol, token1_denom, token1_amount,
+		token0_weight, token1_weight, price_0_1, price_1_0, liquidity_usd, chain,
+		price_0_1_exact, price_1_0_exact
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range prices {
+		if !finite(p.PriceToken0ToToken1) || !finite(p.PriceToken1ToToken0) {
+			continue
+		}
+		if _, err := stmt.Exec(resolution, bucket, p.PoolID, p.ParentPoolID, p.PoolType,
+			p.Token0Symbol, p.Token0Denom, p.Token0Amount,
+			p.Token1Symbol, p.Token1Denom, p.Token1Amount,
+			p.Token0Weight, p.Token1Weight, p.PriceToken0ToToken1, p.PriceToken1ToToken0, p.LiquidityUSD, chainOrDefault(p.Chain),
+			p.Price0To1Exact, p.Price1To0Exact); err != nil {
+			return fmt.Errorf("failed to insert pool price: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStorage) insertTokenPrices(resolution string, bucket int64, prices []types.TokenPrice) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO token_price_history (
+		resolution, bucket, denom, symbol, price_usd, price_osmo, liquidity_usd, chain
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range prices {
+		if !finite(p.PriceUSD) || !finite(p.PriceOSMO) {
+			continue
+		}
+		if _, err := stmt.Exec(resolution, bucket, p.Denom, p.Symbol, p.PriceUSD, p.PriceOSMO, p.LiquidityUSD, chainOrDefault(p.Chain)); err != nil {
+			return fmt.Errorf("failed to insert token price: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// loadLatestSnapshot - Φόρτωση του τελευταίου raw snapshot κάθε αλυσίδας στη μνήμη μετά από restart
+func (s *SQLiteStorage) loadLatestSnapshot() error {
+	poolPrices, err := s.queryPoolPrices(`resolution = ? AND bucket = (SELECT MAX(h.bucket) FROM pool_price_history h
+		WHERE h.resolution = ? AND h.chain = pool_price_history.chain)`,
+		ResolutionRaw, ResolutionRaw)
+	if err != nil {
+		return err
+	}
+	tokenPrices, err := s.queryTokenPrices(`resolution = ? AND bucket = (SELECT MAX(h.bucket) FROM token_price_history h
+		WHERE h.resolution = ? AND h.chain = token_price_history.chain)`,
+		ResolutionRaw, ResolutionRaw)
+	if err != nil {
+		return err
+	}
+
+	if len(poolPrices) > 0 {
+		if err := s.MemoryStorage.SavePoolPrices(poolPrices); err != nil {
+			return err
+		}
+	}
+	if len(tokenPrices) > 0 {
+		if err := s.MemoryStorage.SaveTokenPrices(tokenPrices); err != nil {
+			return err
+		}
+	}
+
+	if len(poolPrices) > 0 || len(tokenPrices) > 0 {
+		log.Printf("✅ Φορτώθηκαν %d pool prices και %d token prices από %s", len(poolPrices), len(tokenPrices), s.path)
+	}
+	return nil
+}
+
+// GetPoolPriceHistory - Ιστορικό ενός pool στο διάστημα [from, to] με την καταλληλότερη ανάλυση
+func (s *SQLiteStorage) GetPoolPriceHistory(poolID string, from, to time.Time) ([]types.PoolPrice, error) {
+	return s.queryPoolPrices(`resolution = ? AND pool_id = ? AND bucket BETWEEN ? AND ? ORDER BY bucket`,
+		s.resolutionFor(from), poolID, from.Unix(), to.Unix())
+}
+
+// GetTokenPriceHistory - Ιστορικό ενός token (symbol ή denom) στο διάστημα [from, to]
+func (s *SQLiteStorage) GetTokenPriceHistory(symbol string, from, to time.Time) ([]types.TokenPrice, error) {
+	denom := symbol
+	if price, err := s.MemoryStorage.GetTokenPrice(symbol); err == nil {
+		denom = price.Denom
+	}
+	return s.queryTokenPrices(`resolution = ? AND denom = ? AND bucket BETWEEN ? AND ? ORDER BY bucket`,
+		s.resolutionFor(from), denom, from.Unix(), to.Unix())
+}
+
+// resolutionFor - Η λεπτομερέστερη ανάλυση που καλύπτει ακόμα το from
+func (s *SQLiteStorage) resolutionFor(from time.Time) string {
+	age := time.Since(from)
+	switch {
+	case age <= s.policy.RawRetention:
+		return ResolutionRaw
+	case age <= s.policy.HourlyRetention:
+		return ResolutionHourly
+	default:
+		return ResolutionDaily
+	}
+}
+
+func (s *SQLiteStorage) queryPoolPrices(where string, args ...interface{}) ([]types.PoolPrice, error) {
+	rows, err := s.db.Query(`SELECT bucket, pool_id, parent_pool_id, pool_type,
+		token0_symbol, token0_denom, token0_amount,
+		token1_symbol, token1_denom, token1_amount,
+		token0_weight, token1_weight, price_0_1, price_1_0, liquidity_usd, chain,
+		price_0_1_exact, price_1_0_exact
+		FROM pool_price_history WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pool prices: %w", err)
+	}
+	defer rows.Close()
+
+	var result []types.PoolPrice
+	for rows.Next() {
+		var p types.PoolPrice
+		var bucket int64
+		if err := rows.Scan(&bucket, &p.PoolID, &p.ParentPoolID, &p.PoolType,
+			&p.Token0Symbol, &p.Token0Denom, &p.Token0Amount,
+			&p.Token1Symbol, &p.Token1Denom, &p.Token1Amount,
+			&p.Token0Weight, &p.Token1Weight, &p.PriceToken0ToToken1, &p.PriceToken1ToToken0, &p.LiquidityUSD, &p.Chain,
+			&p.Price0To1Exact, &p.Price1To0Exact); err != nil {
+			return nil, fmt.Errorf("failed to scan pool price: %w", err)
+		}
+		p.PriceOSMO = p.PriceToken0ToToken1
+		p.Timestamp = time.Unix(bucket, 0)
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *SQLiteStorage) queryTokenPrices(where string, args ...interface{}) ([]types.TokenPrice, error) {
+	rows, err := s.db.Query(`SELECT bucket, denom, symbol, price_usd, price_osmo, liquidity_usd, chain
+		FROM token_price_history WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query token prices: %w", err)
+	}
+	defer rows.Close()
+
+	var result []types.TokenPrice
+	for rows.Next() {
+		var p types.TokenPrice
+		var bucket int64
+		if err := rows.Scan(&bucket, &p.Denom, &p.Symbol, &p.PriceUSD, &p.PriceOSMO, &p.LiquidityUSD, &p.Chain); err != nil {
+			return nil, fmt.Errorf("failed to scan token price: %w", err)
+		}
+		p.Timestamp = time.Unix(bucket, 0)
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+// maintenanceLoop - Περιοδικό downsampling και εφαρμογή retention
+func (s *SQLiteStorage) maintenanceLoop() {
+	defer s.wg.Done()
+
+	interval := s.policy.MaintenanceInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.RunMaintenance(time.Now()); err != nil {
+				log.Printf("⚠️  SQLite maintenance απέτυχε: %v", err)
+			}
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// RunMaintenance - raw → hourly → daily downsampling και διαγραφή παλιών δεδομένων.
+// Συναθροίζονται μόνο buckets που έκλεισαν μετά την προηγούμενη εκτέλεση: το τέλος του
+// τελευταίου παραθύρου κάθε ανάλυσης μένει στο downsample_watermark.
+func (s *SQLiteStorage) RunMaintenance(now time.Time) error {
+	steps := []struct {
+		source, target string
+		size           time.Duration
+		retention      time.Duration
+	}{
+		{ResolutionRaw, ResolutionHourly, time.Hour, s.policy.RawRetention},
+		{ResolutionHourly, ResolutionDaily, 24 * time.Hour, s.policy.HourlyRetention},
+	}
+
+	for _, step := range steps {
+		// Χωρίς watermark (νέα βάση) συναθροίζονται όλα τα δεδομένα πριν διαγραφούν
+		windowEnd := now.Truncate(step.size).Unix()
+		windowStart, err := s.downsampleWatermark(step.target)
+		if err != nil {
+			return err
+		}
+
+		if windowStart < windowEnd {
+			if err := s.downsample(step.source, step.target, int64(step.size.Seconds()), windowStart, windowEnd); err != nil {
+				return err
+			}
+		}
+		if err := s.deleteOlderThan(step.source, now.Add(-step.retention).Unix()); err != nil {
+			return err
+		}
+	}
+
+	if s.policy.DailyRetention > 0 {
+		if err := s.deleteOlderThan(ResolutionDaily, now.Add(-s.policy.DailyRetention).Unix()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// downsampleWatermark - Μέχρι ποιο bucket (αποκλειστικά) έχει συναθροιστεί η ανάλυση
+func (s *SQLiteStorage) downsampleWatermark(resolution string) (int64, error) {
+	var bucket int64
+	err := s.db.QueryRow(`SELECT bucket FROM downsample_watermark WHERE resolution = ?`, resolution).Scan(&bucket)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s watermark: %w", resolution, err)
+	}
+	return bucket, nil
+}
+
+// downsample - Συνάθροιση των buckets [start, end) της source ανάλυσης στην target και
+// μετακίνηση του watermark, σε μία transaction
+func (s *SQLiteStorage) downsample(source, target string, size, start, end int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := downsamplePools(tx, source, target, size, start, end); err != nil {
+		return err
+	}
+	if err := downsampleTokens(tx, source, target, size, start, end); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO downsample_watermark (resolution, bucket) VALUES (?, ?)`, target, end); err != nil {
+		return fmt.Errorf("failed to store %s watermark: %w", target, err)
+	}
+	return tx.Commit()
+}
+
+// downsamplePools - Κάθε pool κρατά το τελευταίο snapshot του bucket: τα reserves είναι
+// TEXT (big ints) και δεν συναθροίζονται, και οι ακριβείς τιμές μένουν συνεπείς με αυτά
+func downsamplePools(tx *sql.Tx, source, target string, size, start, end int64) error {
+	_, err := tx.Exec(`INSERT OR REPLACE INTO pool_price_history (
+		resolution, bucket, pool_id, parent_pool_id, pool_type,
+		token0_symbol, token0_denom, token0_amount,
+		token1_symbol, token1_denom, token1_amount,
+		token0_weight, token1_weight, price_0_1, price_1_0, liquidity_usd, chain,
+		price_0_1_exact, price_1_0_exact
+	)
+	SELECT ?, g.target_bucket, h.pool_id, h.parent_pool_id, h.pool_type,
+		h.token0_symbol, h.token0_denom, h.token0_amount,
+		h.token1_symbol, h.token1_denom, h.token1_amount,
+		h.token0_weight, h.token1_weight, h.price_0_1, h.price_1_0, h.liquidity_usd, h.chain,
+		h.price_0_1_exact, h.price_1_0_exact
+	FROM (
+		SELECT pool_id, (bucket / ?) * ? AS target_bucket, MAX(bucket) AS last_bucket
+		FROM pool_price_history
+		WHERE resolution = ? AND bucket >= ? AND bucket < ?
+		GROUP BY pool_id, bucket / ?
+	) g
+	JOIN pool_price_history h ON h.resolution = ? AND h.pool_id = g.pool_id AND h.bucket = g.last_bucket`,
+		target, size, size, source, start, end, size, source)
+	if err != nil {
+		return fmt.Errorf("failed to downsample pool prices to %s: %w", target, err)
+	}
+	return nil
+}
+
+func downsampleTokens(tx *sql.Tx, source, target string, size, start, end int64) error {
+	_, err := tx.Exec(`INSERT OR REPLACE INTO token_price_history (
+		resolution, bucket, denom, symbol, price_usd, price_osmo, liquidity_usd, chain
+	)
+	SELECT ?, (bucket / ?) * ?, denom, MAX(symbol), AVG(price_usd), AVG(price_osmo), AVG(liquidity_usd), MAX(chain)
+	FROM token_price_history
+	WHERE resolution = ? AND bucket >= ? AND bucket < ?
+	GROUP BY denom, bucket / ?`,
+		target, size, size, source, start, end, size)
+	if err != nil {
+		return fmt.Errorf("failed to downsample token prices to %s: %w", target, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) deleteOlderThan(resolution string, cutoff int64) error {
+	for _, table := range []string{"pool_price_history", "token_price_history"} {
+		if _, err := s.db.Exec(`DELETE FROM `+table+` WHERE resolution = ? AND bucket < ?`, resolution, cutoff); err != nil {
+			return fmt.Errorf("failed to apply retention on %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// GetDatabaseStats - Stats του cache μαζί με το μέγεθος του ιστορικού
+func (s *SQLiteStorage) GetDatabaseStats() (map[string]interface{}, error) {
+	stats, err := s.MemoryStorage.GetDatabaseStats()
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string]int64)
+	for _, table := range []string{"pool_price_history", "token_price_history"} {
+		rows, err := s.db.Query(`SELECT resolution, COUNT(*) FROM ` + table + ` GROUP BY resolution`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		for rows.Next() {
+			var resolution string
+			var count int64
+			if err := rows.Scan(&resolution, &count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			history[strings.TrimSuffix(table, "_history")+"_"+resolution] = count
+		}
+		rows.Close()
+	}
+
+	stats["storage_type"] = "sqlite"
+	stats["database_path"] = s.path
+	stats["history_rows"] = history
+	return stats, nil
+}
+
+// GetName - Επιστρέφει το όνομα του storage
+func (s *SQLiteStorage) GetName() string {
+	return "sqlite"
+}
+
+// Flush - Γράφει στο ιστορικό το τελευταίο snapshot της cache, ώστε οι τιμές μετά το
+// τελευταίο SnapshotInterval να μη χαθούν στο shutdown (το bucket του τρέχοντος
+// διαστήματος αντικαθίσταται με τις νεότερες τιμές)
+func (s *SQLiteStorage) Flush() error {
+	now := time.Now()
+	bucket := s.bucket(now)
+
+	if poolPrices, _ := s.MemoryStorage.GetLatestPoolPrices(); len(poolPrices) > 0 {
+		if err := s.insertPoolPrices(ResolutionRaw, bucket, poolPrices); err != nil {
+			return err
+		}
+	}
+	if tokenPrices, _ := s.MemoryStorage.GetLatestTokenPrices(); len(tokenPrices) > 0 {
+		if err := s.insertTokenPrices(ResolutionRaw, bucket, tokenPrices); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	for chain := range s.lastPoolSnapshot {
+		s.lastPoolSnapshot[chain] = now
+	}
+	for chain := range s.lastTokenSnapshot {
+		s.lastTokenSnapshot[chain] = now
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Close - Σταματά το maintenance και κλείνει τη βάση
+func (s *SQLiteStorage) Close() error {
+	select {
+	case <-s.stopChan:
+		return nil
+	default:
+		close(s.stopChan)
+	}
+	s.wg.Wait()
+	return s.db.Close()
+}
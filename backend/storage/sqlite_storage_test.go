@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"portofoliov1/types"
+)
+
+// testRetentionPolicy - Raw για 2 ημέρες, hourly για 10, daily για 30· το maintenance
+// τρέχει μόνο όταν το καλεί το test
+func testRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		SnapshotInterval:    time.Minute,
+		RawRetention:        2 * 24 * time.Hour,
+		HourlyRetention:     10 * 24 * time.Hour,
+		DailyRetention:      30 * 24 * time.Hour,
+		MaintenanceInterval: 24 * time.Hour,
+	}
+}
+
+func openTestSQLite(t *testing.T, dir string) *SQLiteStorage {
+	t.Helper()
+	s, err := NewSQLiteStorage(dir, testRetentionPolicy())
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	return s
+}
+
+func testPoolPrice(chain, poolID, amount0, amount1, exact string, price float64) types.PoolPrice {
+	return types.PoolPrice{
+		PoolID:              poolID,
+		Chain:               chain,
+		Token0Symbol:        "ATOM",
+		Token0Denom:         "uatom",
+		Token0Amount:        amount0,
+		Token1Symbol:        "OSMO",
+		Token1Denom:         "uosmo",
+		Token1Amount:        amount1,
+		PriceToken0ToToken1: price,
+		PriceToken1ToToken0: 1 / price,
+		Price0To1Exact:      exact,
+		LiquidityUSD:        1000,
+	}
+}
+
+func TestSQLiteReloadsLatestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	s := openTestSQLite(t, dir)
+
+	// Ένα παλαιότερο snapshot που δεν πρέπει να φορτωθεί
+	older := time.Now().Add(-time.Hour)
+	if err := s.insertPoolPrices(ResolutionRaw, s.bucket(older), []types.PoolPrice{testPoolPrice("osmosis", "1", "1", "1", "1", 1)}); err != nil {
+		t.Fatalf("insertPoolPrices: %v", err)
+	}
+
+	if err := s.SavePoolPrices([]types.PoolPrice{testPoolPrice("osmosis", "1", "1000", "2500", "2.5", 2.5)}); err != nil {
+		t.Fatalf("SavePoolPrices: %v", err)
+	}
+	if err := s.SavePoolPrices([]types.PoolPrice{testPoolPrice("crescent", "crescent:7", "10", "30", "3", 3)}); err != nil {
+		t.Fatalf("SavePoolPrices: %v", err)
+	}
+	if err := s.SaveTokenPrices([]types.TokenPrice{{Denom: "uatom", Symbol: "ATOM", PriceUSD: 9.5, PriceOSMO: 2.5, Chain: "osmosis"}}); err != nil {
+		t.Fatalf("SaveTokenPrices: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened := openTestSQLite(t, dir)
+	defer reopened.Close()
+
+	pools, _ := reopened.GetLatestPoolPrices()
+	if len(pools) != 2 {
+		t.Fatalf("reloaded %d pool prices, want 2: %+v", len(pools), pools)
+	}
+	for _, p := range pools {
+		switch p.PoolID {
+		case "1":
+			if p.Token0Amount != "1000" || p.Price0To1Exact != "2.5" || p.PriceToken0ToToken1 != 2.5 {
+				t.Errorf("osmosis pool reloaded as %+v, want the latest snapshot", p)
+			}
+		case "crescent:7":
+			if p.Chain != "crescent" || p.Price0To1Exact != "3" {
+				t.Errorf("crescent pool reloaded as %+v", p)
+			}
+		default:
+			t.Errorf("unexpected pool %s", p.PoolID)
+		}
+	}
+
+	token, err := reopened.GetTokenPrice("ATOM")
+	if err != nil || token.PriceUSD != 9.5 {
+		t.Errorf("reloaded ATOM = %+v, %v, want 9.5 USD", token, err)
+	}
+}
+
+// historyRows - Οι εγγραφές ενός pool σε μια ανάλυση, κατά bucket
+func historyRows(t *testing.T, s *SQLiteStorage, resolution, poolID string) []types.PoolPrice {
+	t.Helper()
+	rows, err := s.queryPoolPrices(`resolution = ? AND pool_id = ? ORDER BY bucket`, resolution, poolID)
+	if err != nil {
+		t.Fatalf("queryPoolPrices: %v", err)
+	}
+	return rows
+}
+
+func TestSQLiteDownsampling(t *testing.T) {
+	s := openTestSQLite(t, t.TempDir())
+	defer s.Close()
+
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	insert := func(at time.Duration, price types.PoolPrice) {
+		t.Helper()
+		if err := s.insertPoolPrices(ResolutionRaw, base.Add(at).Unix(), []types.PoolPrice{price}); err != nil {
+			t.Fatalf("insertPoolPrices: %v", err)
+		}
+	}
+	insertToken := func(at time.Duration, usd float64) {
+		t.Helper()
+		if err := s.insertTokenPrices(ResolutionRaw, base.Add(at).Unix(), []types.TokenPrice{{Denom: "uatom", Symbol: "ATOM", PriceUSD: usd, PriceOSMO: usd}}); err != nil {
+			t.Fatalf("insertTokenPrices: %v", err)
+		}
+	}
+
+	// Ως strings το "999" είναι μεγαλύτερο από το "1000000000": κρατιέται το τελευταίο snapshot
+	insert(10*time.Minute, testPoolPrice("osmosis", "1", "1000000000", "2000000000", "2", 2))
+	insert(50*time.Minute, testPoolPrice("osmosis", "1", "999", "2997", "3", 3))
+	insert(70*time.Minute, testPoolPrice("osmosis", "1", "5", "20", "4", 4))
+	insertToken(10*time.Minute, 9)
+	insertToken(50*time.Minute, 11)
+
+	if err := s.RunMaintenance(base.Add(90 * time.Minute)); err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+
+	hourly := historyRows(t, s, ResolutionHourly, "1")
+	if len(hourly) != 1 {
+		t.Fatalf("got %d hourly rows, want 1 (the second hour is still open)", len(hourly))
+	}
+	if h := hourly[0]; !h.Timestamp.Equal(base) || h.Token0Amount != "999" || h.Token1Amount != "2997" || h.Price0To1Exact != "3" || h.PriceToken0ToToken1 != 3 {
+		t.Errorf("hourly row = %+v, want the 00:50 snapshot", h)
+	}
+	tokens, _ := s.queryTokenPrices(`resolution = ? AND denom = ?`, ResolutionHourly, "uatom")
+	if len(tokens) != 1 || tokens[0].PriceUSD != 10 {
+		t.Errorf("hourly token rows = %+v, want one average of 10", tokens)
+	}
+	if watermark, _ := s.downsampleWatermark(ResolutionHourly); watermark != base.Add(time.Hour).Unix() {
+		t.Errorf("hourly watermark = %d, want %d", watermark, base.Add(time.Hour).Unix())
+	}
+
+	// Ένα κλεισμένο hour δεν συναθροίζεται ξανά
+	insert(55*time.Minute, testPoolPrice("osmosis", "1", "7", "7", "1", 1))
+	if err := s.RunMaintenance(base.Add(100 * time.Minute)); err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	if h := historyRows(t, s, ResolutionHourly, "1")[0]; h.Token0Amount != "999" {
+		t.Errorf("closed hour was aggregated again: %+v", h)
+	}
+
+	// Μια ημέρα μετά: το δεύτερο hour και μετά η ημέρα από τα hourly
+	if err := s.RunMaintenance(base.Add(25 * time.Hour)); err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	if hourly := historyRows(t, s, ResolutionHourly, "1"); len(hourly) != 2 || hourly[1].Token0Amount != "5" {
+		t.Fatalf("hourly rows = %+v, want the 01:10 snapshot second", hourly)
+	}
+	daily := historyRows(t, s, ResolutionDaily, "1")
+	if len(daily) != 1 || !daily[0].Timestamp.Equal(base) || daily[0].Token0Amount != "5" || daily[0].Price0To1Exact != "4" {
+		t.Fatalf("daily rows = %+v, want the last snapshot of the day", daily)
+	}
+}
+
+func TestSQLiteRetention(t *testing.T) {
+	s := openTestSQLite(t, t.TempDir())
+	defer s.Close()
+
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{10 * time.Minute, 26 * time.Hour} {
+		if err := s.insertPoolPrices(ResolutionRaw, base.Add(at).Unix(), []types.PoolPrice{testPoolPrice("osmosis", "1", "1", "2", "2", 2)}); err != nil {
+			t.Fatalf("insertPoolPrices: %v", err)
+		}
+	}
+
+	// Στις 10/1 + 3 ημέρες το raw της πρώτης ημέρας είναι εκτός RawRetention
+	if err := s.RunMaintenance(base.Add(3 * 24 * time.Hour)); err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	raw := historyRows(t, s, ResolutionRaw, "1")
+	if len(raw) != 1 || !raw[0].Timestamp.Equal(base.Add(26*time.Hour)) {
+		t.Errorf("raw rows after retention = %+v, want only the 11/1 snapshot", raw)
+	}
+	if hourly := historyRows(t, s, ResolutionHourly, "1"); len(hourly) != 2 {
+		t.Errorf("got %d hourly rows, want 2", len(hourly))
+	}
+
+	// 40 ημέρες μετά δεν μένει τίποτα από τον Ιανουάριο
+	if err := s.RunMaintenance(base.Add(40 * 24 * time.Hour)); err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	for _, resolution := range []string{ResolutionRaw, ResolutionHourly, ResolutionDaily} {
+		if rows := historyRows(t, s, resolution, "1"); len(rows) != 0 {
+			t.Errorf("%s rows after retention: %+v", resolution, rows)
+		}
+	}
+}
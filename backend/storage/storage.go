@@ -3,6 +3,7 @@ package storage
 import (
 	"encoding/csv"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -17,6 +18,46 @@ type StorageInterface interface {
 	GetName() string
 }
 
+// PriceStorage - Αποθήκευση των snapshots του collector (pools, pool prices, token prices)
+type PriceStorage interface {
+	SavePools(pools []types.OsmosisPool) error
+	SavePoolPrices(prices []types.PoolPrice) error
+	SaveTokenPrices(prices []types.TokenPrice) error
+
+	GetLatestTokenPrices() ([]types.TokenPrice, error)
+	GetAllUniqueTokens() ([]types.TokenPrice, error)
+	GetTokenPrice(symbol string) (*types.TokenPrice, error)
+	GetTokenPriceFromPools(symbol string) (*types.TokenPrice, error)
+	GetAllPoolsForToken(symbol string) ([]types.PoolPrice, error)
+	GetLatestPoolPrices() ([]types.PoolPrice, error)
+	GetPool(poolID string) (*types.OsmosisPool, error)
+	GetDatabaseStats() (map[string]interface{}, error)
+	GetPoolCandles(poolID, interval string, from, to time.Time) ([]types.Candle, error)
+	GetTokenCandles(symbol, interval string, from, to time.Time) ([]types.Candle, error)
+	OnPoolPricesChanged(listener PoolPriceListener)
+
+	GetName() string
+	Flush() error
+	Close() error
+}
+
+// NewPriceStorage - Επιλογή storage από το config ("memory" ή "sqlite")
+func NewPriceStorage(storageType, dataFolder string) (PriceStorage, error) {
+	switch storageType {
+	case "", "memory":
+		return NewMemoryStorage(), nil
+	case "sqlite":
+		return NewSQLiteStorage(dataFolder, DefaultRetentionPolicy())
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", storageType)
+	}
+}
+
+// finite - Αποκλείει NaN/Inf που δεν αποθηκεύονται σωστά
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
 // CSVStorage - Αποθήκευση σε CSV
 type CSVStorage struct {
 	DataFolder string
@@ -131,3 +172,11 @@ func (s *CSVStorage) ensureDataFolder(folderPath string) error {
 	}
 	return nil
 }
+
+// chainOrDefault - Εγγραφές χωρίς αλυσίδα θεωρούνται του Osmosis
+func chainOrDefault(chain string) string {
+	if chain == "" {
+		return types.DefaultChain
+	}
+	return chain
+}
@@ -0,0 +1,233 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Είδη κανόνων alert
+const (
+	AlertKindPriceCross  = "price_cross"  // Η τιμή περνά ένα όριο
+	AlertKindPriceChange = "price_change" // Η τιμή μεταβάλλεται περισσότερο από Threshold μέσα στο Window
+	AlertKindTVLDrop     = "tvl_drop"     // Το TVL ενός pool πέφτει περισσότερο από Threshold από το μέγιστο του Window
+)
+
+// Κατευθύνσεις κανόνων
+const (
+	AlertDirectionAbove = "above" // price_cross: από κάτω προς πάνω
+	AlertDirectionBelow = "below" // price_cross: από πάνω προς τα κάτω
+	AlertDirectionUp    = "up"    // price_change: άνοδος
+	AlertDirectionDown  = "down"  // price_change: πτώση
+	AlertDirectionAny   = "any"
+)
+
+// Προεπιλογές για κανόνες χωρίς Window/Cooldown
+const (
+	DefaultAlertWindow   = 10 * time.Minute
+	DefaultAlertCooldown = 15 * time.Minute
+)
+
+// AlertRule - Κανόνας alert. Με PoolID παρακολουθείται η τιμή του pool (του Token, ή του
+// token0 αν το Token λείπει, σε μονάδες του άλλου token)· χωρίς PoolID η τιμή USD του Token.
+type AlertRule struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name,omitempty"`
+	Kind            string     `json:"kind"`
+	Chain           string     `json:"chain,omitempty"` // Κενό = osmosis
+	Token           string     `json:"token,omitempty"` // Symbol ή denom
+	PoolID          string     `json:"pool_id,omitempty"`
+	Direction       string     `json:"direction,omitempty"` // Κενό = any
+	Threshold       float64    `json:"threshold"`           // Τιμή (price_cross) ή κλάσμα (0.05 = 5%)
+	Window          string     `json:"window,omitempty"`    // Π.χ. "10m" (price_change, tvl_drop)
+	Cooldown        string     `json:"cooldown,omitempty"`  // Ελάχιστο διάστημα μεταξύ δύο alerts του κανόνα
+	WebhookURL      string     `json:"webhook_url"`
+	Disabled        bool       `json:"disabled,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+}
+
+// ChainName - Η αλυσίδα του κανόνα (osmosis αν δεν ορίζεται)
+func (r AlertRule) ChainName() string {
+	if r.Chain == "" {
+		return DefaultChain
+	}
+	return r.Chain
+}
+
+// WindowDuration - Το παράθυρο του κανόνα (DefaultAlertWindow αν λείπει ή είναι άκυρο)
+func (r AlertRule) WindowDuration() time.Duration {
+	return parseAlertDuration(r.Window, DefaultAlertWindow)
+}
+
+// CooldownDuration - Το cooldown του κανόνα (DefaultAlertCooldown αν λείπει ή είναι άκυρο)
+func (r AlertRule) CooldownDuration() time.Duration {
+	return parseAlertDuration(r.Cooldown, DefaultAlertCooldown)
+}
+
+func parseAlertDuration(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fallback
+	}
+	return d
+}
+
+// Validate ελέγχει ότι ο κανόνας είναι πλήρης και συνεπής. Webhooks σε localhost ή σε
+// ιδιωτικές διευθύνσεις απορρίπτονται, εκτός αν ο host είναι στο allowlist.
+func (r AlertRule) Validate(allowlist WebhookAllowlist) error {
+	switch r.Kind {
+	case AlertKindPriceCross:
+		if r.Token == "" && r.PoolID == "" {
+			return fmt.Errorf("το %s χρειάζεται token ή pool_id", r.Kind)
+		}
+		if !validAlertDirection(r.Direction, AlertDirectionAbove, AlertDirectionBelow) {
+			return fmt.Errorf("μη έγκυρη κατεύθυνση για %s: %q (above, below ή any)", r.Kind, r.Direction)
+		}
+		if !(r.Threshold > 0) || math.IsInf(r.Threshold, 0) {
+			return fmt.Errorf("το threshold πρέπει να είναι θετική τιμή")
+		}
+	case AlertKindPriceChange:
+		if r.Token == "" && r.PoolID == "" {
+			return fmt.Errorf("το %s χρειάζεται token ή pool_id", r.Kind)
+		}
+		if !validAlertDirection(r.Direction, AlertDirectionUp, AlertDirectionDown) {
+			return fmt.Errorf("μη έγκυρη κατεύθυνση για %s: %q (up, down ή any)", r.Kind, r.Direction)
+		}
+		if !(r.Threshold > 0) || math.IsInf(r.Threshold, 0) {
+			return fmt.Errorf("το threshold πρέπει να είναι θετικό κλάσμα (0.05 = 5%%)")
+		}
+	case AlertKindTVLDrop:
+		if r.PoolID == "" {
+			return fmt.Errorf("το %s χρειάζεται pool_id", r.Kind)
+		}
+		if r.Direction != "" {
+			return fmt.Errorf("το %s δεν δέχεται κατεύθυνση", r.Kind)
+		}
+		if !(r.Threshold > 0 && r.Threshold < 1) {
+			return fmt.Errorf("το threshold πρέπει να είναι κλάσμα στο (0, 1)")
+		}
+	default:
+		return fmt.Errorf("άγνωστο είδος κανόνα: %q", r.Kind)
+	}
+
+	for name, value := range map[string]string{"window": r.Window, "cooldown": r.Cooldown} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("μη έγκυρο %s: %q", name, value)
+		}
+	}
+
+	webhook, err := url.Parse(r.WebhookURL)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Hostname() == "" {
+		return fmt.Errorf("μη έγκυρο webhook_url: %q", r.WebhookURL)
+	}
+	if host := webhook.Hostname(); !allowlist.AllowsHost(host) && isInternalHost(host) {
+		return fmt.Errorf("το webhook_url δείχνει σε εσωτερική διεύθυνση: %q", host)
+	}
+	return nil
+}
+
+// WebhookAllowlist - Hostnames, IPs ή CIDR που δέχονται webhooks παρότι είναι εσωτερικά
+// (π.χ. "alerts.internal", "10.0.0.5", "192.168.1.0/24")
+type WebhookAllowlist []string
+
+// AllowsHost - Ο host (hostname ή IP, όπως στο URL) είναι στο allowlist
+func (a WebhookAllowlist) AllowsHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return a.AllowsIP(ip)
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, entry := range a {
+		if strings.TrimSuffix(strings.ToLower(strings.TrimSpace(entry)), ".") == host {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP - Η διεύθυνση είναι μία από τις IPs ή μέσα σε ένα από τα CIDR του allowlist
+func (a WebhookAllowlist) AllowsIP(ip net.IP) bool {
+	for _, entry := range a {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInternalIP - Loopback, ιδιωτικές, link-local, multicast και unspecified διευθύνσεις,
+// στις οποίες ένα webhook θα έφτανε σε υπηρεσίες του ίδιου δικτύου (π.χ. cloud metadata)
+func IsInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// isInternalHost - IP literal εσωτερικής διεύθυνσης ή localhost. Τα hostnames ελέγχονται
+// ξανά κατά τη σύνδεση, με τις IPs στις οποίες επιλύονται.
+func isInternalHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return IsInternalIP(ip)
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return host == "localhost" || strings.HasSuffix(host, ".localhost")
+}
+
+func validAlertDirection(direction string, allowed ...string) bool {
+	if direction == "" || direction == AlertDirectionAny {
+		return true
+	}
+	for _, a := range allowed {
+		if direction == a {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertEvent - Ένα alert που ενεργοποιήθηκε· αυτό είναι το σώμα του webhook
+type AlertEvent struct {
+	ID          string    `json:"id"` // Ίδιο σε όλες τις επαναλήψεις αποστολής (και στο header X-Alert-Event-ID)
+	RuleID      string    `json:"rule_id"`
+	RuleName    string    `json:"rule_name,omitempty"`
+	Kind        string    `json:"kind"`
+	Chain       string    `json:"chain"`
+	Subject     string    `json:"subject"` // Π.χ. "ATOM/USD" ή "pool 1 ATOM/USDC"
+	Value       float64   `json:"value"`
+	Reference   float64   `json:"reference"`        // Προηγούμενη τιμή, τιμή αρχής του παραθύρου ή μέγιστο TVL
+	Change      float64   `json:"change,omitempty"` // Value/Reference - 1
+	Threshold   float64   `json:"threshold"`
+	Message     string    `json:"message"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// Καταστάσεις αποστολής ενός webhook
+const (
+	AlertDeliveryPending   = "pending"
+	AlertDeliveryDelivered = "delivered"
+	AlertDeliveryFailed    = "failed"
+)
+
+// AlertDelivery - Η αποστολή ενός AlertEvent στο webhook του κανόνα
+type AlertDelivery struct {
+	Event       AlertEvent `json:"event"`
+	WebhookURL  string     `json:"webhook_url"`
+	Status      string     `json:"status"` // pending | delivered | failed
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
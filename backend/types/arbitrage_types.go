@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// ArbitrageHop - Ένα swap ενός κύκλου arbitrage
+type ArbitrageHop struct {
+	PoolID     string  `json:"pool_id"` // On-chain pool
+	PoolType   string  `json:"pool_type"`
+	FromSymbol string  `json:"from_symbol"`
+	FromDenom  string  `json:"from_denom"`
+	ToSymbol   string  `json:"to_symbol"`
+	ToDenom    string  `json:"to_denom"`
+	Rate       float64 `json:"rate"`       // Spot τιμή (display units) μετά το swap fee
+	SwapFee    float64 `json:"swap_fee"`   // 0.002 = 0.2%
+	AmountIn   string  `json:"amount_in"`  // Base units στο βέλτιστο μέγεθος
+	AmountOut  string  `json:"amount_out"` // Base units, από την τοπική swap math του pool
+}
+
+// ArbitrageOpportunity - Κύκλος pools που επιστρέφει περισσότερα tokens από όσα μπήκαν
+type ArbitrageOpportunity struct {
+	ID          string         `json:"id"` // Σταθερό όσο υπάρχει ο ίδιος κύκλος (pools και κατεύθυνση)
+	Chain       string         `json:"chain"`
+	Path        []string       `json:"path"` // Symbols, π.χ. ["OSMO", "ATOM", "USDC", "OSMO"]
+	Hops        []ArbitrageHop `json:"hops"`
+	TokenSymbol string         `json:"token_symbol"` // Το token με το οποίο ξεκινά και τελειώνει ο κύκλος
+	TokenDenom  string         `json:"token_denom"`
+	SpotReturn  float64        `json:"spot_return"` // Γινόμενο των τιμών μετά τα fees - 1 (για απειροελάχιστο trade)
+	AmountIn    string         `json:"amount_in"`   // Βέλτιστο μέγεθος (base units)
+	AmountOut   string         `json:"amount_out"`
+	Profit      string         `json:"profit"`     // AmountOut - AmountIn (base units)
+	ProfitPct   float64        `json:"profit_pct"` // Profit / AmountIn
+	ProfitUSD   float64        `json:"profit_usd"` // 0 αν το token δεν έχει τιμή USD
+	DetectedAt  time.Time      `json:"detected_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
@@ -1,21 +1,69 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
+// DefaultChain - Η αλυσίδα των unprefixed pool IDs και του default AssetService
+const DefaultChain = "osmosis"
+
+// AssetChangeEvent - Τα assets που προστέθηκαν ή αφαιρέθηκαν σε ένα reload του assetlist
+type AssetChangeEvent struct {
+	Chain     string    `json:"chain"`
+	Added     []Asset   `json:"added"`
+	Removed   []Asset   `json:"removed"`
+	Total     int       `json:"total"` // Assets μετά το reload
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AssetChangeListener - Καλείται μετά από κάθε reload που άλλαξε το assetlist
+type AssetChangeListener func(event AssetChangeEvent)
+
+// AssetService - Token metadata μιας αλυσίδας. Τα δεδομένα αντικαθίστανται ολόκληρα από
+// το Reload (π.χ. μετά από ενημέρωση του chain registry) χωρίς restart· όσοι κρατούν τον
+// pointer βλέπουν αμέσως το νέο assetlist.
 type AssetService struct {
-	DenomToSymbol map[string]string
-	TokenMetadata map[string]Asset
-	OsmoUsdPrice  float64
+	Chain string
+
+	mu            sync.RWMutex
+	assets        []Asset // Με τη σειρά του assetlist
+	denomToSymbol map[string]string
+	tokenMetadata map[string]Asset
+	osmoUsdPrice  float64
+	path          string    // Το assetlist.json που φορτώθηκε
+	modTime       time.Time // mtime του αρχείου στο τελευταίο load (βλ. Watch)
+	listeners     []AssetChangeListener
 }
 
+// NewAssetService - AssetService από το assetlist του Osmosis
 func NewAssetService() (*AssetService, error) {
+	return NewAssetServiceForChain(DefaultChain)
+}
+
+// NewAssetServiceForChain - AssetService από το data/chain-registry/{chain}/assetlist.json
+func NewAssetServiceForChain(chain string) (*AssetService, error) {
+	s := &AssetService{
+		Chain:        chain,
+		osmoUsdPrice: 1.0, // Default τιμή, θα ενημερωθεί αργότερα
+	}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload ξαναδιαβάζει το assetlist και αντικαθιστά τα mappings. Αν άλλαξαν assets,
+// οι listeners λαμβάνουν το event. Σε σφάλμα τα προηγούμενα δεδομένα μένουν ως έχουν.
+func (s *AssetService) Reload() (*AssetChangeEvent, error) {
 	// Read assetlist.json
-	assetList, err := loadAssetList()
+	assetList, path, modTime, err := loadAssetList(s.Chain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load asset list: %w", err)
 	}
@@ -24,49 +72,145 @@ func NewAssetService() (*AssetService, error) {
 	denomToSymbol := GetDenomMapping(assetList.Assets)
 	tokenMetadata := GetTokenMetadata(assetList.Assets)
 
-	return &AssetService{
-		DenomToSymbol: denomToSymbol,
-		TokenMetadata: tokenMetadata,
-		OsmoUsdPrice:  1.0, // Default τιμή, θα ενημερωθεί αργότερα
-	}, nil
-}
+	s.mu.Lock()
+	event := diffAssets(s.Chain, s.assets, assetList.Assets)
+	initial := s.assets == nil
+	s.assets = assetList.Assets
+	s.denomToSymbol = denomToSymbol
+	s.tokenMetadata = tokenMetadata
+	s.path = path
+	s.modTime = modTime
+	listeners := s.listeners
+	s.mu.Unlock()
 
-func loadAssetList() (*AssetList, error) {
-	// Get project root directory by looking for go.mod
-	rootDir, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	if initial || (len(event.Added) == 0 && len(event.Removed) == 0) {
+		return nil, nil
+	}
+
+	log.Printf("🔄 Assetlist %s: +%d / -%d assets (σύνολο %d)", s.Chain, len(event.Added), len(event.Removed), event.Total)
+	// Εκτός lock ώστε οι listeners να μπορούν να διαβάσουν το service
+	for _, listener := range listeners {
+		listener(*event)
 	}
+	return event, nil
+}
+
+// OnChange - Εγγραφή listener για τα added/removed assets κάθε reload
+func (s *AssetService) OnChange(listener AssetChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.listeners = append(s.listeners, listener)
+}
+
+// Watch ελέγχει κάθε interval το mtime του assetlist και κάνει Reload όταν αλλάξει,
+// μέχρι να ακυρωθεί το ctx. Ένα reload από τον updater ενημερώνει ήδη το mtime,
+// οπότε δεν διαβάζεται το ίδιο αρχείο δύο φορές.
+func (s *AssetService) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
 	for {
-		if _, err := os.Stat(filepath.Join(rootDir, "go.mod")); err == nil {
-			break
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
 		}
-		parentDir := filepath.Dir(rootDir)
-		if parentDir == rootDir {
-			return nil, fmt.Errorf("could not find project root (no go.mod found)")
+
+		s.mu.RLock()
+		path, modTime := s.path, s.modTime
+		s.mu.RUnlock()
+
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+		if _, err := s.Reload(); err != nil {
+			log.Printf("⚠️  Αποτυχία reload του assetlist %s: %v", s.Chain, err)
+		}
+	}
+}
+
+// diffAssets - Assets (κατά base denom) που προστέθηκαν ή αφαιρέθηκαν
+func diffAssets(chain string, previous, current []Asset) *AssetChangeEvent {
+	event := &AssetChangeEvent{
+		Chain:     chain,
+		Added:     []Asset{},
+		Removed:   []Asset{},
+		Total:     len(current),
+		Timestamp: time.Now(),
+	}
+
+	before := make(map[string]bool, len(previous))
+	for _, asset := range previous {
+		before[asset.Base] = true
+	}
+	after := make(map[string]bool, len(current))
+	for _, asset := range current {
+		after[asset.Base] = true
+		if !before[asset.Base] {
+			event.Added = append(event.Added, asset)
+		}
+	}
+	for _, asset := range previous {
+		if !after[asset.Base] {
+			event.Removed = append(event.Removed, asset)
 		}
-		rootDir = parentDir
+	}
+
+	return event
+}
+
+func loadAssetList(chain string) (*AssetList, string, time.Time, error) {
+	rootDir, err := findProjectRoot()
+	if err != nil {
+		return nil, "", time.Time{}, err
 	}
 
 	// Read assetlist.json
-	assetListPath := filepath.Join(rootDir, "data", "chain-registry", "osmosis", "assetlist.json")
+	assetListPath := filepath.Join(rootDir, "data", "chain-registry", chain, "assetlist.json")
+	info, err := os.Stat(assetListPath)
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("failed to read assetlist.json for %s: %w", chain, err)
+	}
 	content, err := os.ReadFile(assetListPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read assetlist.json: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("failed to read assetlist.json for %s: %w", chain, err)
 	}
 
 	var assetList AssetList
 	if err := json.Unmarshal(content, &assetList); err != nil {
-		return nil, fmt.Errorf("failed to parse assetlist.json: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("failed to parse assetlist.json: %w", err)
+	}
+
+	return &assetList, assetListPath, info.ModTime(), nil
+}
+
+// findProjectRoot - Ο φάκελος με το go.mod (ψάχνοντας προς τα πάνω από το working directory)
+func findProjectRoot() (string, error) {
+	rootDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	return &assetList, nil
+	for {
+		if _, err := os.Stat(filepath.Join(rootDir, "go.mod")); err == nil {
+			return rootDir, nil
+		}
+		parentDir := filepath.Dir(rootDir)
+		if parentDir == rootDir {
+			return "", fmt.Errorf("could not find project root (no go.mod found)")
+		}
+		rootDir = parentDir
+	}
 }
 
 // GetSymbol returns the symbol for a given denom
 func (s *AssetService) GetSymbol(denom string) string {
-	if symbol, ok := s.DenomToSymbol[denom]; ok {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if symbol, ok := s.denomToSymbol[denom]; ok {
 		return symbol
 	}
 	return denom // Return original denom if no mapping found
@@ -74,13 +218,19 @@ func (s *AssetService) GetSymbol(denom string) string {
 
 // GetAsset returns the full asset metadata for a given symbol or denom
 func (s *AssetService) GetAsset(symbolOrDenom string) (Asset, bool) {
-	asset, ok := s.TokenMetadata[symbolOrDenom]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	asset, ok := s.tokenMetadata[symbolOrDenom]
 	return asset, ok
 }
 
 // GetLogoURL returns the logo URL for a given symbol or denom
 func (s *AssetService) GetLogoURL(symbolOrDenom string) string {
-	if asset, ok := s.TokenMetadata[symbolOrDenom]; ok && asset.LogoURIs != nil {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if asset, ok := s.tokenMetadata[symbolOrDenom]; ok && asset.LogoURIs != nil {
 		if asset.LogoURIs.PNG != "" {
 			return asset.LogoURIs.PNG
 		}
@@ -91,7 +241,10 @@ func (s *AssetService) GetLogoURL(symbolOrDenom string) string {
 
 // GetDisplayDenom returns the display denomination for a given base denom
 func (s *AssetService) GetDisplayDenom(baseDenom string) string {
-	if asset, ok := s.TokenMetadata[baseDenom]; ok {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if asset, ok := s.tokenMetadata[baseDenom]; ok {
 		return asset.Display
 	}
 	return baseDenom
@@ -99,7 +252,10 @@ func (s *AssetService) GetDisplayDenom(baseDenom string) string {
 
 // GetExponent returns the exponent for converting between base and display units
 func (s *AssetService) GetExponent(denom string) int {
-	if asset, ok := s.TokenMetadata[denom]; ok {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if asset, ok := s.tokenMetadata[denom]; ok {
 		for _, unit := range asset.DenomUnits {
 			if unit.Denom == asset.Display {
 				return unit.Exponent
@@ -111,26 +267,36 @@ func (s *AssetService) GetExponent(denom string) int {
 
 // SetOsmoUsdPrice sets the current OSMO/USD price
 func (s *AssetService) SetOsmoUsdPrice(price float64) {
-	s.OsmoUsdPrice = price
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.osmoUsdPrice = price
 }
 
 // GetOsmoUsdPrice returns the current OSMO/USD price
 func (s *AssetService) GetOsmoUsdPrice() float64 {
-	return s.OsmoUsdPrice
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.osmoUsdPrice
 }
 
 // ConvertUsdToOsmo converts a USD price to OSMO
 func (s *AssetService) ConvertUsdToOsmo(usdPrice float64) float64 {
-	if s.OsmoUsdPrice <= 0 {
+	osmoUsdPrice := s.GetOsmoUsdPrice()
+	if osmoUsdPrice <= 0 {
 		return 0
 	}
-	return usdPrice / s.OsmoUsdPrice
+	return usdPrice / osmoUsdPrice
 }
 
 // GetDenom returns the base denom for a given symbol
 func (s *AssetService) GetDenom(symbol string) string {
-	// Αναζήτηση στο TokenMetadata για το denom που αντιστοιχεί στο symbol
-	for _, asset := range s.TokenMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Αναζήτηση στο assetlist για το denom που αντιστοιχεί στο symbol
+	for _, asset := range s.assets {
 		if asset.Symbol == symbol {
 			return asset.Base
 		}
@@ -143,11 +309,12 @@ func (s *AssetService) GetDenomBySymbol(symbol string) string {
 	return s.GetDenom(symbol)
 }
 
-// GetAllTokens returns all tokens from the asset service
+// GetAllTokens returns all tokens from the asset service (one entry per asset)
 func (s *AssetService) GetAllTokens() []Asset {
-	tokens := make([]Asset, 0, len(s.TokenMetadata))
-	for _, asset := range s.TokenMetadata {
-		tokens = append(tokens, asset)
-	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tokens := make([]Asset, len(s.assets))
+	copy(tokens, s.assets)
 	return tokens
 }
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ChainInfo - Τα πεδία του chain.json (chain registry) που χρησιμοποιούμε
+type ChainInfo struct {
+	ChainName string `json:"chain_name"`
+	ChainID   string `json:"chain_id"`
+	Apis      struct {
+		Rest []ChainEndpoint `json:"rest"`
+		Rpc  []ChainEndpoint `json:"rpc"`
+	} `json:"apis"`
+}
+
+// ChainEndpoint - Ένας δημόσιος node από το chain registry
+type ChainEndpoint struct {
+	Address  string `json:"address"`
+	Provider string `json:"provider"`
+}
+
+// EndpointHealth - Η κατάσταση ενός LCD endpoint όπως τη βλέπει ο client
+type EndpointHealth struct {
+	Address             string    `json:"address"`
+	Provider            string    `json:"provider"`
+	Healthy             bool      `json:"healthy"`
+	Score               float64   `json:"score"` // Μικρότερο = καλύτερο
+	LatencyMs           float64   `json:"latency_ms"`
+	ErrorRate           float64   `json:"error_rate"`
+	Requests            int64     `json:"requests"`
+	Failures            int64     `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastSuccess         time.Time `json:"last_success"`
+	CooldownUntil       time.Time `json:"cooldown_until"`
+}
+
+// LoadChainInfo διαβάζει το data/chain-registry/{chain}/chain.json
+func LoadChainInfo(chain string) (*ChainInfo, error) {
+	rootDir, err := findProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(rootDir, "data", "chain-registry", chain, "chain.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain.json: %w", err)
+	}
+
+	var info ChainInfo
+	if err := json.Unmarshal(content, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse chain.json: %w", err)
+	}
+
+	return &info, nil
+}
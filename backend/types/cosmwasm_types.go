@@ -0,0 +1,107 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CW20DenomPrefix - Τα CW20 tokens εμφανίζονται στο chain registry ως "cw20:<contract>"
+const CW20DenomPrefix = "cw20:"
+
+// CosmWasmAssetInfo - Astroport/Terraswap asset info: native denom ή CW20 contract
+type CosmWasmAssetInfo struct {
+	NativeToken *struct {
+		Denom string `json:"denom"`
+	} `json:"native_token,omitempty"`
+	Token *struct {
+		ContractAddr string `json:"contract_addr"`
+	} `json:"token,omitempty"`
+}
+
+// Denom - Το denom όπως στο assetlist (native denom ή "cw20:<contract>")
+func (i CosmWasmAssetInfo) Denom() string {
+	switch {
+	case i.NativeToken != nil:
+		return i.NativeToken.Denom
+	case i.Token != nil:
+		return CW20DenomPrefix + i.Token.ContractAddr
+	default:
+		return ""
+	}
+}
+
+// CosmWasmAsset - Reserve ενός asset στην απάντηση του {"pool":{}} query
+type CosmWasmAsset struct {
+	Info   CosmWasmAssetInfo `json:"info"`
+	Amount string            `json:"amount"`
+}
+
+// CosmWasmPair - Εγγραφή του factory {"pairs":{}} query
+type CosmWasmPair struct {
+	AssetInfos     []CosmWasmAssetInfo        `json:"asset_infos"`
+	ContractAddr   string                     `json:"contract_addr"`
+	LiquidityToken string                     `json:"liquidity_token"`
+	PairType       map[string]json.RawMessage `json:"pair_type,omitempty"` // Astroport: {"xyk":{}} ή {"stable":{}}
+}
+
+// Kind - "xyk", "stable", ... (Terraswap-style pairs χωρίς pair_type είναι xyk)
+func (p CosmWasmPair) Kind() string {
+	for kind := range p.PairType {
+		return kind
+	}
+	return PoolTypeXYK
+}
+
+// CosmWasmPairPool - Ένα pair contract μαζί με τα reserves του
+type CosmWasmPairPool struct {
+	Pair          CosmWasmPair    `json:"-"`
+	Assets        []CosmWasmAsset `json:"assets"`
+	TotalShare    string          `json:"total_share"`
+	Amplification string          `json:"-"` // Stable pairs: από το {"config":{}} query
+}
+
+// CosmWasmPairConfig - Η απάντηση του {"config":{}} query ενός Astroport pair. Τα params
+// είναι base64 JSON· στα stable pairs περιέχουν το amp ({"amp":"100", ...}).
+type CosmWasmPairConfig struct {
+	Params []byte `json:"params,omitempty"`
+}
+
+// Amp - Το amplification ενός stable pair ("" αν τα params δεν το έχουν)
+func (c CosmWasmPairConfig) Amp() string {
+	var params struct {
+		Amp json.RawMessage `json:"amp"`
+	}
+	if len(c.Params) == 0 || json.Unmarshal(c.Params, &params) != nil {
+		return ""
+	}
+	// Decimal ως string στα νεότερα contracts, αριθμός στα παλαιότερα
+	return strings.Trim(string(params.Amp), `"`)
+}
+
+func (p CosmWasmPairPool) GetId() string { return p.Pair.ContractAddr }
+func (p CosmWasmPairPool) GetAssets() []IPoolAsset {
+	assets := make([]IPoolAsset, len(p.Assets))
+	for i, asset := range p.Assets {
+		assets[i] = BasicPoolAsset{Token: BasicCoin{Denom: asset.Info.Denom(), Amount: asset.Amount}}
+	}
+	return assets
+}
+
+// ToOsmosisPool μετατρέπει το pair σε OsmosisPool· το pool ID είναι η διεύθυνση του contract
+func (p CosmWasmPairPool) ToOsmosisPool(chain string) OsmosisPool {
+	pool := OsmosisPool{
+		Type:    p.Pair.Kind(),
+		Address: p.Pair.ContractAddr,
+		Id:      ChainPoolID(chain, p.Pair.ContractAddr),
+		Chain:   chain,
+	}
+	for _, asset := range p.Assets {
+		pool.PoolAssets = append(pool.PoolAssets, BasicPoolAsset{
+			Token: BasicCoin{Denom: asset.Info.Denom(), Amount: asset.Amount},
+		})
+	}
+	pool.TotalShares.Denom = p.Pair.LiquidityToken
+	pool.TotalShares.Amount = p.TotalShare
+	pool.Amplification = p.Amplification
+	return pool
+}
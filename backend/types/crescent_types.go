@@ -0,0 +1,54 @@
+package types
+
+// Crescent liquidity module pool types
+const (
+	CrescentPoolTypeBasic  = "POOL_TYPE_BASIC"
+	CrescentPoolTypeRanged = "POOL_TYPE_RANGED"
+)
+
+// CrescentPool - Ένα pool του /crescent/liquidity/v1beta1/pools
+type CrescentPool struct {
+	Type           string `json:"type"` // POOL_TYPE_BASIC ή POOL_TYPE_RANGED
+	Id             string `json:"id"`
+	PairId         string `json:"pair_id"`
+	ReserveAddress string `json:"reserve_address"`
+	PoolCoinDenom  string `json:"pool_coin_denom"`
+	Price          string `json:"price"` // Quote ανά base, όπως το δίνει ο node
+	Balances       struct {
+		BaseCoin  BasicCoin `json:"base_coin"`
+		QuoteCoin BasicCoin `json:"quote_coin"`
+	} `json:"balances"`
+	PoolCoinSupply string `json:"pool_coin_supply"`
+	Disabled       bool   `json:"disabled"`
+}
+
+func (p CrescentPool) GetId() string { return p.Id }
+func (p CrescentPool) GetAssets() []IPoolAsset {
+	return []IPoolAsset{
+		BasicPoolAsset{Token: p.Balances.BaseCoin},
+		BasicPoolAsset{Token: p.Balances.QuoteCoin},
+	}
+}
+
+// Priceable - Μόνο ενεργά basic (constant-product) pools τιμολογούνται από τα reserves.
+// Τα ranged pools έχουν συγκεντρωμένη liquidity και τα reserves τους δεν δίνουν την τιμή.
+func (p CrescentPool) Priceable() bool {
+	return !p.Disabled && p.Type == CrescentPoolTypeBasic
+}
+
+// ToOsmosisPool μετατρέπει το pool σε OsmosisPool (base/quote ως ισοβαρή assets)
+func (p CrescentPool) ToOsmosisPool(chain string) OsmosisPool {
+	pool := OsmosisPool{
+		Type:    p.Type,
+		Address: p.ReserveAddress,
+		Id:      ChainPoolID(chain, p.Id),
+		Chain:   chain,
+		PoolAssets: []BasicPoolAsset{
+			{Token: p.Balances.BaseCoin},
+			{Token: p.Balances.QuoteCoin},
+		},
+	}
+	pool.TotalShares.Denom = p.PoolCoinDenom
+	pool.TotalShares.Amount = p.PoolCoinSupply
+	return pool
+}
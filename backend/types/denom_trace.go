@@ -0,0 +1,84 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// IBCDenomPrefix - Τα IBC vouchers εμφανίζονται ως "ibc/<SHA-256 του denom trace>"
+const IBCDenomPrefix = "ibc/"
+
+// DenomTraceHop - Ένα βήμα port/channel του IBC path, από την αλυσίδα μας προς την πηγή
+type DenomTraceHop struct {
+	PortID            string `json:"port_id"`
+	ChannelID         string `json:"channel_id"`
+	CounterpartyChain string `json:"counterparty_chain,omitempty"` // Αν είναι γνωστή από το assetlist
+}
+
+// DenomTrace - Η προέλευση ενός denom: IBC path, base denom και αλυσίδα προέλευσης
+type DenomTrace struct {
+	Denom         string          `json:"denom"`
+	Path          string          `json:"path"`       // "transfer/channel-0" (κενό για native denoms)
+	BaseDenom     string          `json:"base_denom"` // Το denom στην αλυσίδα προέλευσης
+	Hops          []DenomTraceHop `json:"hops"`
+	OriginChain   string          `json:"origin_chain,omitempty"`
+	Symbol        string          `json:"symbol,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	Native        bool            `json:"native"`
+	ComputedDenom string          `json:"computed_denom,omitempty"` // ibc/ + SHA-256(path/base_denom)
+	Verified      bool            `json:"verified"`                 // ComputedDenom == Denom
+	Source        string          `json:"source"`                   // "assetlist", "lcd" ή "native"
+}
+
+// IsIBCDenom - Το denom είναι IBC voucher;
+func IsIBCDenom(denom string) bool {
+	return strings.HasPrefix(denom, IBCDenomPrefix)
+}
+
+// NormalizeIBCDenom - "ibc/<hash>" με κεφαλαίο hash· ένα σκέτο hash 64 χαρακτήρων
+// θεωρείται επίσης IBC denom
+func NormalizeIBCDenom(denom string) string {
+	hash := strings.TrimPrefix(denom, IBCDenomPrefix)
+	if hash == denom && !isHexHash(hash) {
+		return denom
+	}
+	return IBCDenomPrefix + strings.ToUpper(hash)
+}
+
+// IBCDenom υπολογίζει το "ibc/HASH" για ένα path ("transfer/channel-0") και base denom.
+// Χωρίς path το denom είναι native και επιστρέφεται ως έχει.
+func IBCDenom(path, baseDenom string) string {
+	if path == "" {
+		return baseDenom
+	}
+	return IBCDenomFromFullPath(path + "/" + baseDenom)
+}
+
+// IBCDenomFromFullPath - Όπως το IBCDenom, για το πλήρες trace ("transfer/channel-0/uatom")
+func IBCDenomFromFullPath(fullPath string) string {
+	hash := sha256.Sum256([]byte(fullPath))
+	return IBCDenomPrefix + strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// ParseDenomTracePath χωρίζει ένα πλήρες trace σε path και base denom. Το path είναι
+// τα αρχικά ζεύγη port/channel-N· ό,τι ακολουθεί (και μπορεί να περιέχει "/") είναι το base.
+func ParseDenomTracePath(fullPath string) (path, baseDenom string, hops []DenomTraceHop) {
+	segments := strings.Split(fullPath, "/")
+
+	i := 0
+	for i+1 < len(segments) && segments[i] != "" && strings.HasPrefix(segments[i+1], "channel-") {
+		hops = append(hops, DenomTraceHop{PortID: segments[i], ChannelID: segments[i+1]})
+		i += 2
+	}
+
+	return strings.Join(segments[:i], "/"), strings.Join(segments[i:], "/"), hops
+}
+
+func isHexHash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIBCDenom(t *testing.T) {
+	tests := []struct {
+		path, baseDenom string
+		want            string
+	}{
+		// Το γνωστό ATOM της Osmosis
+		{"transfer/channel-0", "uatom", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+		{"transfer/channel-141/transfer/channel-0", "uatom", "ibc/DC3546F9ADCB15B45CA5787D9755FC1BDD768173E55025420A217B90F6F6F625"},
+		{"transfer/channel-0", "gamm/pool/1", "ibc/7F41B447119AF42B316F512BBF08CA43922D509703887AEFD9F02BBE7CC92B18"},
+		{"transfer/channel-208/transfer/channel-2", "factory/osmo1abc/ufoo", "ibc/EF0C368CC3C47958251364A905E769C27981657342ECC10CDBA5A361715F83C4"},
+		{"", "uosmo", "uosmo"},
+	}
+	for _, tt := range tests {
+		if got := IBCDenom(tt.path, tt.baseDenom); got != tt.want {
+			t.Errorf("IBCDenom(%q, %q) = %s, want %s", tt.path, tt.baseDenom, got, tt.want)
+		}
+		if tt.path != "" {
+			if got := IBCDenomFromFullPath(tt.path + "/" + tt.baseDenom); got != tt.want {
+				t.Errorf("IBCDenomFromFullPath(%q) = %s, want %s", tt.path+"/"+tt.baseDenom, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestParseDenomTracePath(t *testing.T) {
+	tests := []struct {
+		fullPath  string
+		path      string
+		baseDenom string
+		hops      []DenomTraceHop
+	}{
+		{"uatom", "", "uatom", nil},
+		{"transfer/channel-0/uatom", "transfer/channel-0", "uatom",
+			[]DenomTraceHop{{PortID: "transfer", ChannelID: "channel-0"}}},
+		{"transfer/channel-141/transfer/channel-0/uatom", "transfer/channel-141/transfer/channel-0", "uatom",
+			[]DenomTraceHop{{PortID: "transfer", ChannelID: "channel-141"}, {PortID: "transfer", ChannelID: "channel-0"}}},
+		// Base denoms με "/" μένουν ακέραια
+		{"transfer/channel-0/gamm/pool/1", "transfer/channel-0", "gamm/pool/1",
+			[]DenomTraceHop{{PortID: "transfer", ChannelID: "channel-0"}}},
+		{"transfer/channel-208/transfer/channel-2/factory/osmo1abc/ufoo", "transfer/channel-208/transfer/channel-2", "factory/osmo1abc/ufoo",
+			[]DenomTraceHop{{PortID: "transfer", ChannelID: "channel-208"}, {PortID: "transfer", ChannelID: "channel-2"}}},
+		{"factory/osmo1abc/ufoo", "", "factory/osmo1abc/ufoo", nil},
+		// Ports εκτός του transfer (π.χ. CosmWasm IBC)
+		{"wasm.osmo1contract/channel-5/cw20:juno1token", "wasm.osmo1contract/channel-5", "cw20:juno1token",
+			[]DenomTraceHop{{PortID: "wasm.osmo1contract", ChannelID: "channel-5"}}},
+		// Κενό port δεν είναι hop
+		{"/channel-0/uatom", "", "/channel-0/uatom", nil},
+	}
+	for _, tt := range tests {
+		path, baseDenom, hops := ParseDenomTracePath(tt.fullPath)
+		if path != tt.path || baseDenom != tt.baseDenom || !reflect.DeepEqual(hops, tt.hops) {
+			t.Errorf("ParseDenomTracePath(%q) = %q, %q, %+v; want %q, %q, %+v",
+				tt.fullPath, path, baseDenom, hops, tt.path, tt.baseDenom, tt.hops)
+		}
+		// Το path και το base ξαναδίνουν το ίδιο hash
+		if tt.path != "" && IBCDenom(path, baseDenom) != IBCDenomFromFullPath(tt.fullPath) {
+			t.Errorf("%q: path and base denom hash differently", tt.fullPath)
+		}
+	}
+}
+
+func TestNormalizeIBCDenom(t *testing.T) {
+	const atom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	tests := []struct {
+		denom, want string
+	}{
+		{atom, atom},
+		{"ibc/27394fb092d2eccd56123c74f36e4c1f926001ceada9ca97ea622b25f41e5eb2", atom},
+		{"27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", atom},
+		{"uosmo", "uosmo"},
+		{"factory/osmo1abc/ufoo", "factory/osmo1abc/ufoo"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeIBCDenom(tt.denom); got != tt.want {
+			t.Errorf("NormalizeIBCDenom(%q) = %s, want %s", tt.denom, got, tt.want)
+		}
+	}
+	if !IsIBCDenom(atom) || IsIBCDenom("uosmo") {
+		t.Error("IsIBCDenom")
+	}
+}
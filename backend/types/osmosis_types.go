@@ -1,10 +1,51 @@
 package types
 
-// OsmosisPool represents a liquidity pool in the Osmosis blockchain
+import "strings"
+
+// Osmosis pool types (το πεδίο "@type" των pools)
+const (
+	PoolTypeBalancer     = "balancer"
+	PoolTypeStableswap   = "stableswap"
+	PoolTypeConcentrated = "concentrated"
+	PoolTypeCosmWasm     = "cosmwasm"
+	PoolTypeXYK          = "xyk" // Constant-product pools άλλων DEX (Crescent basic, Astroport/Terraswap xyk)
+	PoolTypeUnknown      = "unknown"
+)
+
+// PoolTypeFromAtType maps an Osmosis "@type" URL (or the pool type of another DEX) to a short pool type
+func PoolTypeFromAtType(atType string) string {
+	switch {
+	case strings.Contains(atType, "concentratedliquidity"):
+		return PoolTypeConcentrated
+	case strings.Contains(atType, "stable"):
+		return PoolTypeStableswap
+	case atType == CrescentPoolTypeBasic || atType == PoolTypeXYK:
+		return PoolTypeXYK
+	case strings.Contains(atType, "cosmwasmpool"):
+		return PoolTypeCosmWasm
+	case strings.Contains(atType, "gamm"):
+		return PoolTypeBalancer
+	default:
+		return PoolTypeUnknown
+	}
+}
+
+// ChainPoolID - Pool IDs άλλων αλυσίδων παίρνουν το όνομα της αλυσίδας ως prefix ("crescent:12"),
+// ώστε να μη συγκρούονται με τα Osmosis pools στο ίδιο storage
+func ChainPoolID(chain, id string) string {
+	if chain == "" || chain == DefaultChain {
+		return id
+	}
+	return chain + ":" + id
+}
+
+// OsmosisPool represents a liquidity pool in the Osmosis blockchain. Pools άλλων DEX
+// μετατρέπονται στην ίδια μορφή (βλ. CrescentPool, CosmWasmPairPool) με το Chain συμπληρωμένο.
 type OsmosisPool struct {
 	Type       string `json:"@type"`
 	Address    string `json:"address"`
 	Id         string `json:"id"`
+	Chain      string `json:"chain,omitempty"`
 	PoolParams struct {
 		SwapFee                  string      `json:"swap_fee"`
 		ExitFee                  string      `json:"exit_fee"`
@@ -15,7 +56,35 @@ type OsmosisPool struct {
 		Denom  string `json:"denom"`
 		Amount string `json:"amount"`
 	} `json:"total_shares"`
-	PoolAssets []BasicPoolAsset `json:"pool_assets"`
+	PoolAssets  []BasicPoolAsset `json:"pool_assets"`
+	TotalWeight string           `json:"total_weight"`
+
+	// Stableswap πεδία: τα reserves έρχονται στο pool_liquidity αντί για pool_assets
+	PoolLiquidity           []BasicCoin `json:"pool_liquidity,omitempty"`
+	ScalingFactors          []string    `json:"scaling_factors,omitempty"`
+	ScalingFactorController string      `json:"scaling_factor_controller,omitempty"`
+	Amplification           string      `json:"amplification,omitempty"` // Curve amp των stable pairs άλλων DEX (Astroport config)
+
+	// Concentrated liquidity: συμπληρώνεται από το ConcentratedPool.ToOsmosisPool
+	Concentrated *ConcentratedState `json:"concentrated,omitempty"`
+}
+
+// NormalizeAssets γεμίζει τα PoolAssets από το PoolLiquidity για stableswap pools,
+// ώστε όλα τα pools να εκθέτουν τα reserves τους με τον ίδιο τρόπο
+func (p *OsmosisPool) NormalizeAssets() {
+	if len(p.PoolAssets) > 0 || len(p.PoolLiquidity) == 0 {
+		return
+	}
+
+	p.PoolAssets = make([]BasicPoolAsset, 0, len(p.PoolLiquidity))
+	for _, coin := range p.PoolLiquidity {
+		p.PoolAssets = append(p.PoolAssets, BasicPoolAsset{Token: coin})
+	}
+}
+
+// PoolType returns the short pool type (balancer, stableswap, concentrated, ...)
+func (p OsmosisPool) PoolType() string {
+	return PoolTypeFromAtType(p.Type)
 }
 
 func (p OsmosisPool) GetId() string {
@@ -42,9 +111,40 @@ type OsmosisSwapRoute struct {
 	TokenOutDenom string `json:"token_out_denom"`
 }
 
-// OsmosisRouteResponse represents the best route for a swap
+// OsmosisRouteHop - Ένα swap μιας διαδρομής με τα ποσά του στο προτεινόμενο split
+type OsmosisRouteHop struct {
+	OsmosisSwapRoute
+	PoolType       string  `json:"pool_type"`
+	TokenInSymbol  string  `json:"token_in_symbol,omitempty"`
+	TokenOutSymbol string  `json:"token_out_symbol,omitempty"`
+	AmountIn       string  `json:"amount_in"`  // Base units
+	AmountOut      string  `json:"amount_out"` // Base units
+	Fee            string  `json:"fee"`        // Base units του token in
+	SwapFee        float64 `json:"swap_fee"`
+	SpotPrice      float64 `json:"spot_price"`   // Token out ανά token in (base units) πριν το swap
+	PriceImpact    float64 `json:"price_impact"` // Χωρίς το fee (0.01 = 1%)
+}
+
+// OsmosisSplitRoute - Μία από τις παράλληλες διαδρομές ενός swap
+type OsmosisSplitRoute struct {
+	Hops      []OsmosisRouteHop `json:"hops"`
+	Share     float64           `json:"share"` // Κλάσμα του token in που περνά από αυτή τη διαδρομή
+	AmountIn  string            `json:"amount_in"`
+	AmountOut string            `json:"amount_out"`
+}
+
+// OsmosisRouteResponse represents the best route for a swap: μία ή περισσότερες παράλληλες
+// διαδρομές, το αναμενόμενο output και το ελάχιστο output για το slippage tolerance
 type OsmosisRouteResponse struct {
-	Routes []OsmosisSwapRoute `json:"routes"`
+	Routes               []OsmosisSplitRoute `json:"routes"`
+	TokenIn              BasicCoin           `json:"token_in"`
+	TokenOutDenom        string              `json:"token_out_denom"`
+	AmountOut            string              `json:"amount_out"`              // Αναμενόμενο output (base units)
+	MinAmountOut         string              `json:"min_amount_out"`          // AmountOut × (1 - slippage), προς τα κάτω
+	SlippageTolerance    float64             `json:"slippage_tolerance"`      // 0.005 = 0.5%
+	EffectivePrice       float64             `json:"effective_price"`         // Token out ανά token in (base units)
+	PriceImpact          float64             `json:"price_impact"`            // Συνολικό, χωρίς τα fees
+	SingleRouteAmountOut string              `json:"single_route_amount_out"` // Η καλύτερη διαδρομή χωρίς split
 }
 
 // OsmosisSpotPriceRequest represents a request for spot price
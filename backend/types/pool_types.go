@@ -1,5 +1,12 @@
 package types
 
+import (
+	"fmt"
+	"time"
+
+	"portofoliov1/decimal"
+)
+
 // Generic Pool Types - these are generic interfaces for all DEXs
 type IPool interface {
 	GetId() string
@@ -64,13 +71,149 @@ type SpotPriceTick struct {
 
 // Για το concentrated liquidity
 type ConcentratedPool struct {
-	Id               string `json:"id"`
-	CurrentSqrtPrice string `json:"current_sqrt_price"`
-	CurrentTick      int64  `json:"current_tick"`
-	Token0           string `json:"token0"`
-	Token1           string `json:"token1"`
-	TickSpacing      uint64 `json:"tick_spacing"`
-	ExponentAtPrice  int32  `json:"exponent_at_price"`
+	Type                 string `json:"@type"`
+	Address              string `json:"address"`
+	Id                   string `json:"id"`
+	CurrentTickLiquidity string `json:"current_tick_liquidity"`
+	CurrentSqrtPrice     string `json:"current_sqrt_price"`
+	CurrentTick          int64  `json:"current_tick,string"`
+	Token0               string `json:"token0"`
+	Token1               string `json:"token1"`
+	TickSpacing          uint64 `json:"tick_spacing,string"`
+	ExponentAtPrice      int32  `json:"exponent_at_price_one,string"`
+	SpreadFactor         string `json:"spread_factor"`
+}
+
+// SpotPrice αποκωδικοποιεί το CurrentSqrtPrice σε τιμή (token1 ανά token0, base units)
+func (p ConcentratedPool) SpotPrice() (decimal.Dec, error) {
+	sqrtPrice, err := decimal.Parse(p.CurrentSqrtPrice)
+	if err != nil {
+		return decimal.Dec{}, fmt.Errorf("invalid sqrt price for pool %s: %w", p.Id, err)
+	}
+	return sqrtPrice.Mul(sqrtPrice), nil
+}
+
+// clExponentAtPriceOne - Το exponent_at_price_one των CL pools του Osmosis (σταθερό -6
+// από τότε που το πεδίο αφαιρέθηκε από το pool)
+const clExponentAtPriceOne = -6
+
+// TickToPrice - Η τιμή (token1 ανά token0) στο κάτω όριο ενός tick, όπως το TickToPrice του
+// x/concentrated-liquidity: ανά 9·10^(-exponentAtPriceOne) ticks η τιμή δεκαπλασιάζεται και
+// μέσα σε κάθε δεκάδα κάθε tick προσθέτει 10^(exponentAtPriceOne + δεκάδα).
+func TickToPrice(tick int64, exponentAtPriceOne int32) decimal.Dec {
+	if exponentAtPriceOne == 0 {
+		exponentAtPriceOne = clExponentAtPriceOne
+	}
+	if tick == 0 {
+		return decimal.One()
+	}
+
+	ticksPerDecade := 9 * pow10Int64(int(-exponentAtPriceOne))
+	decade := tick / ticksPerDecade // Προς το 0, όπως το sdk.Int.Quo
+	exponentAtTick := int(exponentAtPriceOne) + int(decade)
+	if tick < 0 {
+		exponentAtTick--
+	}
+
+	additiveTicks := tick - decade*ticksPerDecade
+	return decimal.One().MulPow10(int(decade)).Add(decimal.NewFromInt(additiveTicks).MulPow10(exponentAtTick))
+}
+
+func pow10Int64(n int) int64 {
+	result := int64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+// ActiveRange - Τα ticks [lower, upper] γύρω από το τρέχον μέσα στα οποία η liquidity είναι
+// σίγουρα ίση με το CurrentTickLiquidity: οι θέσεις ξεκινούν και τελειώνουν μόνο σε
+// πολλαπλάσια του TickSpacing, οπότε η liquidity δεν αλλάζει μέσα σε ένα τέτοιο διάστημα.
+// Το πραγματικό εύρος μπορεί να είναι μεγαλύτερο· χωρίς τα ticks του pool δεν το ξέρουμε.
+func (p ConcentratedPool) ActiveRange() (int64, int64) {
+	spacing := int64(p.TickSpacing)
+	if spacing <= 0 {
+		spacing = 1
+	}
+	lower := p.CurrentTick / spacing * spacing
+	if p.CurrentTick < 0 && p.CurrentTick%spacing != 0 {
+		lower -= spacing // Floor για αρνητικά ticks
+	}
+	return lower, lower + spacing
+}
+
+// ToOsmosisPool μετατρέπει ένα CL pool σε OsmosisPool για αποθήκευση μαζί με τα gamm pools.
+// Τα PoolAssets είναι τα πραγματικά balances του pool (balances nil = άγνωστα, με ποσά 0)·
+// η τιμή και τα swaps υπολογίζονται από το Concentrated (βλ. api.ConcentratedModel).
+func (p ConcentratedPool) ToOsmosisPool(balances []BasicCoin) (OsmosisPool, error) {
+	sqrtPrice, err := decimal.Parse(p.CurrentSqrtPrice)
+	if err != nil || !sqrtPrice.IsPositive() {
+		return OsmosisPool{}, fmt.Errorf("invalid sqrt price for pool %s", p.Id)
+	}
+	liquidity, err := decimal.Parse(p.CurrentTickLiquidity)
+	if err != nil || liquidity.Sign() < 0 {
+		return OsmosisPool{}, fmt.Errorf("invalid tick liquidity for pool %s", p.Id)
+	}
+
+	lowerTick, upperTick := p.ActiveRange()
+	lowerSqrt, err := TickToPrice(lowerTick, p.ExponentAtPrice).Sqrt()
+	if err != nil {
+		return OsmosisPool{}, fmt.Errorf("invalid tick range for pool %s: %w", p.Id, err)
+	}
+	upperSqrt, err := TickToPrice(upperTick, p.ExponentAtPrice).Sqrt()
+	if err != nil {
+		return OsmosisPool{}, fmt.Errorf("invalid tick range for pool %s: %w", p.Id, err)
+	}
+
+	amounts := map[string]string{p.Token0: "0", p.Token1: "0"}
+	for _, coin := range balances {
+		if _, ok := amounts[coin.Denom]; ok {
+			amounts[coin.Denom] = coin.Amount
+		}
+	}
+
+	pool := OsmosisPool{
+		Type:    p.Type,
+		Address: p.Address,
+		Id:      p.Id,
+		PoolAssets: []BasicPoolAsset{
+			{Token: BasicCoin{Denom: p.Token0, Amount: amounts[p.Token0]}},
+			{Token: BasicCoin{Denom: p.Token1, Amount: amounts[p.Token1]}},
+		},
+		Concentrated: &ConcentratedState{
+			Token0:               p.Token0,
+			Token1:               p.Token1,
+			CurrentSqrtPrice:     sqrtPrice.String(),
+			CurrentTickLiquidity: liquidity.String(),
+			CurrentTick:          p.CurrentTick,
+			TickSpacing:          p.TickSpacing,
+			LowerTick:            lowerTick,
+			UpperTick:            upperTick,
+			LowerSqrtPrice:       lowerSqrt.String(),
+			UpperSqrtPrice:       upperSqrt.String(),
+			BalancesKnown:        balances != nil,
+		},
+	}
+	pool.PoolParams.SwapFee = p.SpreadFactor
+	return pool, nil
+}
+
+// ConcentratedState - Η κατάσταση ενός CL pool που χρειάζεται για τιμή και swaps. Μέσα στο
+// [LowerSqrtPrice, UpperSqrtPrice] το pool συμπεριφέρεται σαν constant-product με virtual
+// reserves x = L/√P, y = L·√P· αυτά δεν είναι balances και δεν χρησιμοποιούνται ως τέτοια.
+type ConcentratedState struct {
+	Token0               string `json:"token0"`
+	Token1               string `json:"token1"`
+	CurrentSqrtPrice     string `json:"current_sqrt_price"`
+	CurrentTickLiquidity string `json:"current_tick_liquidity"`
+	CurrentTick          int64  `json:"current_tick"`
+	TickSpacing          uint64 `json:"tick_spacing"`
+	LowerTick            int64  `json:"lower_tick"` // Βλ. ConcentratedPool.ActiveRange
+	UpperTick            int64  `json:"upper_tick"`
+	LowerSqrtPrice       string `json:"lower_sqrt_price"`
+	UpperSqrtPrice       string `json:"upper_sqrt_price"`
+	BalancesKnown        bool   `json:"balances_known"` // false: τα PoolAssets είναι 0 γιατί τα balances δεν έχουν ληφθεί ακόμα
 }
 
 // Για τα swaps
@@ -80,9 +223,12 @@ type SwapAmount struct {
 }
 
 type SimulateSwapResponse struct {
-	TokenIn  BasicCoin `json:"token_in"`
-	TokenOut BasicCoin `json:"token_out"`
-	Fee      BasicCoin `json:"fee"`
+	TokenIn        BasicCoin `json:"token_in"`
+	TokenOut       BasicCoin `json:"token_out"`
+	Fee            BasicCoin `json:"fee"`
+	SpotPrice      float64   `json:"spot_price,omitempty"`      // TokenOut ανά TokenIn πριν το swap (base units)
+	EffectivePrice float64   `json:"effective_price,omitempty"` // TokenOut ανά TokenIn που πραγματικά λαμβάνεται
+	PriceImpact    float64   `json:"price_impact,omitempty"`    // Απόκλιση από το spot price (0.01 = 1%), χωρίς το fee
 }
 
 // Για τα pool statistics
@@ -94,3 +240,16 @@ type PoolStats struct {
 	TVL       float64            `json:"tvl"`
 	TokenAPRs map[string]float64 `json:"token_aprs"`
 }
+
+// PoolFetchReport - Πόσο πλήρης ήταν η τελευταία λήψη pools από ένα endpoint
+type PoolFetchReport struct {
+	Source       string    `json:"source"`       // "gamm" ή "concentrated"
+	Total        int       `json:"total"`        // pagination.total (0 αν ο node δεν το δηλώνει)
+	Fetched      int       `json:"fetched"`      // Pools που λήφθηκαν
+	Pages        int       `json:"pages"`        // Σελίδες που ζητήθηκαν
+	FailedPages  int       `json:"failed_pages"` // Σελίδες που απέτυχαν
+	Complete     bool      `json:"complete"`
+	Completeness float64   `json:"completeness"` // fetched / total (1 αν το total είναι άγνωστο και εξαντλήθηκε το next_key)
+	Duration     string    `json:"duration"`
+	Timestamp    time.Time `json:"timestamp"`
+}
@@ -0,0 +1,31 @@
+package types
+
+import "time"
+
+// PortfolioHolding - Ένα balance ενός wallet, με metadata από το assetlist και αξία από την cache τιμών
+type PortfolioHolding struct {
+	Denom         string  `json:"denom"`
+	Symbol        string  `json:"symbol"` // Το denom αν δεν υπάρχει στο assetlist
+	Name          string  `json:"name,omitempty"`
+	LogoURL       string  `json:"logo_url,omitempty"`
+	Amount        string  `json:"amount"` // Base units
+	Exponent      int     `json:"exponent"`
+	DisplayAmount string  `json:"display_amount"` // Amount / 10^Exponent, ακριβές
+	PriceUSD      float64 `json:"price_usd"`
+	PriceOSMO     float64 `json:"price_osmo"`
+	ValueUSD      float64 `json:"value_usd"`
+	ValueOSMO     float64 `json:"value_osmo"`
+	Priced        bool    `json:"priced"` // false αν το token δεν έχει τιμή στην cache
+	Share         float64 `json:"share"`  // ValueUSD / TotalUSD
+}
+
+// Portfolio - Τα balances ενός wallet αποτιμημένα σε USD και OSMO
+type Portfolio struct {
+	Address       string             `json:"address"`
+	Chain         string             `json:"chain"`
+	Holdings      []PortfolioHolding `json:"holdings"` // Μεγαλύτερη αξία πρώτα
+	TotalUSD      float64            `json:"total_usd"`
+	TotalOSMO     float64            `json:"total_osmo"`
+	UnpricedCount int                `json:"unpriced_count"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
@@ -4,25 +4,79 @@ import "time"
 
 // TokenPrice represents a price entry for a token
 type TokenPrice struct {
-	Symbol    string    `json:"symbol"`
-	Denom     string    `json:"denom"`
-	PriceUSD  float64   `json:"price_usd"`
-	PriceOSMO float64   `json:"price_osmo"` // νέο πεδίο
-	Timestamp time.Time `json:"timestamp"`
+	Symbol       string    `json:"symbol"`
+	Denom        string    `json:"denom"`
+	PriceUSD     float64   `json:"price_usd"`
+	PriceOSMO    float64   `json:"price_osmo"`    // νέο πεδίο
+	LiquidityUSD float64   `json:"liquidity_usd"` // Liquidity των pools που χρησιμοποιήθηκαν για την τιμή
+	Chain        string    `json:"chain"`
+	Timestamp    time.Time `json:"timestamp"`
 }
 
 // PoolPrice represents price data for a liquidity pool pair
 type PoolPrice struct {
 	PoolID              string    `json:"pool_id"`
+	ParentPoolID        string    `json:"parent_pool_id,omitempty"` // Το on-chain pool για ζεύγη από multi-asset pools
+	PoolAssetCount      int       `json:"pool_asset_count"`
+	PoolType            string    `json:"pool_type"` // balancer, stableswap, concentrated
 	Token0Symbol        string    `json:"token0_symbol"`
 	Token0Denom         string    `json:"token0_denom"`
 	Token0Amount        string    `json:"token0_amount"`
 	Token1Symbol        string    `json:"token1_symbol"`
 	Token1Denom         string    `json:"token1_denom"`
 	Token1Amount        string    `json:"token1_amount"`
-	PriceOSMO           float64   `json:"price_osmo"`             // Τιμή του Token0 σε Token1
-	PriceToken0ToToken1 float64   `json:"price_token0_to_token1"` // Τιμή Token0 -> Token1
-	PriceToken1ToToken0 float64   `json:"price_token1_to_token0"` // Τιμή Token1 -> Token0
-	LiquidityUSD        float64   `json:"liquidity_usd"`          // Total liquidity in USD
+	PriceOSMO           float64   `json:"price_osmo"`                             // Τιμή του Token0 σε Token1
+	PriceToken0ToToken1 float64   `json:"price_token0_to_token1"`                 // Τιμή Token0 -> Token1
+	PriceToken1ToToken0 float64   `json:"price_token1_to_token0"`                 // Τιμή Token1 -> Token0
+	Price0To1Exact      string    `json:"price_token0_to_token1_exact,omitempty"` // Ακριβές δεκαδικό (36 ψηφία)· κενό αν η τιμή είναι 0
+	Price1To0Exact      string    `json:"price_token1_to_token0_exact,omitempty"` // Ακριβές δεκαδικό της αντίστροφης τιμής
+	Token0Weight        float64   `json:"token0_weight"`                          // Κανονικοποιημένο weight του Token0 (0.8 = 80%)
+	Token1Weight        float64   `json:"token1_weight"`                          // Κανονικοποιημένο weight του Token1
+	LiquidityUSD        float64   `json:"liquidity_usd"`                          // Total liquidity in USD
+	LiquidityUnknown    bool      `json:"liquidity_unknown,omitempty"`            // Το TVL δεν είναι γνωστό (π.χ. CL pool χωρίς balances)· το LiquidityUSD είναι 0
+	Chain               string    `json:"chain"`
 	Timestamp           time.Time `json:"timestamp"`
 }
+
+// ConversionHop represents a single pool hop of a token conversion
+type ConversionHop struct {
+	PoolID     string  `json:"pool_id"`
+	FromSymbol string  `json:"from_symbol"`
+	FromDenom  string  `json:"from_denom"`
+	ToSymbol   string  `json:"to_symbol"`
+	ToDenom    string  `json:"to_denom"`
+	Rate       float64 `json:"rate"` // Πόσα ToSymbol δίνει 1 FromSymbol
+}
+
+// ConversionResult represents the outcome of a (multi-hop) token conversion
+type ConversionResult struct {
+	From            string          `json:"from"`
+	FromDenom       string          `json:"from_denom"`
+	To              string          `json:"to"`
+	ToDenom         string          `json:"to_denom"`
+	Amount          float64         `json:"amount"`
+	ConvertedAmount float64         `json:"converted_amount"`
+	Rate            float64         `json:"rate"` // Effective rate: 1 From = Rate To
+	PoolIDs         []string        `json:"pool_ids"`
+	Hops            []ConversionHop `json:"hops"`
+	Timestamp       time.Time       `json:"timestamp"`
+}
+
+// OnChainPoolID returns the on-chain pool ID (the parent pool for multi-asset pairs)
+func (p PoolPrice) OnChainPoolID() string {
+	if p.ParentPoolID != "" {
+		return p.ParentPoolID
+	}
+	return p.PoolID
+}
+
+// Candle represents one OHLCV bar aggregated from price snapshots
+type Candle struct {
+	Time      time.Time `json:"time"` // Start of the interval
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	VolumeUSD float64   `json:"volume_usd"` // Estimated from reserve changes between snapshots
+	Samples   int       `json:"samples"`
+}
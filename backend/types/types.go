@@ -16,10 +16,13 @@ type PoolResponse struct {
 		SwapFee     string `json:"swap_fee"`
 		ExitFee     string `json:"exit_fee"`
 	} `json:"pools"`
-	Pagination struct {
-		NextKey string `json:"next_key"`
-		Total   string `json:"total"`
-	} `json:"pagination"`
+	Pagination PageResponse `json:"pagination"`
+}
+
+// PageResponse - Το pagination block των Cosmos SDK LCD responses
+type PageResponse struct {
+	NextKey string `json:"next_key"`
+	Total   string `json:"total"` // Μόνο όταν ζητηθεί pagination.count_total=true
 }
 
 // Μετατροπή από PoolResponse σε BasicPool
@@ -1,33 +1,95 @@
 package utils
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultChainRegistryURL - Raw αρχεία του cosmos/chain-registry στο GitHub
+const DefaultChainRegistryURL = "https://raw.githubusercontent.com/cosmos/chain-registry/master"
+
+// lastUpdateLayout - Η μορφή του .last_update (ίδια με το παλιό PowerShell script)
+const lastUpdateLayout = "2006-01-02 15:04:05"
+
+// maxRegistryFileSize - Όριο μεγέθους ανά αρχείο (το osmosis assetlist είναι ~1.5 MB)
+const maxRegistryFileSize = 32 << 20
+
+// registryFile - Ένα αρχείο ανά αλυσίδα· τα optional μπορεί να λείπουν από το registry
+type registryFile struct {
+	name     string
+	optional bool
+}
+
+var registryFiles = []registryFile{
+	{name: "assetlist.json"},
+	{name: "chain.json"},
+	{name: "versions.json", optional: true},
+}
+
+// ChainRegistryConfig - Από πού και για ποιες αλυσίδες κατεβαίνει το registry
+type ChainRegistryConfig struct {
+	BaseURL        string        // Default: DefaultChainRegistryURL (ένας τοπικός server για tests)
+	DataDir        string        // Default: data/chain-registry
+	Chains         []string      // Default: osmosis
+	UpdateInterval time.Duration // Default: 7 ημέρες
+}
+
 // ChainRegistryUpdater - Διαχειριστής ενημέρωσης chain registry
 type ChainRegistryUpdater struct {
-	scriptPath     string
+	baseURL        string
+	dataDir        string
+	chains         []string
+	httpClient     *http.Client
 	updateInterval time.Duration
 	lastUpdateFile string
 	isRunning      bool
 	stopChan       chan bool
+	ctx            context.Context
+	cancel         context.CancelFunc
+	updateMu       sync.Mutex // Μία ενημέρωση τη φορά (loop και /api/chain-registry/update)
+	listeners      []func(chains []string)
 }
 
-// NewChainRegistryUpdater - Δημιουργία νέου updater
+// NewChainRegistryUpdater - Δημιουργία νέου updater για το osmosis
 func NewChainRegistryUpdater() *ChainRegistryUpdater {
-	scriptPath := filepath.Join("scripts", "update-chain-registry-full.ps1")
-	lastUpdateFile := filepath.Join("data", "chain-registry", ".last_update")
+	return NewChainRegistryUpdaterWithConfig(ChainRegistryConfig{})
+}
+
+// NewChainRegistryUpdaterWithConfig - Updater με δικό του base URL, φάκελο και αλυσίδες
+func NewChainRegistryUpdaterWithConfig(cfg ChainRegistryConfig) *ChainRegistryUpdater {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultChainRegistryURL
+	}
+	if cfg.DataDir == "" {
+		cfg.DataDir = filepath.Join("data", "chain-registry")
+	}
+	if len(cfg.Chains) == 0 {
+		cfg.Chains = []string{"osmosis"}
+	}
+	if cfg.UpdateInterval <= 0 {
+		cfg.UpdateInterval = 7 * 24 * time.Hour
+	}
 
 	return &ChainRegistryUpdater{
-		scriptPath:     scriptPath,
-		updateInterval: 7 * 24 * time.Hour,
-		lastUpdateFile: lastUpdateFile,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		dataDir: cfg.DataDir,
+		chains:  cfg.Chains,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		updateInterval: cfg.UpdateInterval,
+		lastUpdateFile: filepath.Join(cfg.DataDir, ".last_update"),
 		stopChan:       make(chan bool),
 	}
 }
@@ -39,6 +101,8 @@ func (u *ChainRegistryUpdater) Start() error {
 	}
 
 	u.isRunning = true
+	u.stopChan = make(chan bool)
+	u.ctx, u.cancel = context.WithCancel(context.Background())
 
 	// Τρέξε αμέσως την πρώτη φορά
 	log.Println("🔄 Έλεγχος chain-registry...")
@@ -59,7 +123,10 @@ func (u *ChainRegistryUpdater) Stop() {
 	}
 
 	u.isRunning = false
-	u.stopChan <- true
+	// Ακυρώνει και μια ενημέρωση που είναι σε εξέλιξη
+	u.cancel()
+	// close αντί για send: δεν μπλοκάρει αν το loop είναι στη μέση μιας ενημέρωσης
+	close(u.stopChan)
 }
 
 // updateLoop - Loop για αυτόματες ενημερώσεις
@@ -81,7 +148,7 @@ func (u *ChainRegistryUpdater) updateLoop() {
 	}
 }
 
-// RunUpdate - Εκτέλεση του PowerShell script
+// RunUpdate - Ενημέρωση των αλυσίδων αν πέρασε το updateInterval από την τελευταία
 func (u *ChainRegistryUpdater) RunUpdate() error {
 	// Έλεγχος αν χρειάζεται ενημέρωση
 	if !u.needsUpdate() {
@@ -89,83 +156,220 @@ func (u *ChainRegistryUpdater) RunUpdate() error {
 		return nil
 	}
 
-	log.Println("📥 Ενημέρωση chain-registry...")
+	return u.update()
+}
+
+// update κατεβάζει τα αρχεία κάθε αλυσίδας. Μια αλυσίδα που αποτυγχάνει κρατά τα
+// προηγούμενα αρχεία της· το .last_update γράφεται αν ενημερώθηκε έστω μία.
+func (u *ChainRegistryUpdater) update() error {
+	u.updateMu.Lock()
+	defer u.updateMu.Unlock()
+
+	log.Printf("📥 Ενημέρωση chain-registry από %s...", u.baseURL)
+
+	ctx := u.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var updated []string
+	var failed int
+	for _, chain := range u.chains {
+		if err := u.updateChain(ctx, chain); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			log.Printf("   ❌ %s: %v", chain, err)
+			continue
+		}
+		updated = append(updated, chain)
+		log.Printf("   ✅ %s", chain)
+	}
 
-	// Έλεγχος αν υπάρχει το script
-	if _, err := os.Stat(u.scriptPath); os.IsNotExist(err) {
-		return fmt.Errorf("το script δεν βρέθηκε: %s", u.scriptPath)
+	if len(updated) == 0 {
+		return fmt.Errorf("καμία αλυσίδα δεν ενημερώθηκε (%d αποτυχίες)", failed)
 	}
 
-	// Εκτέλεση PowerShell script
-	cmd := exec.Command("powershell.exe", "-ExecutionPolicy", "Bypass", "-File", u.scriptPath)
+	now := time.Now().Format(lastUpdateLayout)
+	if err := writeFileAtomic(u.lastUpdateFile, []byte(now)); err != nil {
+		return fmt.Errorf("σφάλμα εγγραφής του %s: %w", u.lastUpdateFile, err)
+	}
+
+	log.Printf("✅ Chain registry ενημερώθηκε επιτυχώς (%d αλυσίδες, %d αποτυχίες)", len(updated), failed)
 
-	// Capture output
-	output, err := cmd.CombinedOutput()
+	for _, listener := range u.listeners {
+		listener(updated)
+	}
+	return nil
+}
+
+// OnUpdate - Εγγραφή listener που καλείται με τις αλυσίδες που ενημερώθηκαν,
+// μετά από κάθε επιτυχημένη ενημέρωση (π.χ. για reload των AssetServices)
+func (u *ChainRegistryUpdater) OnUpdate(listener func(chains []string)) {
+	u.updateMu.Lock()
+	defer u.updateMu.Unlock()
+
+	u.listeners = append(u.listeners, listener)
+}
+
+// updateChain κατεβάζει και ελέγχει όλα τα αρχεία της αλυσίδας πριν γράψει οποιοδήποτε,
+// ώστε ο φάκελος να μη μείνει με assetlist και chain.json από διαφορετικές εκδόσεις
+func (u *ChainRegistryUpdater) updateChain(ctx context.Context, chain string) error {
+	if chain == "" || strings.ContainsAny(chain, `/\`) || strings.HasPrefix(chain, ".") {
+		return fmt.Errorf("μη έγκυρο όνομα αλυσίδας: %q", chain)
+	}
+
+	files := make(map[string][]byte)
+	for _, file := range registryFiles {
+		data, err := u.download(ctx, chain, file.name)
+		if errors.Is(err, errRegistryFileNotFound) && file.optional {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+		if err := validateRegistryFile(chain, file.name, data); err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+		files[file.name] = data
+	}
+
+	chainDir := filepath.Join(u.dataDir, chain)
+	if err := os.MkdirAll(chainDir, 0755); err != nil {
+		return err
+	}
+	for _, file := range registryFiles {
+		data, ok := files[file.name]
+		if !ok {
+			continue
+		}
+		if err := writeFileAtomic(filepath.Join(chainDir, file.name), data); err != nil {
+			return fmt.Errorf("σφάλμα εγγραφής του %s: %w", file.name, err)
+		}
+	}
+
+	return nil
+}
+
+// errRegistryFileNotFound - 404 από το registry (αποδεκτό για optional αρχεία)
+var errRegistryFileNotFound = errors.New("δεν βρέθηκε στο registry")
+
+// download ζητά το <baseURL>/<chain>/<file>
+func (u *ChainRegistryUpdater) download(ctx context.Context, chain, file string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/%s", u.baseURL, chain, file), nil)
 	if err != nil {
-		return fmt.Errorf("σφάλμα εκτέλεσης script: %v\nOutput: %s", err, string(output))
+		return nil, err
 	}
+	req.Header.Set("User-Agent", "portofoliov1-chain-registry-updater")
 
-	log.Printf("📋 Script output:\n%s", string(output))
-	log.Println("✅ Chain registry ενημερώθηκε επιτυχώς")
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errRegistryFileNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
 
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRegistryFileSize {
+		return nil, fmt.Errorf("το αρχείο ξεπερνά τα %d bytes", maxRegistryFileSize)
+	}
+	return data, nil
+}
+
+// validateRegistryFile - Έγκυρο JSON που ανήκει στην αλυσίδα· το assetlist πρέπει να έχει assets
+func validateRegistryFile(chain, file string, data []byte) error {
+	var content struct {
+		ChainName string            `json:"chain_name"`
+		Assets    []json.RawMessage `json:"assets"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return fmt.Errorf("μη έγκυρο JSON: %w", err)
+	}
+	if content.ChainName != chain {
+		return fmt.Errorf("chain_name %q αντί για %q", content.ChainName, chain)
+	}
+	if file == "assetlist.json" && len(content.Assets) == 0 {
+		return fmt.Errorf("το assetlist δεν έχει assets")
+	}
 	return nil
 }
 
-// needsUpdate - Έλεγχος αν χρειάζεται ενημέρωση
-func (u *ChainRegistryUpdater) needsUpdate() bool {
-	// Αν δεν υπάρχει το αρχείο, χρειάζεται ενημέρωση
-	if _, err := os.Stat(u.lastUpdateFile); os.IsNotExist(err) {
-		return true
+// writeFileAtomic γράφει σε προσωρινό αρχείο στον ίδιο φάκελο και το μετονομάζει,
+// ώστε όποιος διαβάζει το αρχείο να βλέπει είτε την παλιά είτε τη νέα έκδοση
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
 	}
 
-	// Διάβασε την τελευταία ενημέρωση
-	data, err := os.ReadFile(u.lastUpdateFile)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
-		log.Printf("⚠️  Δεν μπόρεσε να διαβάσει last_update: %v", err)
-		return true
+		return err
 	}
+	defer os.Remove(tmp.Name()) // No-op μετά το rename
 
-	// Clean the string (remove \r\n)
-	timestampStr := string(data)
-	timestampStr = strings.TrimSpace(timestampStr)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
 
-	lastUpdate, err := time.Parse("2006-01-02 15:04:05", timestampStr)
+// needsUpdate - Έλεγχος αν χρειάζεται ενημέρωση
+func (u *ChainRegistryUpdater) needsUpdate() bool {
+	lastUpdate, err := u.GetLastUpdateTime()
 	if err != nil {
-		log.Printf("⚠️  Δεν μπόρεσε να parse την ημερομηνία: %v", err)
+		// Αν δεν υπάρχει ή δεν διαβάζεται το αρχείο, χρειάζεται ενημέρωση
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("⚠️  Δεν μπόρεσε να διαβάσει last_update: %v", err)
+		}
 		return true
 	}
 
-	// Αν πέρασαν πάνω από 7 ημέρες (1 εβδομάδα)
-	hoursSince := time.Since(lastUpdate).Hours()
-	return hoursSince >= (7 * 24)
+	return time.Since(lastUpdate) >= u.updateInterval
 }
 
-// ForceUpdate - Αναγκαστική ενημέρωση (αγνοώντας το 24ωρο)
+// ForceUpdate - Αναγκαστική ενημέρωση (αγνοώντας το updateInterval). Σε αποτυχία
+// το .last_update μένει ως έχει.
 func (u *ChainRegistryUpdater) ForceUpdate() error {
 	log.Println("🔄 Αναγκαστική ενημέρωση chain-registry...")
-
-	// Διαγραφή του last_update file για να αναγκάσουμε update
-	if err := os.Remove(u.lastUpdateFile); err != nil && !os.IsNotExist(err) {
-		log.Printf("⚠️  Δεν μπόρεσε να διαγράψει last_update: %v", err)
-	}
-
-	return u.RunUpdate()
+	return u.update()
 }
 
 // GetLastUpdateTime - Επιστρέφει την τελευταία ενημέρωση
 func (u *ChainRegistryUpdater) GetLastUpdateTime() (time.Time, error) {
-	if _, err := os.Stat(u.lastUpdateFile); os.IsNotExist(err) {
-		return time.Time{}, fmt.Errorf("δεν έχει γίνει ενημέρωση ακόμα")
-	}
-
 	data, err := os.ReadFile(u.lastUpdateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, fmt.Errorf("δεν έχει γίνει ενημέρωση ακόμα: %w", err)
+	}
 	if err != nil {
 		return time.Time{}, err
 	}
 
 	// Clean the string (remove \r\n)
-	timestampStr := string(data)
-	timestampStr = strings.TrimSpace(timestampStr)
+	timestampStr := strings.TrimSpace(string(data))
 
-	return time.Parse("2006-01-02 15:04:05", timestampStr)
+	// Η ώρα γράφεται σε τοπική ώρα, όπως και από το παλιό script
+	return time.ParseInLocation(lastUpdateLayout, timestampStr, time.Local)
 }
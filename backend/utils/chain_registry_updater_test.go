@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testAssetlist = `{"chain_name":"osmosis","assets":[{"base":"uosmo","symbol":"OSMO"}]}`
+	testChainJSON = `{"chain_name":"osmosis","apis":{"rest":[{"address":"https://lcd.example.com"}]}}`
+	testVersions  = `{"chain_name":"osmosis","versions":[]}`
+)
+
+// registryServer - Σερβίρει τα αρχεία του files (path -> περιεχόμενο)· ό,τι λείπει είναι 404
+func registryServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestUpdater(baseURL, dataDir string, chains ...string) *ChainRegistryUpdater {
+	return NewChainRegistryUpdaterWithConfig(ChainRegistryConfig{
+		BaseURL: baseURL,
+		DataDir: dataDir,
+		Chains:  chains,
+	})
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestChainRegistryUpdate(t *testing.T) {
+	server := registryServer(t, map[string]string{
+		"/osmosis/assetlist.json": testAssetlist,
+		"/osmosis/chain.json":     testChainJSON,
+		"/osmosis/versions.json":  testVersions,
+	})
+	dataDir := t.TempDir()
+	updater := newTestUpdater(server.URL+"/", dataDir, "osmosis")
+
+	var notified []string
+	updater.OnUpdate(func(chains []string) { notified = chains })
+
+	if err := updater.ForceUpdate(); err != nil {
+		t.Fatalf("ForceUpdate: %v", err)
+	}
+
+	for file, want := range map[string]string{
+		"assetlist.json": testAssetlist,
+		"chain.json":     testChainJSON,
+		"versions.json":  testVersions,
+	} {
+		if got := readTestFile(t, filepath.Join(dataDir, "osmosis", file)); got != want {
+			t.Errorf("%s = %s, want %s", file, got, want)
+		}
+	}
+
+	lastUpdate, err := updater.GetLastUpdateTime()
+	if err != nil {
+		t.Fatalf("GetLastUpdateTime: %v", err)
+	}
+	if time.Since(lastUpdate) > time.Minute {
+		t.Errorf("last update %v, want now", lastUpdate)
+	}
+	if updater.needsUpdate() {
+		t.Error("needsUpdate right after an update")
+	}
+	if len(notified) != 1 || notified[0] != "osmosis" {
+		t.Errorf("listener got %v, want [osmosis]", notified)
+	}
+}
+
+func TestChainRegistryUpdateKeepsOldFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		assetlist string
+	}{
+		{"invalid JSON", `{"chain_name":"osmosis","assets":[`},
+		{"wrong chain_name", `{"chain_name":"cosmoshub","assets":[{"base":"uatom"}]}`},
+		{"empty assetlist", `{"chain_name":"osmosis","assets":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := registryServer(t, map[string]string{
+				"/osmosis/assetlist.json":  tt.assetlist,
+				"/osmosis/chain.json":      testChainJSON,
+				"/crescent/assetlist.json": `{"chain_name":"crescent","assets":[{"base":"ucre"}]}`,
+				"/crescent/chain.json":     `{"chain_name":"crescent"}`,
+			})
+			dataDir := t.TempDir()
+
+			// Τα προηγούμενα αρχεία του osmosis
+			osmosisDir := filepath.Join(dataDir, "osmosis")
+			if err := os.MkdirAll(osmosisDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range []string{"assetlist.json", "chain.json"} {
+				if err := os.WriteFile(filepath.Join(osmosisDir, file), []byte("old "+file), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Το crescent ενημερώνεται, οπότε η ενημέρωση συνολικά πετυχαίνει
+			updater := newTestUpdater(server.URL, dataDir, "osmosis", "crescent")
+			if err := updater.ForceUpdate(); err != nil {
+				t.Fatalf("ForceUpdate: %v", err)
+			}
+
+			for _, file := range []string{"assetlist.json", "chain.json"} {
+				if got := readTestFile(t, filepath.Join(osmosisDir, file)); got != "old "+file {
+					t.Errorf("osmosis %s replaced with %q", file, got)
+				}
+			}
+			if got := readTestFile(t, filepath.Join(dataDir, "crescent", "chain.json")); got != `{"chain_name":"crescent"}` {
+				t.Errorf("crescent chain.json = %s", got)
+			}
+		})
+	}
+}
+
+func TestChainRegistryUpdateWithoutVersions(t *testing.T) {
+	server := registryServer(t, map[string]string{
+		"/osmosis/assetlist.json": testAssetlist,
+		"/osmosis/chain.json":     testChainJSON,
+	})
+	dataDir := t.TempDir()
+
+	if err := newTestUpdater(server.URL, dataDir, "osmosis").ForceUpdate(); err != nil {
+		t.Fatalf("ForceUpdate without versions.json: %v", err)
+	}
+	if got := readTestFile(t, filepath.Join(dataDir, "osmosis", "assetlist.json")); got != testAssetlist {
+		t.Errorf("assetlist.json = %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "osmosis", "versions.json")); !os.IsNotExist(err) {
+		t.Errorf("versions.json written although the registry has none (stat: %v)", err)
+	}
+}
+
+func TestChainRegistryUpdateAllChainsFail(t *testing.T) {
+	server := registryServer(t, map[string]string{
+		// Χωρίς chain.json, που δεν είναι optional
+		"/osmosis/assetlist.json": testAssetlist,
+	})
+	dataDir := t.TempDir()
+
+	lastUpdateFile := filepath.Join(dataDir, ".last_update")
+	previous := "2020-01-02 03:04:05"
+	if err := os.WriteFile(lastUpdateFile, []byte(previous), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	updater := newTestUpdater(server.URL, dataDir, "osmosis", "../etc")
+	var notified bool
+	updater.OnUpdate(func([]string) { notified = true })
+
+	if err := updater.ForceUpdate(); err == nil {
+		t.Fatal("ForceUpdate succeeded with every chain failing")
+	}
+	if got := readTestFile(t, lastUpdateFile); got != previous {
+		t.Errorf(".last_update = %q, want %q", got, previous)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "osmosis", "assetlist.json")); !os.IsNotExist(err) {
+		t.Errorf("assetlist.json written without chain.json (stat: %v)", err)
+	}
+	if notified {
+		t.Error("listener called after a failed update")
+	}
+}
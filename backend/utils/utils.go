@@ -86,9 +86,10 @@ func ConvertToOsmosisPools(poolResponses []types.PoolResponse) []types.OsmosisPo
 			}
 
 			pool := types.OsmosisPool{
-				Id:         p.ID.String(),
-				Type:       p.Type,
-				PoolAssets: poolAssets,
+				Id:          p.ID.String(),
+				Type:        p.Type,
+				PoolAssets:  poolAssets,
+				TotalWeight: p.TotalWeight,
 				PoolParams: struct {
 					SwapFee                  string      `json:"swap_fee"`
 					ExitFee                  string      `json:"exit_fee"`